@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"api/logger"
+	"api/metrics"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var ErrMiss = errors.New("cache miss")
+
+// Cache menyimpan nilai berdasarkan key selama ttl. Nilai disimpan sebagai
+// byte agar penyimpanan di luar proses (mis. Redis) bisa dipasang tanpa
+// mengubah pemakainya.
+type Cache interface {
+	// Get mengembalikan ErrMiss bila key tidak ada atau sudah kedaluwarsa.
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, val []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Load membaca key dari c ke dst dalam bentuk JSON. Bila key tidak ada, fn
+// dipanggil sekali untuk semua pemanggil bersamaan dengan key yang sama,
+// lalu hasilnya disimpan selama ttl. Error dari fn tidak disimpan. name
+// dipakai sebagai label metrics.
+func Load(ctx context.Context, c Cache, g *Group, name, key string, ttl time.Duration, dst interface{}, fn func() (interface{}, error)) error {
+	raw, err := c.Get(ctx, key)
+	if err == nil && json.Unmarshal(raw, dst) == nil {
+		metrics.CacheRequests.WithLabelValues(name, metrics.CacheHit).Inc()
+		return nil
+	}
+	if err != nil && !errors.Is(err, ErrMiss) {
+		logger.Warn(ctx, "baca cache gagal", logger.Fields{"error": err, "key": key})
+	}
+	metrics.CacheRequests.WithLabelValues(name, metrics.CacheMiss).Inc()
+
+	// hasil dibagi dalam bentuk byte agar setiap pemanggil mendapat salinan
+	// sendiri
+	v, err := g.Do(key, func() (interface{}, error) {
+		res, err := fn()
+		if err != nil {
+			return nil, err
+		}
+		raw, err := json.Marshal(res)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.Set(ctx, key, raw, ttl); err != nil {
+			logger.Warn(ctx, "simpan cache gagal", logger.Fields{"error": err, "key": key})
+		}
+		return raw, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(v.([]byte), dst)
+}
+
+// Invalidate menghapus keys, kegagalan hanya dicatat karena data tetap
+// kedaluwarsa setelah ttl.
+func Invalidate(ctx context.Context, c Cache, keys ...string) {
+	if err := c.Delete(ctx, keys...); err != nil {
+		logger.Warn(ctx, "hapus cache gagal", logger.Fields{"error": err, "keys": keys})
+	}
+}
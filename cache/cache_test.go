@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("entry lama dibuang saat penuh", func(t *testing.T) {
+		m := NewMemory(2)
+		require.NoError(t, m.Set(ctx, "a", []byte("1"), time.Minute))
+		require.NoError(t, m.Set(ctx, "b", []byte("2"), time.Minute))
+		_, err := m.Get(ctx, "a")
+		require.NoError(t, err)
+		require.NoError(t, m.Set(ctx, "c", []byte("3"), time.Minute))
+
+		_, err = m.Get(ctx, "b")
+		assert.ErrorIs(t, err, ErrMiss)
+		val, err := m.Get(ctx, "a")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("1"), val)
+	})
+
+	t.Run("kedaluwarsa dan dihapus", func(t *testing.T) {
+		now := time.Now()
+		m := NewMemory(10)
+		m.now = func() time.Time { return now }
+		require.NoError(t, m.Set(ctx, "a", []byte("1"), time.Minute))
+		require.NoError(t, m.Set(ctx, "b", []byte("2"), time.Hour))
+
+		now = now.Add(2 * time.Minute)
+		_, err := m.Get(ctx, "a")
+		assert.ErrorIs(t, err, ErrMiss)
+		_, err = m.Get(ctx, "b")
+		assert.NoError(t, err)
+
+		require.NoError(t, m.Delete(ctx, "b"))
+		_, err = m.Get(ctx, "b")
+		assert.ErrorIs(t, err, ErrMiss)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("pemanggil bersamaan hanya memuat sekali", func(t *testing.T) {
+		m := NewMemory(10)
+		g := &Group{}
+		var loads int32
+		release := make(chan struct{})
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res := map[string]int{}
+				err := Load(ctx, m, g, "test", "k", time.Minute, &res, func() (interface{}, error) {
+					atomic.AddInt32(&loads, 1)
+					<-release
+					return map[string]int{"n": 1}, nil
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, 1, res["n"])
+			}()
+		}
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&loads))
+
+		// pembacaan berikutnya diambil dari cache
+		res := map[string]int{}
+		err := Load(ctx, m, g, "test", "k", time.Minute, &res, func() (interface{}, error) {
+			t.Fatal("tidak boleh memuat ulang")
+			return nil, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, res["n"])
+	})
+
+	t.Run("error tidak disimpan", func(t *testing.T) {
+		m := NewMemory(10)
+		g := &Group{}
+		var res int
+
+		err := Load(ctx, m, g, "test", "k", time.Minute, &res, func() (interface{}, error) {
+			return nil, errors.New("data not found")
+		})
+		assert.ErrorContains(t, err, "not found")
+
+		err = Load(ctx, m, g, "test", "k", time.Minute, &res, func() (interface{}, error) {
+			return 7, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 7, res)
+	})
+}
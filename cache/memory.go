@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory adalah Cache di dalam proses dengan batas jumlah entry. Entry yang
+// paling lama tidak dipakai dibuang lebih dulu saat cache penuh.
+type Memory struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[string]*list.Element
+	now   func() time.Time
+}
+
+type entry struct {
+	key     string
+	val     []byte
+	expires time.Time
+}
+
+func NewMemory(size int) *Memory {
+	return &Memory{
+		size:  size,
+		ll:    list.New(),
+		items: map[string]*list.Element{},
+		now:   time.Now,
+	}
+}
+
+func (m *Memory) Get(ctx context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+	e := el.Value.(*entry)
+	if !m.now().Before(e.expires) {
+		m.remove(el)
+		return nil, ErrMiss
+	}
+	m.ll.MoveToFront(el)
+	return e.val, nil
+}
+
+func (m *Memory) Set(ctx context.Context, key string, val []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expires := m.now().Add(ttl)
+	if el, ok := m.items[key]; ok {
+		e := el.Value.(*entry)
+		e.val, e.expires = val, expires
+		m.ll.MoveToFront(el)
+		return nil
+	}
+
+	m.items[key] = m.ll.PushFront(&entry{key: key, val: val, expires: expires})
+	for m.ll.Len() > m.size {
+		m.remove(m.ll.Back())
+	}
+	return nil
+}
+
+func (m *Memory) Delete(ctx context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := m.items[key]; ok {
+			m.remove(el)
+		}
+	}
+	return nil
+}
+
+func (m *Memory) remove(el *list.Element) {
+	m.ll.Remove(el)
+	delete(m.items, el.Value.(*entry).key)
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"api/cache"
+	"time"
+)
+
+const (
+	defaultCacheSize = 10000
+	defaultCacheTTL  = time.Minute
+)
+
+// InitCache membuat cache sesuai konfigurasi, default cache di dalam
+// proses. Nil berarti cache dimatikan.
+func InitCache(ac AppConfig) cache.Cache {
+	if ac.Cache == "none" {
+		return nil
+	}
+
+	return cache.NewMemory(number("cache size", ac.CacheSize, defaultCacheSize))
+}
+
+// CacheTTL adalah lama data disimpan di cache, sekaligus batas waktu data
+// yang diubah fitur lain terlihat basi.
+func CacheTTL(ac AppConfig) time.Duration {
+	return duration("cache ttl", ac.CacheTTL, defaultCacheTTL)
+}
@@ -1,7 +1,8 @@
 package config
 
 import (
-	"log"
+	"api/logger"
+	"context"
 	"os"
 	"strconv"
 
@@ -13,12 +14,56 @@ var (
 )
 
 type AppConfig struct {
-	DBUser string
-	DBPass string
-	DBHost string
-	DBPort int
-	DBName string
-	jwtKey string
+	DBUser        string
+	DBPass        string
+	DBHost        string
+	DBPort        int
+	DBName        string
+	LogLevel      string
+	TraceExporter string
+	OTLPEndpoint  string
+	// DBReplicas berisi replica baca yang dipisah koma, berupa host:port
+	// dengan user, password dan nama database yang sama dengan primary
+	// atau DSN lengkap. Kosong berarti semua query ke primary.
+	DBReplicas string
+	// Pengaturan pool koneksi primary dan replica, kosong berarti default
+	// database/sql. Lifetime dan idle time berupa durasi seperti "30m".
+	DBMaxOpenConns    string
+	DBMaxIdleConns    string
+	DBConnMaxLifetime string
+	DBConnMaxIdleTime string
+	// BlobStore bernilai "local" (default) atau "s3"
+	BlobStore   string
+	BlobDir     string
+	BlobBaseURL string
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3PublicURL string
+	// MetadataProvider bernilai "openlibrary" (default) atau "none" untuk
+	// mematikan lookup ISBN, MetadataTimeout berupa durasi seperti "3s".
+	MetadataProvider string
+	MetadataURL      string
+	MetadataTimeout  string
+	// DeactivationGrace adalah masa pemulihan akun nonaktif (default 720h),
+	// TrashRetention lama buku di sampah (default 720h) dan PurgeInterval
+	// jarak antar penghapusan permanen (default 1h).
+	DeactivationGrace string
+	TrashRetention    string
+	PurgeInterval     string
+	// WebhookInterval adalah jarak antar pengiriman event outbox ke webhook
+	// (default 5s) dan WebhookTimeout batas waktu satu delivery (default
+	// 10s).
+	WebhookInterval string
+	WebhookTimeout  string
+	// Cache bernilai "memory" (default) atau "none", CacheSize jumlah entry
+	// maksimal (default 10000) dan CacheTTL lama data disimpan (default 1m).
+	Cache     string
+	CacheSize string
+	CacheTTL  string
+	jwtKey    string
 }
 
 func InitConfig() *AppConfig {
@@ -54,6 +99,51 @@ func ReadEnv() *AppConfig {
 		app.DBName = val
 		isRead = false
 	}
+	if val, found := os.LookupEnv("LOGLEVEL"); found {
+		app.LogLevel = val
+	}
+	if val, found := os.LookupEnv("TRACEEXPORTER"); found {
+		app.TraceExporter = val
+	}
+	if val, found := os.LookupEnv("OTLPENDPOINT"); found {
+		app.OTLPEndpoint = val
+	}
+	for env, field := range map[string]*string{
+		"DBREPLICAS":        &app.DBReplicas,
+		"DBMAXOPENCONNS":    &app.DBMaxOpenConns,
+		"DBMAXIDLECONNS":    &app.DBMaxIdleConns,
+		"DBCONNMAXLIFETIME": &app.DBConnMaxLifetime,
+		"DBCONNMAXIDLETIME": &app.DBConnMaxIdleTime,
+
+		"BLOBSTORE":   &app.BlobStore,
+		"BLOBDIR":     &app.BlobDir,
+		"BLOBBASEURL": &app.BlobBaseURL,
+		"S3ENDPOINT":  &app.S3Endpoint,
+		"S3REGION":    &app.S3Region,
+		"S3BUCKET":    &app.S3Bucket,
+		"S3ACCESSKEY": &app.S3AccessKey,
+		"S3SECRETKEY": &app.S3SecretKey,
+		"S3PUBLICURL": &app.S3PublicURL,
+
+		"METADATAPROVIDER": &app.MetadataProvider,
+		"METADATAURL":      &app.MetadataURL,
+		"METADATATIMEOUT":  &app.MetadataTimeout,
+
+		"DEACTIVATIONGRACE": &app.DeactivationGrace,
+		"TRASHRETENTION":    &app.TrashRetention,
+		"PURGEINTERVAL":     &app.PurgeInterval,
+
+		"WEBHOOKINTERVAL": &app.WebhookInterval,
+		"WEBHOOKTIMEOUT":  &app.WebhookTimeout,
+
+		"CACHE":     &app.Cache,
+		"CACHESIZE": &app.CacheSize,
+		"CACHETTL":  &app.CacheTTL,
+	} {
+		if val, found := os.LookupEnv(env); found {
+			*field = val
+		}
+	}
 
 	if isRead {
 		viper.AddConfigPath(".")
@@ -62,16 +152,17 @@ func ReadEnv() *AppConfig {
 
 		err := viper.ReadInConfig()
 		if err != nil {
-			log.Println("error read config : ", err.Error())
+			logger.Error(context.Background(), "error read config", logger.Fields{"error": err})
 			return nil
 		}
 		err = viper.Unmarshal(&app)
 		if err != nil {
-			log.Println("error parse config : ", err.Error())
+			logger.Error(context.Background(), "error parse config", logger.Fields{"error": err})
 			return nil
 		}
 	}
 
 	JWT_KEY = app.jwtKey
+	logger.SetLevel(app.LogLevel)
 	return &app
 }
@@ -1,28 +1,176 @@
 package config
 
 import (
+	audit "api/features/audit/data"
+	author "api/features/author/data"
 	book "api/features/book/data"
+	review "api/features/review/data"
+	shelf "api/features/shelf/data"
 	user "api/features/user/data"
+	webhook "api/features/webhook/data"
+	"api/logger"
+	"api/outbox"
+	"api/replica"
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"strconv"
+	"strings"
+	"time"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
 
 func InitDB(ac AppConfig) *gorm.DB {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		ac.DBUser, ac.DBPass, ac.DBHost, ac.DBPort, ac.DBName)
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(mysql.Open(dsn(ac, fmt.Sprintf("%s:%d", ac.DBHost, ac.DBPort))), &gorm.Config{})
 	if err != nil {
-		log.Println("database connection error : ", err.Error())
+		logger.Error(context.Background(), "database connection error", logger.Fields{"error": err})
 		return nil
 	}
+	if err := tunePool(ac, db); err != nil {
+		logger.Error(context.Background(), "database pool error", logger.Fields{"error": err})
+	}
+
+	pools := []gorm.ConnPool{}
+	for _, r := range strings.Split(ac.DBReplicas, ",") {
+		if r = strings.TrimSpace(r); r == "" {
+			continue
+		}
+		if !strings.Contains(r, "@") {
+			r = dsn(ac, r)
+		}
+		rdb, err := gorm.Open(mysql.Open(r), &gorm.Config{})
+		if err == nil {
+			err = tunePool(ac, rdb)
+		}
+		if err != nil {
+			// replica yang gagal dilewati, bacaan tetap dilayani replica lain
+			// atau primary
+			logger.Error(context.Background(), "replica connection error", logger.Fields{"error": err, "replica": len(pools)})
+			continue
+		}
+		pool, _ := rdb.DB()
+		pools = append(pools, pool)
+	}
+	if len(pools) > 0 {
+		if err := db.Use(replica.New(pools...)); err != nil {
+			logger.Error(context.Background(), "register replica error", logger.Fields{"error": err})
+		}
+		logger.Info(context.Background(), "read replica aktif", logger.Fields{"count": len(pools)})
+	}
 
 	return db
 }
 
+// dsn membuat DSN MySQL ke addr (host:port) dengan kredensial primary.
+func dsn(ac AppConfig, addr string) string {
+	return fmt.Sprintf("%s:%s@tcp(%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+		ac.DBUser, ac.DBPass, addr, ac.DBName)
+}
+
+// tunePool menerapkan pengaturan pool koneksi yang diisi saja.
+func tunePool(ac AppConfig, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if n := number("db max open conns", ac.DBMaxOpenConns, 0); n > 0 {
+		sqlDB.SetMaxOpenConns(n)
+	}
+	if n := number("db max idle conns", ac.DBMaxIdleConns, 0); n > 0 {
+		sqlDB.SetMaxIdleConns(n)
+	}
+	if d := duration("db conn max lifetime", ac.DBConnMaxLifetime, 0); d > 0 {
+		sqlDB.SetConnMaxLifetime(d)
+	}
+	if d := duration("db conn max idle time", ac.DBConnMaxIdleTime, 0); d > 0 {
+		sqlDB.SetConnMaxIdleTime(d)
+	}
+	return nil
+}
+
+// number membaca bilangan bulat positif, def dipakai bila kosong atau
+// tidak valid.
+func number(name, val string, def int) int {
+	if val == "" {
+		return def
+	}
+	n, err := strconv.Atoi(val)
+	if err != nil || n <= 0 {
+		logger.Warn(context.Background(), name+" tidak valid", logger.Fields{"error": err, "value": val})
+		return def
+	}
+	return n
+}
+
+// SchemaVersion dinaikkan setiap kali ada perubahan model yang dimigrasi,
+// dipakai readiness probe untuk memastikan migrasi sudah berjalan.
+const SchemaVersion = 12
+
+type SchemaMigration struct {
+	Version   int `gorm:"primaryKey;autoIncrement:false"`
+	AppliedAt time.Time
+}
+
 func Migrate(db *gorm.DB) {
-	db.AutoMigrate(user.User{})
-	db.AutoMigrate(book.Books{})
+	models := []interface{}{
+		user.User{},
+		book.Genre{},
+		book.Books{},
+		author.Author{},
+		book.BookAuthor{},
+		book.ImportJob{},
+		book.BookRevision{},
+		review.Review{},
+		shelf.Reading{},
+		shelf.Shelf{},
+		shelf.ShelfBook{},
+		audit.AuditLog{},
+		outbox.Message{},
+		webhook.Subscription{},
+		webhook.Delivery{},
+		SchemaMigration{},
+	}
+	for _, m := range models {
+		if err := db.AutoMigrate(m); err != nil {
+			logger.Error(context.Background(), "migrate error", logger.Fields{"error": err, "model": fmt.Sprintf("%T", m)})
+			return
+		}
+	}
+
+	if err := author.MigratePenulis(db); err != nil {
+		logger.Error(context.Background(), "migrate penulis error", logger.Fields{"error": err})
+		return
+	}
+
+	if err := db.Save(&SchemaMigration{Version: SchemaVersion, AppliedAt: time.Now()}).Error; err != nil {
+		logger.Error(context.Background(), "save schema version error", logger.Fields{"error": err})
+	}
+}
+
+// CheckMigration memastikan versi skema di database sudah sesuai dengan
+// versi yang dibutuhkan aplikasi.
+func CheckMigration(ctx context.Context, db *gorm.DB) error {
+	var version int
+	err := db.WithContext(ctx).Model(&SchemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	if err != nil {
+		return err
+	}
+	if version < SchemaVersion {
+		return fmt.Errorf("schema version %d, need %d", version, SchemaVersion)
+	}
+	return nil
+}
+
+// Ping memastikan koneksi ke database masih bisa dipakai.
+func Ping(ctx context.Context, db *gorm.DB) error {
+	if db == nil {
+		return errors.New("database not initialized")
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
 }
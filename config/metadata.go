@@ -0,0 +1,40 @@
+package config
+
+import (
+	"api/features/book"
+	"api/logger"
+	"api/metadata"
+	"context"
+	"time"
+)
+
+const (
+	defaultMetadataURL     = "https://openlibrary.org"
+	defaultMetadataTimeout = 3 * time.Second
+	metadataCacheTTL       = 24 * time.Hour
+	metadataCacheSize      = 10000
+)
+
+// InitMetadata membuat provider metadata ISBN sesuai konfigurasi, nil bila
+// lookup dimatikan.
+func InitMetadata(ac AppConfig) book.MetadataProvider {
+	if ac.MetadataProvider == "none" {
+		return nil
+	}
+
+	baseURL := ac.MetadataURL
+	if baseURL == "" {
+		baseURL = defaultMetadataURL
+	}
+	timeout := defaultMetadataTimeout
+	if ac.MetadataTimeout != "" {
+		d, err := time.ParseDuration(ac.MetadataTimeout)
+		if err != nil {
+			logger.Warn(context.Background(), "metadata timeout tidak valid", logger.Fields{"error": err, "value": ac.MetadataTimeout})
+		} else {
+			timeout = d
+		}
+	}
+
+	return metadata.NewCache(metadata.NewOpenLibrary(baseURL, timeout), metadataCacheTTL, metadataCacheSize)
+}
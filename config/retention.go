@@ -0,0 +1,42 @@
+package config
+
+import (
+	"api/logger"
+	"context"
+	"time"
+)
+
+const (
+	defaultDeactivationGrace = 30 * 24 * time.Hour
+	defaultPurgeInterval     = time.Hour
+	defaultTrashRetention    = 30 * 24 * time.Hour
+)
+
+// DeactivationGrace adalah masa akun nonaktif masih bisa dipulihkan.
+func DeactivationGrace(ac AppConfig) time.Duration {
+	return duration("deactivation grace", ac.DeactivationGrace, defaultDeactivationGrace)
+}
+
+// TrashRetention adalah lama buku disimpan di sampah sebelum dihapus permanen.
+func TrashRetention(ac AppConfig) time.Duration {
+	return duration("trash retention", ac.TrashRetention, defaultTrashRetention)
+}
+
+// PurgeInterval adalah jarak antar pembersihan data yang sudah kedaluwarsa.
+func PurgeInterval(ac AppConfig) time.Duration {
+	return duration("purge interval", ac.PurgeInterval, defaultPurgeInterval)
+}
+
+// duration membaca durasi seperti "720h", def dipakai bila kosong atau
+// tidak valid.
+func duration(name, val string, def time.Duration) time.Duration {
+	if val == "" {
+		return def
+	}
+	d, err := time.ParseDuration(val)
+	if err != nil || d <= 0 {
+		logger.Warn(context.Background(), name+" tidak valid", logger.Fields{"error": err, "value": val})
+		return def
+	}
+	return d
+}
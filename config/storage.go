@@ -0,0 +1,43 @@
+package config
+
+import "api/storage"
+
+const (
+	defaultBlobDir     = "uploads"
+	defaultBlobBaseURL = "/files"
+)
+
+// InitBlobStore membuat BlobStore sesuai konfigurasi, default menyimpan
+// ke direktori lokal yang disajikan di /files.
+func InitBlobStore(ac AppConfig) storage.BlobStore {
+	if ac.BlobStore == "s3" {
+		return storage.NewS3(storage.S3Config{
+			Endpoint:  ac.S3Endpoint,
+			Region:    ac.S3Region,
+			Bucket:    ac.S3Bucket,
+			AccessKey: ac.S3AccessKey,
+			SecretKey: ac.S3SecretKey,
+			PublicURL: ac.S3PublicURL,
+		})
+	}
+
+	return storage.NewLocal(LocalBlobDir(ac), blobBaseURL(ac))
+}
+
+// LocalBlobDir mengembalikan direktori blob lokal, kosong bila memakai S3.
+func LocalBlobDir(ac AppConfig) string {
+	if ac.BlobStore == "s3" {
+		return ""
+	}
+	if ac.BlobDir == "" {
+		return defaultBlobDir
+	}
+	return ac.BlobDir
+}
+
+func blobBaseURL(ac AppConfig) string {
+	if ac.BlobBaseURL == "" {
+		return defaultBlobBaseURL
+	}
+	return ac.BlobBaseURL
+}
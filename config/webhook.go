@@ -0,0 +1,38 @@
+package config
+
+import (
+	"api/netguard"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultWebhookInterval = 5 * time.Second
+	defaultWebhookTimeout  = 10 * time.Second
+	// maxWebhookTimeout harus lebih pendek dari lease delivery agar delivery
+	// yang sedang dikirim tidak diambil lagi oleh instance lain.
+	maxWebhookTimeout = 30 * time.Second
+)
+
+// WebhookInterval adalah jarak antar pengiriman event outbox ke webhook.
+func WebhookInterval(ac AppConfig) time.Duration {
+	return duration("webhook interval", ac.WebhookInterval, defaultWebhookInterval)
+}
+
+// WebhookClient membuat HTTP client untuk mengirim webhook. Redirect tidak
+// diikuti agar payload yang sudah ditandatangani tidak terkirim ke URL lain,
+// dan koneksi ke alamat internal ditolak agar webhook tidak bisa dipakai
+// memindai jaringan internal.
+func WebhookClient(ac AppConfig) *http.Client {
+	timeout := duration("webhook timeout", ac.WebhookTimeout, defaultWebhookTimeout)
+	if timeout > maxWebhookTimeout {
+		timeout = maxWebhookTimeout
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: netguard.Transport(timeout),
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
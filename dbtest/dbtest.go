@@ -0,0 +1,50 @@
+// Package dbtest menyiapkan database sungguhan untuk test query. Bila env
+// TESTDSN berisi DSN MySQL, test memakai database tersebut (tabel model
+// dihapus lalu dibuat ulang), selain itu dipakai SQLite di direktori
+// sementara.
+package dbtest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Open membuka database test dan memigrasi models. Transaksi SQLite
+// langsung mengambil write lock (_txlock=immediate) agar transaksi yang
+// berjalan bersamaan antre seperti row lock MySQL, bukan gagal SQLITE_BUSY.
+func Open(t testing.TB, models ...interface{}) *gorm.DB {
+	t.Helper()
+
+	cfg := &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)}
+	var db *gorm.DB
+	var err error
+	if dsn := os.Getenv("TESTDSN"); dsn != "" {
+		db, err = gorm.Open(mysql.Open(dsn), cfg)
+		if err == nil {
+			err = db.Migrator().DropTable(models...)
+		}
+	} else {
+		path := filepath.Join(t.TempDir(), "test.db")
+		db, err = gorm.Open(sqlite.Open("file:"+path+"?_txlock=immediate&_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)"), cfg)
+	}
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatalf("migrate test db: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	return db
+}
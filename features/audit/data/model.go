@@ -0,0 +1,60 @@
+package data
+
+import (
+	"api/features/audit"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AuditLog hanya pernah ditambah, hook di bawah menolak update dan delete
+// agar catatan tidak bisa diubah lewat GORM.
+type AuditLog struct {
+	ID        uint      `gorm:"primaryKey"`
+	ActorID   uint      `gorm:"index"`
+	Action    string    `gorm:"size:20;index"`
+	Entity    string    `gorm:"size:20;index:idx_audit_entity"`
+	EntityID  uint      `gorm:"index:idx_audit_entity"`
+	Changes   string    `gorm:"type:text"`
+	IP        string    `gorm:"size:45"`
+	RequestID string    `gorm:"size:64;index"`
+	CreatedAt time.Time `gorm:"index"`
+}
+
+var errAppendOnly = errors.New("audit log tidak boleh diubah atau dihapus")
+
+func (AuditLog) BeforeUpdate(*gorm.DB) error { return errAppendOnly }
+
+func (AuditLog) BeforeDelete(*gorm.DB) error { return errAppendOnly }
+
+func ToCore(data AuditLog) audit.Core {
+	res := audit.Core{
+		ID:        data.ID,
+		ActorID:   data.ActorID,
+		Action:    data.Action,
+		Entity:    data.Entity,
+		EntityID:  data.EntityID,
+		Changes:   map[string]audit.Change{},
+		IP:        data.IP,
+		RequestID: data.RequestID,
+		CreatedAt: data.CreatedAt,
+	}
+	// changes selalu ditulis oleh CoreToData, isi rusak cukup diabaikan
+	_ = json.Unmarshal([]byte(data.Changes), &res.Changes)
+	return res
+}
+
+func CoreToData(data audit.Core) AuditLog {
+	changes, _ := json.Marshal(data.Changes)
+	return AuditLog{
+		ActorID:   data.ActorID,
+		Action:    data.Action,
+		Entity:    data.Entity,
+		EntityID:  data.EntityID,
+		Changes:   string(changes),
+		IP:        data.IP,
+		RequestID: data.RequestID,
+	}
+}
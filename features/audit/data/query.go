@@ -0,0 +1,77 @@
+package data
+
+import (
+	"api/features/audit"
+	"api/logger"
+	"api/uow"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type auditData struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) audit.AuditData {
+	return &auditData{
+		db: db,
+	}
+}
+
+func (ad *auditData) Add(ctx context.Context, entry audit.Core) error {
+	cnv := CoreToData(entry)
+	if err := uow.DB(ctx, ad.db).Create(&cnv).Error; err != nil {
+		logger.Error(ctx, "add audit log error", logger.Fields{"error": err})
+		return err
+	}
+
+	return nil
+}
+
+func (ad *auditData) List(ctx context.Context, filter audit.Filter) ([]audit.Core, int64, error) {
+	qry := uow.DB(ctx, ad.db).Model(&AuditLog{})
+	if filter.ActorID > 0 {
+		qry = qry.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		qry = qry.Where("action = ?", filter.Action)
+	}
+	if filter.Entity != "" {
+		qry = qry.Where("entity = ?", filter.Entity)
+	}
+	if filter.EntityID > 0 {
+		qry = qry.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.RequestID != "" {
+		qry = qry.Where("request_id = ?", filter.RequestID)
+	}
+	if !filter.From.IsZero() {
+		qry = qry.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		qry = qry.Where("created_at < ?", filter.To)
+	}
+
+	var total int64
+	if err := qry.Count(&total).Error; err != nil {
+		logger.Error(ctx, "count audit log error", logger.Fields{"error": err})
+		return nil, 0, err
+	}
+
+	rows := []AuditLog{}
+	err := qry.Order("id DESC").
+		Offset((filter.Page - 1) * filter.Limit).Limit(filter.Limit).
+		Find(&rows).Error
+	if err != nil {
+		logger.Error(ctx, "list audit log error", logger.Fields{"error": err})
+		return nil, 0, err
+	}
+
+	res := []audit.Core{}
+	for _, r := range rows {
+		res = append(res, ToCore(r))
+	}
+
+	return res, total, nil
+}
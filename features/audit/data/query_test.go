@@ -0,0 +1,48 @@
+package data_test
+
+import (
+	"api/dbtest"
+	"api/features/audit"
+	"api/features/audit/data"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLog(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t, data.AuditLog{})
+	repo := data.New(db)
+
+	require.NoError(t, repo.Add(ctx, audit.Core{ActorID: 1, Action: audit.ActionCreate, Entity: audit.EntityBook, EntityID: 7,
+		Changes: map[string]audit.Change{"Judul": {After: "One Piece"}}, IP: "10.0.0.1", RequestID: "req-1"}))
+	require.NoError(t, repo.Add(ctx, audit.Core{ActorID: 1, Action: audit.ActionUpdate, Entity: audit.EntityBook, EntityID: 7,
+		Changes: map[string]audit.Change{"Judul": {Before: "One Piece", After: "Naruto"}}, RequestID: "req-2"}))
+	require.NoError(t, repo.Add(ctx, audit.Core{ActorID: 2, Action: audit.ActionUpdate, Entity: audit.EntityUser, EntityID: 2}))
+
+	t.Run("filter dan urutan terbaru", func(t *testing.T) {
+		res, total, err := repo.List(ctx, audit.Filter{Entity: audit.EntityBook, EntityID: 7, Page: 1, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		require.Len(t, res, 2)
+		assert.Equal(t, audit.ActionUpdate, res[0].Action)
+		assert.Equal(t, audit.Change{Before: "One Piece", After: "Naruto"}, res[0].Changes["Judul"])
+		assert.Equal(t, "10.0.0.1", res[1].IP)
+
+		res, total, err = repo.List(ctx, audit.Filter{RequestID: "req-1", Page: 1, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, audit.ActionCreate, res[0].Action)
+	})
+
+	t.Run("tidak bisa diubah atau dihapus", func(t *testing.T) {
+		assert.Error(t, db.Model(&data.AuditLog{}).Where("id = ?", 1).Update("action", "delete").Error)
+		assert.Error(t, db.Delete(&data.AuditLog{}, 1).Error)
+
+		_, total, err := repo.List(ctx, audit.Filter{Page: 1, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+	})
+}
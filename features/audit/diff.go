@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Diff membandingkan before dan after lewat bentuk JSON-nya lalu
+// mengembalikan field yang berubah. Nilai kosong dianggap tidak ada,
+// sehingga field yang dikosongkan tercatat dengan After nil. Field di omit,
+// misalnya password, tidak pernah dicatat.
+func Diff(before, after interface{}, omit ...string) (map[string]Change, error) {
+	b, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range omit {
+		delete(b, f)
+		delete(a, f)
+	}
+
+	res := map[string]Change{}
+	for k, v := range b {
+		if w, ok := a[k]; !ok || !reflect.DeepEqual(v, w) {
+			res[k] = Change{Before: v, After: a[k]}
+		}
+	}
+	for k, w := range a {
+		if _, ok := b[k]; !ok {
+			res[k] = Change{After: w}
+		}
+	}
+	return res, nil
+}
+
+// fields mengubah struct menjadi map field, nil menjadi map kosong.
+func fields(v interface{}) (map[string]interface{}, error) {
+	res := map[string]interface{}{}
+	if v == nil {
+		return res, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &res); err != nil {
+		return nil, err
+	}
+	for k, v := range res {
+		if isZero(v) {
+			delete(res, k)
+		}
+	}
+	return res, nil
+}
+
+// zeroTime adalah time.Time kosong dalam bentuk JSON.
+const zeroTime = "0001-01-01T00:00:00Z"
+
+func isZero(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == "" || v == zeroTime
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	ActionCreate     = "create"
+	ActionUpdate     = "update"
+	ActionDelete     = "delete"
+	ActionRestore    = "restore"
+	ActionPurge      = "purge"
+	ActionDeactivate = "deactivate"
+	ActionImport     = "import"
+	ActionRevert     = "revert"
+
+	EntityUser      = "user"
+	EntityBook      = "book"
+	EntityImportJob = "import_job"
+)
+
+// Core adalah satu catatan audit. ActorID 0 berarti perubahan dilakukan
+// sistem, misalnya purge terjadwal.
+type Core struct {
+	ID        uint
+	ActorID   uint
+	Action    string
+	Entity    string
+	EntityID  uint
+	Changes   map[string]Change
+	IP        string
+	RequestID string
+	CreatedAt time.Time
+}
+
+// Change berisi nilai field sebelum dan sesudah diubah, nil bila field
+// belum ada (create) atau sudah tidak ada (delete).
+type Change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Filter berisi kriteria pencarian audit log. Field kosong diabaikan.
+type Filter struct {
+	ActorID   uint
+	Action    string
+	Entity    string
+	EntityID  uint
+	RequestID string
+	From      time.Time
+	To        time.Time
+	Page      int
+	Limit     int
+}
+
+// Recorder dipakai service lain untuk mencatat perubahan. Actor, IP dan
+// request ID diambil dari ctx, before dan after berupa data sebelum dan
+// sesudah diubah (nil untuk create atau delete).
+type Recorder interface {
+	Record(ctx context.Context, action, entity string, entityID uint, before, after interface{})
+}
+
+type AuditHandler interface {
+	List() echo.HandlerFunc
+}
+
+type AuditService interface {
+	// List hanya bisa dipakai admin.
+	List(ctx context.Context, token interface{}, filter Filter) ([]Core, int64, error)
+}
+
+// AuditData hanya bisa menambah dan membaca, catatan audit tidak pernah
+// diubah atau dihapus.
+type AuditData interface {
+	Add(ctx context.Context, entry Core) error
+	List(ctx context.Context, filter Filter) ([]Core, int64, error)
+}
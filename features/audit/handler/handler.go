@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"api/features/audit"
+	"api/helper"
+	"api/logger"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type auditHandle struct {
+	srv audit.AuditService
+}
+
+func New(as audit.AuditService) audit.AuditHandler {
+	return &auditHandle{
+		srv: as,
+	}
+}
+
+func (ah *auditHandle) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := ListAuditRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		filter, err := ToFilter(input)
+		if err != nil {
+			logger.Warn(c.Request().Context(), "parse filter waktu error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "format from dan to harus RFC3339")
+		}
+
+		res, total, err := ah.srv.List(c.Request().Context(), c.Get("user"), filter)
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintListResponse(http.StatusOK, "sukses menampilkan audit log", res, helper.NewPagination(input.Page, input.Limit, total)))
+	}
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"api/features/audit"
+	"time"
+)
+
+// ListAuditRequest berisi filter audit log, from dan to memakai format
+// RFC3339 dan keduanya opsional.
+type ListAuditRequest struct {
+	ActorID   uint   `query:"actor_id"`
+	Action    string `query:"action" validate:"omitempty,oneof=create update delete restore purge deactivate import revert"`
+	Entity    string `query:"entity" validate:"omitempty,oneof=user book import_job"`
+	EntityID  uint   `query:"entity_id"`
+	RequestID string `query:"request_id" validate:"max=64"`
+	From      string `query:"from"`
+	To        string `query:"to"`
+	Page      int    `query:"page" validate:"gte=1"`
+	Limit     int    `query:"limit" validate:"gte=1,lte=100"`
+}
+
+func ToFilter(data ListAuditRequest) (audit.Filter, error) {
+	res := audit.Filter{
+		ActorID:   data.ActorID,
+		Action:    data.Action,
+		Entity:    data.Entity,
+		EntityID:  data.EntityID,
+		RequestID: data.RequestID,
+		Page:      data.Page,
+		Limit:     data.Limit,
+	}
+
+	var err error
+	if data.From != "" {
+		if res.From, err = time.Parse(time.RFC3339, data.From); err != nil {
+			return audit.Filter{}, err
+		}
+	}
+	if data.To != "" {
+		if res.To, err = time.Parse(time.RFC3339, data.To); err != nil {
+			return audit.Filter{}, err
+		}
+	}
+
+	return res, nil
+}
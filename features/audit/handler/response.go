@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"api/features/audit"
+	"api/helper"
+	"time"
+)
+
+type AuditResponse struct {
+	ID        uint                    `json:"id"`
+	ActorID   uint                    `json:"actor_id"`
+	Action    string                  `json:"action"`
+	Entity    string                  `json:"entity"`
+	EntityID  uint                    `json:"entity_id"`
+	Changes   map[string]audit.Change `json:"changes"`
+	IP        string                  `json:"ip"`
+	RequestID string                  `json:"request_id"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+func ToResponse(data audit.Core) AuditResponse {
+	changes := data.Changes
+	if changes == nil {
+		changes = map[string]audit.Change{}
+	}
+	return AuditResponse{
+		ID:        data.ID,
+		ActorID:   data.ActorID,
+		Action:    data.Action,
+		Entity:    data.Entity,
+		EntityID:  data.EntityID,
+		Changes:   changes,
+		IP:        data.IP,
+		RequestID: data.RequestID,
+		CreatedAt: data.CreatedAt,
+	}
+}
+
+func PrintListResponse(code int, message string, data []audit.Core, pagination helper.Pagination) (int, interface{}) {
+	res := []AuditResponse{}
+	for _, v := range data {
+		res = append(res, ToResponse(v))
+	}
+
+	resp := map[string]interface{}{}
+	resp["data"] = res
+	resp["pagination"] = pagination
+
+	if message != "" {
+		resp["message"] = message
+	}
+
+	return code, resp
+}
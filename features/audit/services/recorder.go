@@ -0,0 +1,70 @@
+package services
+
+import (
+	"api/features/audit"
+	"api/logger"
+	"context"
+)
+
+// omitFields tidak pernah dicatat di audit log.
+var omitFields = []string{"Password"}
+
+// maskedFields berisi data pribadi. Perubahannya tetap dicatat tetapi
+// nilainya disamarkan, karena audit log tidak bisa diubah sehingga tidak
+// ikut dianonimkan saat akun dihapus permanen.
+var maskedFields = []string{"Nama", "Email", "Alamat", "HP", "TransferTo", "Pemilik"}
+
+const maskedValue = "[disamarkan]"
+
+type recorder struct {
+	data audit.AuditData
+}
+
+// NewRecorder membuat Recorder yang menyimpan catatan lewat ad. Kegagalan
+// mencatat hanya ditulis ke log agar perubahan yang sudah terjadi tetap
+// dilaporkan berhasil.
+func NewRecorder(ad audit.AuditData) audit.Recorder {
+	return &recorder{
+		data: ad,
+	}
+}
+
+func (r *recorder) Record(ctx context.Context, action, entity string, entityID uint, before, after interface{}) {
+	changes, err := audit.Diff(before, after, omitFields...)
+	if err != nil {
+		logger.Error(ctx, "audit diff error", logger.Fields{"error": err, "entity": entity, "entity_id": entityID})
+		return
+	}
+	mask(changes)
+
+	err = r.data.Add(ctx, audit.Core{
+		ActorID:   logger.UserID(ctx),
+		Action:    action,
+		Entity:    entity,
+		EntityID:  entityID,
+		Changes:   changes,
+		IP:        logger.IP(ctx),
+		RequestID: logger.RequestID(ctx),
+	})
+	if err != nil {
+		logger.Error(ctx, "catat audit log gagal", logger.Fields{"error": err, "action": action, "entity": entity, "entity_id": entityID})
+	}
+}
+
+// mask mengganti nilai maskedFields yang berubah dengan maskedValue. Nilai
+// nil tetap nil agar field yang baru diisi atau dikosongkan masih terlihat.
+func mask(changes map[string]audit.Change) {
+	for _, f := range maskedFields {
+		c, ok := changes[f]
+		if !ok {
+			continue
+		}
+		if c.Before != nil {
+			c.Before = maskedValue
+		}
+		if c.After != nil {
+			c.After = maskedValue
+		}
+		changes[f] = c
+	}
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"api/features/audit"
+	"api/features/user"
+	"api/helper"
+	"api/tracing"
+	"context"
+	"errors"
+	"strings"
+)
+
+type auditSrv struct {
+	data  audit.AuditData
+	users user.UserData
+}
+
+// New membuat AuditService, ud dipakai untuk memeriksa role admin.
+func New(ad audit.AuditData, ud user.UserData) audit.AuditService {
+	return &auditSrv{
+		data:  ad,
+		users: ud,
+	}
+}
+
+func (as *auditSrv) List(ctx context.Context, token interface{}, filter audit.Filter) ([]audit.Core, int64, error) {
+	ctx, span := tracing.Start(ctx, "AuditService.List")
+	defer span.End()
+
+	id := helper.ExtractToken(token)
+	if id <= 0 {
+		return nil, 0, errors.New("user not found")
+	}
+	u, err := as.users.Profile(ctx, uint(id))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, 0, errors.New("user not found")
+		}
+		return nil, 0, errors.New("terjadi kesalahan pada server")
+	}
+	if u.Role != user.RoleAdmin {
+		return nil, 0, errors.New("forbidden: hanya admin yang bisa melihat audit log")
+	}
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, 0, errors.New("format filter tidak sesuai, from harus sebelum to")
+	}
+	filter.Page, filter.Limit = helper.PageLimit(filter.Page, filter.Limit)
+
+	res, total, err := as.data.List(ctx, filter)
+	if err != nil {
+		return nil, 0, errors.New("terjadi kesalahan pada server")
+	}
+
+	return res, total, nil
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"api/features/audit"
+	"api/features/user"
+	"api/helper"
+	"api/logger"
+	"api/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func validToken() interface{} {
+	_, token := helper.GenerateJWT(1)
+	pToken := token.(*jwt.Token)
+	pToken.Valid = true
+	return pToken
+}
+
+func TestList(t *testing.T) {
+	t.Run("admin melihat audit log", func(t *testing.T) {
+		repo := mocks.NewAuditData(t)
+		users := mocks.NewUserData(t)
+		users.On("Profile", mock.Anything, uint(1)).Return(user.Core{ID: 1, Role: user.RoleAdmin}, nil).Once()
+		expected := []audit.Core{{ID: 3, Action: audit.ActionUpdate, Entity: audit.EntityBook, EntityID: 7}}
+		repo.On("List", mock.Anything, audit.Filter{Entity: audit.EntityBook, Page: 1, Limit: helper.DefaultLimit}).Return(expected, int64(1), nil).Once()
+
+		res, total, err := New(repo, users).List(context.Background(), validToken(), audit.Filter{Entity: audit.EntityBook})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, expected, res)
+	})
+
+	t.Run("bukan admin", func(t *testing.T) {
+		users := mocks.NewUserData(t)
+		users.On("Profile", mock.Anything, uint(1)).Return(user.Core{ID: 1, Role: user.RoleUser}, nil).Once()
+
+		_, _, err := New(mocks.NewAuditData(t), users).List(context.Background(), validToken(), audit.Filter{})
+		assert.ErrorContains(t, err, "forbidden")
+	})
+
+	t.Run("rentang waktu terbalik", func(t *testing.T) {
+		users := mocks.NewUserData(t)
+		users.On("Profile", mock.Anything, uint(1)).Return(user.Core{ID: 1, Role: user.RoleAdmin}, nil).Once()
+		now := time.Now()
+
+		_, _, err := New(mocks.NewAuditData(t), users).List(context.Background(), validToken(), audit.Filter{From: now, To: now.Add(-time.Hour)})
+		assert.ErrorContains(t, err, "format")
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		repo := mocks.NewAuditData(t)
+		users := mocks.NewUserData(t)
+		users.On("Profile", mock.Anything, uint(1)).Return(user.Core{ID: 1, Role: user.RoleAdmin}, nil).Once()
+		repo.On("List", mock.Anything, mock.Anything).Return(nil, int64(0), errors.New("query error")).Once()
+
+		_, _, err := New(repo, users).List(context.Background(), validToken(), audit.Filter{})
+		assert.ErrorContains(t, err, "server")
+	})
+}
+
+func TestRecord(t *testing.T) {
+	ctx := logger.WithIP(logger.WithUserID(logger.WithRequestID(context.Background(), "req-1"), 5), "10.0.0.1")
+
+	t.Run("mencatat field yang berubah, data pribadi disamarkan", func(t *testing.T) {
+		repo := mocks.NewAuditData(t)
+		repo.On("Add", mock.Anything, audit.Core{
+			ActorID: 5, Action: audit.ActionUpdate, Entity: audit.EntityUser, EntityID: 5,
+			Changes: map[string]audit.Change{
+				"Nama":    {Before: maskedValue, After: maskedValue},
+				"HP":      {Before: maskedValue},
+				"Version": {Before: float64(1), After: float64(2)},
+			},
+			IP:        "10.0.0.1",
+			RequestID: "req-1",
+		}).Return(nil).Once()
+
+		before := user.Core{ID: 5, Nama: "alif", Email: "alif@be14.com", HP: "0812", Password: "lama", Version: 1}
+		after := user.Core{ID: 5, Nama: "budi", Email: "alif@be14.com", Password: "baru", Version: 2}
+		NewRecorder(repo).Record(ctx, audit.ActionUpdate, audit.EntityUser, 5, before, after)
+	})
+
+	t.Run("gagal menyimpan tidak panic", func(t *testing.T) {
+		repo := mocks.NewAuditData(t)
+		repo.On("Add", mock.Anything, mock.Anything).Return(errors.New("database is locked")).Once()
+
+		assert.NotPanics(t, func() {
+			NewRecorder(repo).Record(ctx, audit.ActionDelete, audit.EntityBook, 1, user.Core{ID: 1}, nil)
+		})
+	})
+}
@@ -0,0 +1,75 @@
+package data
+
+import (
+	book "api/features/book/data"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// MigratePenulis memindahkan teks penulis pada buku lama menjadi data
+// author. Nama yang hanya berbeda huruf besar/kecil atau spasi dianggap
+// penulis yang sama. Buku yang sudah punya author dilewati sehingga aman
+// dijalankan berulang kali.
+func MigratePenulis(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		books := []book.Books{}
+		err := tx.Unscoped().Select("id, penulis").
+			Where("id NOT IN (?)", tx.Model(&book.BookAuthor{}).Select("books_id")).
+			Find(&books).Error
+		if err != nil || len(books) == 0 {
+			return err
+		}
+
+		existing := []Author{}
+		if err := tx.Find(&existing).Error; err != nil {
+			return err
+		}
+		authors := map[string]Author{}
+		for _, a := range existing {
+			authors[strings.ToLower(a.Nama)] = a
+		}
+
+		for _, b := range books {
+			names := []string{}
+			links := []book.BookAuthor{}
+			for _, n := range book.SplitPenulis(b.Penulis) {
+				a, ok := authors[strings.ToLower(n)]
+				if !ok {
+					a = Author{Nama: n}
+					if err := tx.Create(&a).Error; err != nil {
+						return err
+					}
+					authors[strings.ToLower(n)] = a
+				}
+				if containsAuthor(links, a.ID) {
+					continue
+				}
+				names = append(names, a.Nama)
+				links = append(links, book.BookAuthor{BooksID: b.ID, AuthorID: a.ID, Urutan: len(links) + 1})
+			}
+			if len(links) == 0 {
+				continue
+			}
+
+			if err := tx.Create(&links).Error; err != nil {
+				return err
+			}
+			err := tx.Model(&book.Books{}).Unscoped().Where("id = ?", b.ID).
+				UpdateColumn("penulis", strings.Join(names, ", ")).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func containsAuthor(links []book.BookAuthor, authorID uint) bool {
+	for _, l := range links {
+		if l.AuthorID == authorID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package data
+
+import (
+	"api/features/author"
+	"time"
+)
+
+type Author struct {
+	ID        uint   `gorm:"primaryKey"`
+	Nama      string `gorm:"size:100;uniqueIndex"`
+	Bio       string `gorm:"type:text"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// AuthorWithCount dipakai saat membaca author beserta jumlah bukunya.
+type AuthorWithCount struct {
+	Author
+	JumlahBuku int
+}
+
+func ToCore(data Author) author.Core {
+	return author.Core{
+		ID:   data.ID,
+		Nama: data.Nama,
+		Bio:  data.Bio,
+	}
+}
+
+func CoreToData(data author.Core) Author {
+	return Author{
+		ID:   data.ID,
+		Nama: data.Nama,
+		Bio:  data.Bio,
+	}
+}
@@ -0,0 +1,225 @@
+package data
+
+import (
+	"api/cache"
+	"api/features/author"
+	book "api/features/book/data"
+	"api/logger"
+	"api/uow"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// jumlahBuku menghitung buku aktif milik author untuk kolom jumlah_buku.
+const jumlahBuku = `(SELECT COUNT(*) FROM book_authors
+	JOIN books ON books.id = book_authors.books_id AND books.deleted_at IS NULL
+	WHERE book_authors.author_id = authors.id) AS jumlah_buku`
+
+type authorData struct {
+	db *gorm.DB
+	// books adalah cache detail buku yang dihapus bila nama penulis pada
+	// buku ikut berubah.
+	books cache.Cache
+}
+
+// New membuat AuthorData, books boleh nil bila cache detail buku tidak
+// dipakai.
+func New(db *gorm.DB, books cache.Cache) author.AuthorData {
+	return &authorData{
+		db:    db,
+		books: books,
+	}
+}
+
+func (ad *authorData) Add(ctx context.Context, newAuthor author.Core) (author.Core, error) {
+	if err := ad.checkNama(ctx, 0, newAuthor.Nama); err != nil {
+		return author.Core{}, err
+	}
+
+	cnv := CoreToData(newAuthor)
+	if err := uow.DB(ctx, ad.db).Create(&cnv).Error; err != nil {
+		logger.Error(ctx, "add author query error", logger.Fields{"error": err})
+		return author.Core{}, err
+	}
+
+	return ToCore(cnv), nil
+}
+
+func (ad *authorData) List(ctx context.Context, q string, page, limit int) ([]author.Core, int64, error) {
+	qry := uow.DB(ctx, ad.db).Model(&Author{})
+	if q != "" {
+		qry = qry.Where("nama LIKE ?", "%"+q+"%")
+	}
+
+	var total int64
+	if err := qry.Count(&total).Error; err != nil {
+		logger.Error(ctx, "count author query error", logger.Fields{"error": err})
+		return nil, 0, err
+	}
+
+	rows := []AuthorWithCount{}
+	err := qry.Select("authors.*, " + jumlahBuku).Order("nama").
+		Offset((page - 1) * limit).Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		logger.Error(ctx, "list author query error", logger.Fields{"error": err})
+		return nil, 0, err
+	}
+
+	res := []author.Core{}
+	for _, r := range rows {
+		c := ToCore(r.Author)
+		c.JumlahBuku = r.JumlahBuku
+		res = append(res, c)
+	}
+
+	return res, total, nil
+}
+
+func (ad *authorData) Detail(ctx context.Context, authorID uint) (author.Core, error) {
+	row := Author{}
+	err := uow.DB(ctx, ad.db).Where("id = ?", authorID).First(&row).Error
+	if err != nil {
+		logger.Error(ctx, "get author error", logger.Fields{"error": err, "author_id": authorID})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return author.Core{}, errors.New("data not found")
+		}
+		return author.Core{}, err
+	}
+
+	books := []author.Book{}
+	err = uow.DB(ctx, ad.db).Model(&book.BookAuthor{}).
+		Select("books.id, books.judul, books.tahun_terbit, book_authors.urutan").
+		Joins("JOIN books ON books.id = book_authors.books_id AND books.deleted_at IS NULL").
+		Where("book_authors.author_id = ?", authorID).
+		Order("books.tahun_terbit, books.id").
+		Scan(&books).Error
+	if err != nil {
+		logger.Error(ctx, "get author books error", logger.Fields{"error": err, "author_id": authorID})
+		return author.Core{}, err
+	}
+
+	res := ToCore(row)
+	res.Buku = books
+	res.JumlahBuku = len(books)
+
+	return res, nil
+}
+
+func (ad *authorData) Update(ctx context.Context, authorID uint, updatedData author.Core) (author.Core, error) {
+	if updatedData.Nama != "" {
+		if err := ad.checkNama(ctx, authorID, updatedData.Nama); err != nil {
+			return author.Core{}, err
+		}
+	}
+
+	cnv := CoreToData(updatedData)
+	cnv.ID = 0
+	changed := []uint{}
+	err := uow.DB(ctx, ad.db).Transaction(func(tx *gorm.DB) error {
+		qry := tx.Model(&Author{}).Where("id = ?", authorID).Updates(&cnv)
+		if err := qry.Error; err != nil {
+			return err
+		}
+		if qry.RowsAffected <= 0 {
+			return errors.New("data not found")
+		}
+		if cnv.Nama == "" {
+			return nil
+		}
+		// nama penulis pada buku disimpan juga sebagai teks
+		ids, err := book.RefreshPenulis(tx, authorID)
+		changed = ids
+		return err
+	})
+	if err != nil {
+		logger.Error(ctx, "update author query error", logger.Fields{"error": err, "author_id": authorID})
+		return author.Core{}, err
+	}
+	book.InvalidateDetail(ctx, ad.books, changed...)
+
+	return ad.Detail(ctx, authorID)
+}
+
+func (ad *authorData) Delete(ctx context.Context, authorID uint) error {
+	var count int64
+	err := uow.DB(ctx, ad.db).Model(&book.BookAuthor{}).Where("author_id = ?", authorID).Count(&count).Error
+	if err != nil {
+		logger.Error(ctx, "count author books error", logger.Fields{"error": err, "author_id": authorID})
+		return err
+	}
+	if count > 0 {
+		logger.Warn(ctx, "author masih memiliki buku", logger.Fields{"author_id": authorID})
+		return errors.New("conflict: penulis masih memiliki buku")
+	}
+
+	qry := uow.DB(ctx, ad.db).Delete(&Author{}, authorID)
+	if err := qry.Error; err != nil {
+		logger.Error(ctx, "delete author query error", logger.Fields{"error": err, "author_id": authorID})
+		return err
+	}
+	if qry.RowsAffected <= 0 {
+		logger.Warn(ctx, "no rows affected", logger.Fields{"author_id": authorID})
+		return errors.New("data not found")
+	}
+
+	return nil
+}
+
+func (ad *authorData) Merge(ctx context.Context, authorID, duplicateID uint) error {
+	changed := []uint{}
+	err := uow.DB(ctx, ad.db).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&Author{}).Where("id IN ?", []uint{authorID, duplicateID}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count < 2 {
+			return errors.New("data not found")
+		}
+
+		// buku yang sudah ditulis keduanya cukup membuang relasi duplikat
+		owned := []uint{}
+		if err := tx.Model(&book.BookAuthor{}).Where("author_id = ?", authorID).Pluck("books_id", &owned).Error; err != nil {
+			return err
+		}
+		move := tx.Model(&book.BookAuthor{}).Where("author_id = ?", duplicateID)
+		if len(owned) > 0 {
+			move = move.Where("books_id NOT IN ?", owned)
+		}
+		if err := move.Update("author_id", authorID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("author_id = ?", duplicateID).Delete(&book.BookAuthor{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&Author{}, duplicateID).Error; err != nil {
+			return err
+		}
+		ids, err := book.RefreshPenulis(tx, authorID)
+		changed = ids
+		return err
+	})
+	if err != nil {
+		logger.Error(ctx, "merge author query error", logger.Fields{"error": err, "author_id": authorID, "duplicate_id": duplicateID})
+		return err
+	}
+	book.InvalidateDetail(ctx, ad.books, changed...)
+
+	return nil
+}
+
+// checkNama memastikan nama belum dipakai author lain.
+func (ad *authorData) checkNama(ctx context.Context, authorID uint, nama string) error {
+	var count int64
+	err := uow.DB(ctx, ad.db).Model(&Author{}).Where("nama = ? AND id <> ?", nama, authorID).Count(&count).Error
+	if err != nil {
+		logger.Error(ctx, "check author name error", logger.Fields{"error": err})
+		return err
+	}
+	if count > 0 {
+		return errors.New("conflict: nama penulis sudah terdaftar")
+	}
+	return nil
+}
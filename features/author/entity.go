@@ -0,0 +1,54 @@
+package author
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Core struct {
+	ID         uint
+	Nama       string `validate:"required,max=100"`
+	Bio        string `validate:"max=5000"`
+	JumlahBuku int
+	// Buku hanya diisi pada halaman detail penulis.
+	Buku []Book
+}
+
+// Book adalah ringkasan buku yang ditulis penulis, Urutan adalah posisi
+// penulis pada daftar penulis buku tersebut.
+type Book struct {
+	ID          uint
+	Judul       string
+	TahunTerbit int
+	Urutan      int
+}
+
+type AuthorHandler interface {
+	Add() echo.HandlerFunc
+	List() echo.HandlerFunc
+	Detail() echo.HandlerFunc
+	Update() echo.HandlerFunc
+	Delete() echo.HandlerFunc
+	Merge() echo.HandlerFunc
+}
+
+type AuthorService interface {
+	Add(ctx context.Context, token interface{}, newAuthor Core) (Core, error)
+	List(ctx context.Context, q string, page, limit int) ([]Core, int64, error)
+	Detail(ctx context.Context, authorID uint) (Core, error)
+	Update(ctx context.Context, token interface{}, authorID uint, updatedData Core) (Core, error)
+	Delete(ctx context.Context, token interface{}, authorID uint) error
+	Merge(ctx context.Context, token interface{}, authorID, duplicateID uint) (Core, error)
+}
+
+type AuthorData interface {
+	Add(ctx context.Context, newAuthor Core) (Core, error)
+	List(ctx context.Context, q string, page, limit int) ([]Core, int64, error)
+	Detail(ctx context.Context, authorID uint) (Core, error)
+	Update(ctx context.Context, authorID uint, updatedData Core) (Core, error)
+	Delete(ctx context.Context, authorID uint) error
+	// Merge memindahkan semua buku duplicateID ke authorID lalu menghapus
+	// duplicateID.
+	Merge(ctx context.Context, authorID, duplicateID uint) error
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"api/features/author"
+	"api/helper"
+	"api/logger"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type authorHandle struct {
+	srv author.AuthorService
+}
+
+func New(as author.AuthorService) author.AuthorHandler {
+	return &authorHandle{
+		srv: as,
+	}
+}
+
+func (ah *authorHandle) Add() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := AddAuthorRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, err := ah.srv.Add(c.Request().Context(), c.Get("user"), *ToCore(input))
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintSuccessReponse(http.StatusCreated, "sukses menambahkan penulis", res))
+	}
+}
+
+func (ah *authorHandle) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := ListAuthorRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, total, err := ah.srv.List(c.Request().Context(), input.Q, input.Page, input.Limit)
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintListResponse(http.StatusOK, "sukses menampilkan daftar penulis", res, helper.NewPagination(input.Page, input.Limit, total)))
+	}
+}
+
+func (ah *authorHandle) Detail() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		authorID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		res, err := ah.srv.Detail(c.Request().Context(), uint(authorID))
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintSuccessReponse(http.StatusOK, "sukses menampilkan penulis", res))
+	}
+}
+
+func (ah *authorHandle) Update() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		authorID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		input := UpdateAuthorRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, err := ah.srv.Update(c.Request().Context(), c.Get("user"), uint(authorID), *ToCore(input))
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintSuccessReponse(http.StatusOK, "berhasil update penulis", res))
+	}
+}
+
+func (ah *authorHandle) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		authorID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		if err := ah.srv.Delete(c.Request().Context(), c.Get("user"), uint(authorID)); err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(http.StatusAccepted, "berhasil delete penulis")
+	}
+}
+
+func (ah *authorHandle) Merge() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		authorID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		input := MergeAuthorRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, err := ah.srv.Merge(c.Request().Context(), c.Get("user"), uint(authorID), input.AuthorID)
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintSuccessReponse(http.StatusOK, "berhasil menggabungkan penulis", res))
+	}
+}
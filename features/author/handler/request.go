@@ -0,0 +1,43 @@
+package handler
+
+import "api/features/author"
+
+type AddAuthorRequest struct {
+	Nama string `json:"nama" validate:"required,max=100"`
+	Bio  string `json:"bio" validate:"max=5000"`
+}
+
+type UpdateAuthorRequest struct {
+	Nama string `json:"nama" validate:"max=100"`
+	Bio  string `json:"bio" validate:"max=5000"`
+}
+
+type ListAuthorRequest struct {
+	Q     string `query:"q"`
+	Page  int    `query:"page" validate:"gte=1"`
+	Limit int    `query:"limit" validate:"gte=1,lte=100"`
+}
+
+// MergeAuthorRequest berisi ID penulis duplikat yang akan digabung.
+type MergeAuthorRequest struct {
+	AuthorID uint `json:"author_id" validate:"required,min=1"`
+}
+
+func ToCore(data interface{}) *author.Core {
+	res := author.Core{}
+
+	switch data.(type) {
+	case AddAuthorRequest:
+		cnv := data.(AddAuthorRequest)
+		res.Nama = cnv.Nama
+		res.Bio = cnv.Bio
+	case UpdateAuthorRequest:
+		cnv := data.(UpdateAuthorRequest)
+		res.Nama = cnv.Nama
+		res.Bio = cnv.Bio
+	default:
+		return nil
+	}
+
+	return &res
+}
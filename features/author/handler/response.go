@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"api/features/author"
+	"api/helper"
+)
+
+type AuthorResponse struct {
+	ID         uint                 `json:"id"`
+	Nama       string               `json:"nama"`
+	Bio        string               `json:"bio,omitempty"`
+	JumlahBuku int                  `json:"jumlah_buku"`
+	Buku       []AuthorBookResponse `json:"buku,omitempty"`
+}
+
+type AuthorBookResponse struct {
+	ID          uint   `json:"id"`
+	Judul       string `json:"judul"`
+	TahunTerbit int    `json:"tahun_terbit"`
+	Urutan      int    `json:"urutan"`
+}
+
+func ToResponse(data author.Core) AuthorResponse {
+	res := AuthorResponse{
+		ID:         data.ID,
+		Nama:       data.Nama,
+		Bio:        data.Bio,
+		JumlahBuku: data.JumlahBuku,
+	}
+	for _, b := range data.Buku {
+		res.Buku = append(res.Buku, AuthorBookResponse{
+			ID:          b.ID,
+			Judul:       b.Judul,
+			TahunTerbit: b.TahunTerbit,
+			Urutan:      b.Urutan,
+		})
+	}
+	return res
+}
+
+func PrintSuccessReponse(code int, message string, data interface{}) (int, interface{}) {
+	resp := map[string]interface{}{}
+	resp["data"] = ToResponse(data.(author.Core))
+
+	if message != "" {
+		resp["message"] = message
+	}
+
+	return code, resp
+}
+
+func PrintListResponse(code int, message string, data []author.Core, pagination helper.Pagination) (int, interface{}) {
+	res := []AuthorResponse{}
+	for _, v := range data {
+		res = append(res, ToResponse(v))
+	}
+
+	resp := map[string]interface{}{}
+	resp["data"] = res
+	resp["pagination"] = pagination
+
+	if message != "" {
+		resp["message"] = message
+	}
+
+	return code, resp
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"api/features/author"
+	"api/features/user"
+	"api/helper"
+	"api/logger"
+	"api/tracing"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type authorSrv struct {
+	data  author.AuthorData
+	users user.UserData
+	vld   *validator.Validate
+}
+
+// New membuat AuthorService, ud dipakai untuk memeriksa role admin karena
+// penulis dipakai bersama oleh buku semua user.
+func New(d author.AuthorData, ud user.UserData) author.AuthorService {
+	return &authorSrv{
+		data:  d,
+		users: ud,
+		vld:   validator.New(),
+	}
+}
+
+// admin memastikan pemilik token adalah admin. Mengubah, menghapus dan
+// menggabungkan penulis ikut mengubah buku milik user lain.
+func (as *authorSrv) admin(ctx context.Context, token interface{}) error {
+	id := helper.ExtractToken(token)
+	if id <= 0 {
+		return errors.New("user not found")
+	}
+	u, err := as.users.Profile(ctx, uint(id))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return errors.New("user not found")
+		}
+		return errors.New("terjadi kesalahan pada server")
+	}
+	if u.Role != user.RoleAdmin {
+		return errors.New("forbidden: hanya admin yang bisa mengubah penulis")
+	}
+	return nil
+}
+
+func (as *authorSrv) Add(ctx context.Context, token interface{}, newAuthor author.Core) (author.Core, error) {
+	ctx, span := tracing.Start(ctx, "AuthorService.Add")
+	defer span.End()
+
+	if helper.ExtractToken(token) <= 0 {
+		return author.Core{}, errors.New("user not found")
+	}
+
+	newAuthor.Nama = normalizeNama(newAuthor.Nama)
+	if err := as.vld.Struct(newAuthor); err != nil {
+		return author.Core{}, errors.New("format input penulis tidak sesuai, nama wajib diisi maksimal 100 karakter")
+	}
+
+	res, err := as.data.Add(ctx, newAuthor)
+	if err != nil {
+		return author.Core{}, errorMsg(err)
+	}
+
+	return res, nil
+}
+
+func (as *authorSrv) List(ctx context.Context, q string, page, limit int) ([]author.Core, int64, error) {
+	ctx, span := tracing.Start(ctx, "AuthorService.List")
+	defer span.End()
+
+	page, limit = helper.PageLimit(page, limit)
+	res, total, err := as.data.List(ctx, strings.TrimSpace(q), page, limit)
+	if err != nil {
+		return nil, 0, errors.New("terjadi kesalahan pada server")
+	}
+
+	return res, total, nil
+}
+
+func (as *authorSrv) Detail(ctx context.Context, authorID uint) (author.Core, error) {
+	ctx, span := tracing.Start(ctx, "AuthorService.Detail")
+	defer span.End()
+
+	res, err := as.data.Detail(ctx, authorID)
+	if err != nil {
+		return author.Core{}, errorMsg(err)
+	}
+
+	return res, nil
+}
+
+func (as *authorSrv) Update(ctx context.Context, token interface{}, authorID uint, updatedData author.Core) (author.Core, error) {
+	ctx, span := tracing.Start(ctx, "AuthorService.Update")
+	defer span.End()
+
+	if err := as.admin(ctx, token); err != nil {
+		return author.Core{}, err
+	}
+
+	// nama kosong berarti tidak diubah
+	updatedData.Nama = normalizeNama(updatedData.Nama)
+	if err := as.vld.StructExcept(updatedData, "Nama"); err != nil || len(updatedData.Nama) > 100 {
+		return author.Core{}, errors.New("format input penulis tidak sesuai")
+	}
+
+	res, err := as.data.Update(ctx, authorID, updatedData)
+	if err != nil {
+		return author.Core{}, errorMsg(err)
+	}
+
+	return res, nil
+}
+
+func (as *authorSrv) Delete(ctx context.Context, token interface{}, authorID uint) error {
+	ctx, span := tracing.Start(ctx, "AuthorService.Delete")
+	defer span.End()
+
+	if err := as.admin(ctx, token); err != nil {
+		return err
+	}
+
+	if err := as.data.Delete(ctx, authorID); err != nil {
+		return errorMsg(err)
+	}
+
+	return nil
+}
+
+func (as *authorSrv) Merge(ctx context.Context, token interface{}, authorID, duplicateID uint) (author.Core, error) {
+	ctx, span := tracing.Start(ctx, "AuthorService.Merge")
+	defer span.End()
+
+	if err := as.admin(ctx, token); err != nil {
+		return author.Core{}, err
+	}
+	if duplicateID == 0 || authorID == duplicateID {
+		return author.Core{}, errors.New("format input tidak sesuai, pilih penulis lain untuk digabung")
+	}
+
+	if err := as.data.Merge(ctx, authorID, duplicateID); err != nil {
+		return author.Core{}, errorMsg(err)
+	}
+
+	res, err := as.data.Detail(ctx, authorID)
+	if err != nil {
+		return author.Core{}, errorMsg(err)
+	}
+
+	logger.Info(ctx, "author merged", logger.Fields{"author_id": authorID, "duplicate_id": duplicateID})
+	return res, nil
+}
+
+// normalizeNama merapikan spasi berlebih agar "Eiichiro  Oda" dan
+// "Eiichiro Oda" tidak menjadi dua penulis.
+func normalizeNama(nama string) string {
+	return strings.Join(strings.Fields(nama), " ")
+}
+
+func errorMsg(err error) error {
+	switch {
+	case strings.Contains(err.Error(), "not found"):
+		return errors.New("penulis not found")
+	case strings.Contains(err.Error(), "conflict"):
+		return err
+	default:
+		return errors.New("terjadi kesalahan pada server")
+	}
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"api/features/author"
+	"api/features/user"
+	"api/helper"
+	"api/mocks"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func validToken() interface{} {
+	_, token := helper.GenerateJWT(1)
+	pToken := token.(*jwt.Token)
+	pToken.Valid = true
+	return pToken
+}
+
+// users mengembalikan UserData yang menganggap pemilik validToken sebagai
+// role.
+func users(t *testing.T, role string) *mocks.UserData {
+	ud := mocks.NewUserData(t)
+	ud.On("Profile", mock.Anything, uint(1)).Return(user.Core{ID: 1, Role: role}, nil).Maybe()
+	return ud
+}
+
+func TestAdd(t *testing.T) {
+	repo := mocks.NewAuthorData(t)
+
+	t.Run("berhasil tambah penulis", func(t *testing.T) {
+		expected := author.Core{Nama: "Eiichiro Oda"}
+		resData := author.Core{ID: 1, Nama: "Eiichiro Oda"}
+		repo.On("Add", mock.Anything, expected).Return(resData, nil).Once()
+
+		srv := New(repo, nil)
+		res, err := srv.Add(context.Background(), validToken(), author.Core{Nama: "  Eiichiro   Oda "})
+		assert.Nil(t, err)
+		assert.Equal(t, resData, res)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("nama kosong", func(t *testing.T) {
+		srv := New(repo, nil)
+		_, err := srv.Add(context.Background(), validToken(), author.Core{Nama: "   "})
+		assert.ErrorContains(t, err, "format")
+	})
+
+	t.Run("nama sudah terdaftar", func(t *testing.T) {
+		repo.On("Add", mock.Anything, mock.Anything).Return(author.Core{}, errors.New("conflict: nama penulis sudah terdaftar")).Once()
+
+		srv := New(repo, nil)
+		_, err := srv.Add(context.Background(), validToken(), author.Core{Nama: "Eiichiro Oda"})
+		assert.ErrorContains(t, err, "conflict")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("jwt tidak valid", func(t *testing.T) {
+		srv := New(repo, nil)
+		_, token := helper.GenerateJWT(1)
+		_, err := srv.Add(context.Background(), token, author.Core{Nama: "Eiichiro Oda"})
+		assert.ErrorContains(t, err, "not found")
+	})
+}
+
+func TestList(t *testing.T) {
+	repo := mocks.NewAuthorData(t)
+
+	t.Run("berhasil menampilkan penulis", func(t *testing.T) {
+		resData := []author.Core{{ID: 1, Nama: "Eiichiro Oda", JumlahBuku: 3}}
+		repo.On("List", mock.Anything, "oda", 1, helper.DefaultLimit).Return(resData, int64(1), nil).Once()
+
+		srv := New(repo, nil)
+		res, total, err := srv.List(context.Background(), " oda ", 0, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, resData, res)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("masalah di server", func(t *testing.T) {
+		repo.On("List", mock.Anything, "", 1, helper.DefaultLimit).Return(nil, int64(0), errors.New("query error")).Once()
+
+		srv := New(repo, nil)
+		_, _, err := srv.List(context.Background(), "", 1, 0)
+		assert.ErrorContains(t, err, "server")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestDetail(t *testing.T) {
+	repo := mocks.NewAuthorData(t)
+
+	t.Run("berhasil melihat penulis", func(t *testing.T) {
+		resData := author.Core{ID: 1, Nama: "Eiichiro Oda", JumlahBuku: 1, Buku: []author.Book{{ID: 1, Judul: "One Piece", TahunTerbit: 1997, Urutan: 1}}}
+		repo.On("Detail", mock.Anything, uint(1)).Return(resData, nil).Once()
+
+		srv := New(repo, nil)
+		res, err := srv.Detail(context.Background(), 1)
+		assert.Nil(t, err)
+		assert.Equal(t, resData, res)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("penulis tidak ditemukan", func(t *testing.T) {
+		repo.On("Detail", mock.Anything, uint(2)).Return(author.Core{}, errors.New("data not found")).Once()
+
+		srv := New(repo, nil)
+		_, err := srv.Detail(context.Background(), 2)
+		assert.ErrorContains(t, err, "not found")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	repo := mocks.NewAuthorData(t)
+
+	t.Run("berhasil update penulis", func(t *testing.T) {
+		resData := author.Core{ID: 1, Nama: "Eiichiro Oda", Bio: "Mangaka"}
+		repo.On("Update", mock.Anything, uint(1), author.Core{Bio: "Mangaka"}).Return(resData, nil).Once()
+
+		srv := New(repo, users(t, user.RoleAdmin))
+		res, err := srv.Update(context.Background(), validToken(), 1, author.Core{Bio: "Mangaka"})
+		assert.Nil(t, err)
+		assert.Equal(t, resData, res)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("penulis tidak ditemukan", func(t *testing.T) {
+		repo.On("Update", mock.Anything, uint(2), mock.Anything).Return(author.Core{}, errors.New("data not found")).Once()
+
+		srv := New(repo, users(t, user.RoleAdmin))
+		_, err := srv.Update(context.Background(), validToken(), 2, author.Core{Nama: "Oda"})
+		assert.ErrorContains(t, err, "not found")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("bukan admin", func(t *testing.T) {
+		srv := New(repo, users(t, user.RoleUser))
+		_, err := srv.Update(context.Background(), validToken(), 1, author.Core{Nama: "Oda"})
+		assert.ErrorContains(t, err, "forbidden")
+	})
+}
+
+func TestDelete(t *testing.T) {
+	repo := mocks.NewAuthorData(t)
+
+	t.Run("berhasil delete penulis", func(t *testing.T) {
+		repo.On("Delete", mock.Anything, uint(1)).Return(nil).Once()
+
+		srv := New(repo, users(t, user.RoleAdmin))
+		err := srv.Delete(context.Background(), validToken(), 1)
+		assert.Nil(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("penulis masih memiliki buku", func(t *testing.T) {
+		repo.On("Delete", mock.Anything, uint(2)).Return(errors.New("conflict: penulis masih memiliki buku")).Once()
+
+		srv := New(repo, users(t, user.RoleAdmin))
+		err := srv.Delete(context.Background(), validToken(), 2)
+		assert.ErrorContains(t, err, "conflict")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("bukan admin", func(t *testing.T) {
+		srv := New(repo, users(t, user.RoleUser))
+		err := srv.Delete(context.Background(), validToken(), 1)
+		assert.ErrorContains(t, err, "forbidden")
+	})
+}
+
+func TestMerge(t *testing.T) {
+	repo := mocks.NewAuthorData(t)
+
+	t.Run("berhasil menggabungkan penulis", func(t *testing.T) {
+		resData := author.Core{ID: 1, Nama: "Eiichiro Oda", JumlahBuku: 2}
+		repo.On("Merge", mock.Anything, uint(1), uint(2)).Return(nil).Once()
+		repo.On("Detail", mock.Anything, uint(1)).Return(resData, nil).Once()
+
+		srv := New(repo, users(t, user.RoleAdmin))
+		res, err := srv.Merge(context.Background(), validToken(), 1, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, resData, res)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("menggabungkan dengan diri sendiri", func(t *testing.T) {
+		srv := New(repo, users(t, user.RoleAdmin))
+		_, err := srv.Merge(context.Background(), validToken(), 1, 1)
+		assert.ErrorContains(t, err, "format")
+	})
+
+	t.Run("penulis tidak ditemukan", func(t *testing.T) {
+		repo.On("Merge", mock.Anything, uint(1), uint(3)).Return(errors.New("data not found")).Once()
+
+		srv := New(repo, users(t, user.RoleAdmin))
+		_, err := srv.Merge(context.Background(), validToken(), 1, 3)
+		assert.ErrorContains(t, err, "not found")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("bukan admin", func(t *testing.T) {
+		srv := New(repo, users(t, user.RoleUser))
+		_, err := srv.Merge(context.Background(), validToken(), 1, 2)
+		assert.ErrorContains(t, err, "forbidden")
+	})
+}
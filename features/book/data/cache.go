@@ -0,0 +1,125 @@
+package data
+
+import (
+	"api/cache"
+	"api/features/book"
+	"api/replica"
+	"api/uow"
+	"context"
+	"fmt"
+	"time"
+)
+
+// invalidating menghapus cache detail buku setiap kali buku diubah tanpa
+// menyimpan apa pun ke cache.
+type invalidating struct {
+	book.BookData
+	cache cache.Cache
+}
+
+// NewInvalidating membungkus next agar perubahan buku menghapus cache
+// detail setelah di-commit. Dipakai untuk repository di dalam unit of work,
+// yang tidak boleh membaca dari atau mengisi cache karena datanya belum
+// di-commit.
+func NewInvalidating(next book.BookData, c cache.Cache) book.BookData {
+	return &invalidating{
+		BookData: next,
+		cache:    c,
+	}
+}
+
+// InvalidateDetail menghapus cache detail bookIDs setelah transaksi pada
+// ctx di-commit. Di luar unit of work cache langsung dihapus, termasuk bila
+// perubahan gagal, karena commit yang gagal tidak bisa dibedakan dari
+// perubahan yang sudah tersimpan. c boleh nil.
+func InvalidateDetail(ctx context.Context, c cache.Cache, bookIDs ...uint) {
+	if c == nil || len(bookIDs) == 0 {
+		return
+	}
+	keys := []string{}
+	for _, id := range bookIDs {
+		keys = append(keys, detailKey(id))
+	}
+	uow.AfterCommit(ctx, func(ctx context.Context) {
+		cache.Invalidate(ctx, c, keys...)
+	})
+}
+
+func (iv *invalidating) Update(ctx context.Context, userID uint, bookID uint, updatedData book.Core, fields []string) (book.Core, error) {
+	defer InvalidateDetail(ctx, iv.cache, bookID)
+	return iv.BookData.Update(ctx, userID, bookID, updatedData, fields)
+}
+
+func (iv *invalidating) Delete(ctx context.Context, userID uint, bookID uint, version uint) error {
+	defer InvalidateDetail(ctx, iv.cache, bookID)
+	return iv.BookData.Delete(ctx, userID, bookID, version)
+}
+
+func (iv *invalidating) UpdateCover(ctx context.Context, userID uint, bookID uint, coverKey, thumbnailKey string) (string, string, error) {
+	defer InvalidateDetail(ctx, iv.cache, bookID)
+	return iv.BookData.UpdateCover(ctx, userID, bookID, coverKey, thumbnailKey)
+}
+
+func (iv *invalidating) Restore(ctx context.Context, userID uint, bookID uint) (book.Core, error) {
+	defer InvalidateDetail(ctx, iv.cache, bookID)
+	return iv.BookData.Restore(ctx, userID, bookID)
+}
+
+func (iv *invalidating) Purge(ctx context.Context, userID uint, bookID uint) (book.Core, error) {
+	defer InvalidateDetail(ctx, iv.cache, bookID)
+	return iv.BookData.Purge(ctx, userID, bookID)
+}
+
+func (iv *invalidating) PurgeTrash(ctx context.Context, before time.Time) ([]book.Core, error) {
+	res, err := iv.BookData.PurgeTrash(ctx, before)
+	ids := []uint{}
+	for _, b := range res {
+		ids = append(ids, b.ID)
+	}
+	InvalidateDetail(ctx, iv.cache, ids...)
+	return res, err
+}
+
+func (iv *invalidating) Transfer(ctx context.Context, fromUserID uint, toUserID uint) ([]uint, error) {
+	ids, err := iv.BookData.Transfer(ctx, fromUserID, toUserID)
+	InvalidateDetail(ctx, iv.cache, ids...)
+	return ids, err
+}
+
+// cachedData menyimpan hasil Detail di cache dan menghapusnya setiap kali
+// buku diubah. Method lain diteruskan langsung ke next.
+type cachedData struct {
+	book.BookData
+	cache cache.Cache
+	group cache.Group
+	ttl   time.Duration
+}
+
+// NewCached membungkus next dengan cache. Data yang diubah fitur lain,
+// yaitu rating dari ulasan, nama pemilik dan buku yang disembunyikan karena
+// pemiliknya nonaktif, baru terlihat setelah ttl.
+func NewCached(next book.BookData, c cache.Cache, ttl time.Duration) book.BookData {
+	return &cachedData{
+		BookData: NewInvalidating(next, c),
+		cache:    c,
+		ttl:      ttl,
+	}
+}
+
+func detailKey(id uint) string {
+	return fmt.Sprintf("book:%d", id)
+}
+
+// Isi cache selalu dibaca dari primary agar data replica yang tertinggal
+// tidak ikut tersimpan sampai TTL habis. Di dalam unit of work cache
+// dilewati agar data yang belum di-commit tidak tersimpan.
+func (cd *cachedData) Detail(ctx context.Context, bookID uint) (book.Core, error) {
+	if uow.InTx(ctx) {
+		return cd.BookData.Detail(ctx, bookID)
+	}
+	res := book.Core{}
+	err := cache.Load(ctx, cd.cache, &cd.group, "book", detailKey(bookID), cd.ttl, &res, func() (interface{}, error) {
+		return cd.BookData.Detail(replica.Primary(ctx), bookID)
+	})
+	return res, err
+}
@@ -2,32 +2,225 @@ package data
 
 import (
 	"api/features/book"
+	"encoding/json"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+// Books.Penulis tetap disimpan sebagai gabungan nama penulis agar pencarian
+// dan response lama tidak berubah, sumber datanya adalah BookAuthor.
 type Books struct {
 	gorm.Model
-	Judul       string
-	TahunTerbit int
-	Penulis     string
-	UserID      uint
+	Version       uint `gorm:"not null;default:1"`
+	Judul         string
+	TahunTerbit   int
+	Penulis       string
+	UserID        uint
+	ISBN          string `gorm:"column:isbn;size:13;index"`
+	Penerbit      string `gorm:"size:100;index"`
+	Bahasa        string `gorm:"size:35;index"`
+	JumlahHalaman int
+	Deskripsi     string  `gorm:"type:text"`
+	Genres        []Genre `gorm:"many2many:book_genres;"`
+	CoverKey      string
+	ThumbnailKey  string
+	// RatingRata dan JumlahUlasan dihitung ulang setiap ulasan berubah agar
+	// daftar buku bisa diurutkan tanpa agregasi.
+	RatingRata   float64 `gorm:"type:decimal(3,2);default:0;index"`
+	JumlahUlasan int     `gorm:"default:0;index"`
+}
+
+// Genre dipakai juga sebagai tag bebas, nama disimpan dalam huruf kecil.
+type Genre struct {
+	ID   uint   `gorm:"primaryKey"`
+	Nama string `gorm:"size:50;uniqueIndex"`
+}
+
+// BookAuthor menghubungkan buku dengan penulisnya, Urutan dimulai dari 1.
+type BookAuthor struct {
+	BooksID  uint `gorm:"primaryKey;autoIncrement:false"`
+	AuthorID uint `gorm:"primaryKey;autoIncrement:false;index"`
+	Urutan   int
+}
+
+// authorRow adalah tampilan tabel authors yang dibutuhkan query buku, model
+// lengkapnya ada di package author/data.
+type authorRow struct {
+	ID        uint
+	Nama      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (authorRow) TableName() string {
+	return "authors"
+}
+
+// SplitPenulis memecah teks penulis bebas menjadi nama-nama penulis,
+// misalnya "Neil Gaiman & Terry Pratchett".
+func SplitPenulis(penulis string) []string {
+	parts := strings.FieldsFunc(penulis, func(r rune) bool {
+		return r == ',' || r == ';' || r == '&'
+	})
+	res := []string{}
+	for _, p := range parts {
+		if p = strings.Join(strings.Fields(p), " "); p != "" {
+			res = append(res, p)
+		}
+	}
+	return res
 }
 
 func ToCore(data Books) book.Core {
-	return book.Core{
-		ID:          data.ID,
-		Judul:       data.Judul,
-		TahunTerbit: data.TahunTerbit,
-		Penulis:     data.Penulis,
+	res := book.Core{
+		ID:            data.ID,
+		Version:       data.Version,
+		Judul:         data.Judul,
+		TahunTerbit:   data.TahunTerbit,
+		Penulis:       data.Penulis,
+		UserID:        data.UserID,
+		ISBN:          data.ISBN,
+		Penerbit:      data.Penerbit,
+		Bahasa:        data.Bahasa,
+		JumlahHalaman: data.JumlahHalaman,
+		Deskripsi:     data.Deskripsi,
+		CoverKey:      data.CoverKey,
+		ThumbnailKey:  data.ThumbnailKey,
+		RatingRata:    data.RatingRata,
+		JumlahUlasan:  data.JumlahUlasan,
+	}
+	if data.DeletedAt.Valid {
+		res.DeletedAt = data.DeletedAt.Time
 	}
+	for _, g := range data.Genres {
+		res.Genre = append(res.Genre, g.Nama)
+	}
+	return res
 }
 
+// CoreToData tidak mengisi Genres, relasi genre disimpan terpisah agar
+// Updates tidak ikut meng-upsert asosiasi.
 func CoreToData(data book.Core) Books {
 	return Books{
-		Model:       gorm.Model{ID: data.ID},
-		Judul:       data.Judul,
-		Penulis:     data.Penulis,
-		TahunTerbit: data.TahunTerbit,
+		Model:         gorm.Model{ID: data.ID},
+		Judul:         data.Judul,
+		Penulis:       data.Penulis,
+		TahunTerbit:   data.TahunTerbit,
+		ISBN:          data.ISBN,
+		Penerbit:      data.Penerbit,
+		Bahasa:        data.Bahasa,
+		JumlahHalaman: data.JumlahHalaman,
+		Deskripsi:     data.Deskripsi,
+	}
+}
+
+// BookRevision menyimpan metadata buku sebelum dan sesudah satu kali
+// update sebagai JSON. Rev adalah versi buku sebelum diubah sehingga unik
+// per buku.
+type BookRevision struct {
+	ID        uint `gorm:"primaryKey"`
+	BooksID   uint `gorm:"uniqueIndex:idx_book_rev"`
+	Rev       uint `gorm:"uniqueIndex:idx_book_rev"`
+	UserID    uint
+	Before    string `gorm:"type:text"`
+	After     string `gorm:"type:text"`
+	CreatedAt time.Time
+}
+
+func RevisionToCore(data BookRevision) book.Revision {
+	res := book.Revision{
+		Rev:       data.Rev,
+		BookID:    data.BooksID,
+		UserID:    data.UserID,
+		CreatedAt: data.CreatedAt,
+	}
+	// snapshot selalu ditulis oleh Update, isi rusak cukup diabaikan
+	_ = json.Unmarshal([]byte(data.Before), &res.Before)
+	_ = json.Unmarshal([]byte(data.After), &res.After)
+	return res
+}
+
+// ToSnapshot mengambil metadata buku yang dicatat di revisi, Genres harus
+// sudah di-preload.
+func ToSnapshot(data Books) book.Snapshot {
+	res := book.Snapshot{
+		Judul:         data.Judul,
+		TahunTerbit:   data.TahunTerbit,
+		Penulis:       data.Penulis,
+		ISBN:          data.ISBN,
+		Penerbit:      data.Penerbit,
+		Bahasa:        data.Bahasa,
+		JumlahHalaman: data.JumlahHalaman,
+		Deskripsi:     data.Deskripsi,
+	}
+	for _, g := range data.Genres {
+		res.Genre = append(res.Genre, g.Nama)
+	}
+	return res
+}
+
+// ImportJob menyimpan status dan laporan import buku, laporan per baris
+// disimpan sebagai JSON.
+type ImportJob struct {
+	ID         uint   `gorm:"primaryKey"`
+	UserID     uint   `gorm:"index"`
+	Status     string `gorm:"size:20"`
+	Total      int
+	Created    int
+	Skipped    int
+	Failed     int
+	Results    string `gorm:"type:longtext"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	FinishedAt *time.Time
+}
+
+type importResult struct {
+	Line   int    `json:"line"`
+	Status string `json:"status"`
+	BookID uint   `json:"book_id,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func JobToCore(data ImportJob) book.ImportJob {
+	res := book.ImportJob{
+		ID:         data.ID,
+		UserID:     data.UserID,
+		Status:     data.Status,
+		Total:      data.Total,
+		Created:    data.Created,
+		Skipped:    data.Skipped,
+		Failed:     data.Failed,
+		CreatedAt:  data.CreatedAt,
+		FinishedAt: data.FinishedAt,
+	}
+
+	rows := []importResult{}
+	_ = json.Unmarshal([]byte(data.Results), &rows)
+	for _, r := range rows {
+		res.Results = append(res.Results, book.ImportResult(r))
+	}
+	return res
+}
+
+func CoreToJob(data book.ImportJob) ImportJob {
+	rows := make([]importResult, 0, len(data.Results))
+	for _, r := range data.Results {
+		rows = append(rows, importResult(r))
+	}
+	results, _ := json.Marshal(rows)
+
+	return ImportJob{
+		ID:         data.ID,
+		UserID:     data.UserID,
+		Status:     data.Status,
+		Total:      data.Total,
+		Created:    data.Created,
+		Skipped:    data.Skipped,
+		Failed:     data.Failed,
+		Results:    string(results),
+		FinishedAt: data.FinishedAt,
 	}
 }
@@ -2,10 +2,20 @@ package data
 
 import (
 	"api/features/book"
+	"api/features/webhook"
+	"api/logger"
+	"api/outbox"
+	"api/uow"
+	"context"
+	"encoding/json"
 	"errors"
-	"log"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type bookData struct {
@@ -18,71 +28,891 @@ func New(db *gorm.DB) book.BookData {
 	}
 }
 
-func (bd *bookData) Add(userID uint, newBook book.Core) (book.Core, error) {
-	cnv := CoreToData(newBook)
-	cnv.UserID = uint(userID)
-	err := bd.db.Create(&cnv).Error
+func (bd *bookData) Add(ctx context.Context, userID uint, newBook book.Core) (book.Core, error) {
+	var res book.Core
+	err := uow.DB(ctx, bd.db).Transaction(func(tx *gorm.DB) error {
+		var err error
+		res, err = addBook(tx, userID, newBook)
+		return err
+	})
+	if err != nil {
+		logger.Error(ctx, "add book query error", logger.Fields{"error": err})
+		return book.Core{}, err
+	}
+
+	return res, nil
+}
+
+func (bd *bookData) AddBatch(ctx context.Context, userID uint, newBooks []book.Core) ([]book.Core, error) {
+	res := make([]book.Core, 0, len(newBooks))
+	err := uow.DB(ctx, bd.db).Transaction(func(tx *gorm.DB) error {
+		for _, b := range newBooks {
+			added, err := addBook(tx, userID, b)
+			if err != nil {
+				return err
+			}
+			res = append(res, added)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error(ctx, "add book batch query error", logger.Fields{"error": err, "count": len(newBooks)})
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// addBook menyimpan buku beserta penulis dan genre di dalam transaksi tx.
+func addBook(tx *gorm.DB, userID uint, newBook book.Core) (book.Core, error) {
+	authors, err := resolveAuthors(tx, newBook.PenulisID, newBook.Penulis)
 	if err != nil {
 		return book.Core{}, err
 	}
+	genres, err := findOrCreateGenres(tx, newBook.Genre)
+	if err != nil {
+		return book.Core{}, err
+	}
+
+	cnv := CoreToData(newBook)
+	cnv.UserID = userID
+	cnv.Version = 1
+	cnv.Genres = genres
+	cnv.Penulis = joinNames(authors)
+	if err := tx.Omit("Genres.*").Create(&cnv).Error; err != nil {
+		return book.Core{}, err
+	}
+	if err := linkAuthors(tx, cnv.ID, authors); err != nil {
+		return book.Core{}, err
+	}
+	snap := ToSnapshot(cnv)
+	if err := publish(tx, webhook.EventBookCreated, bookEvent{ID: cnv.ID, UserID: userID, Version: cnv.Version, Book: &snap}); err != nil {
+		return book.Core{}, err
+	}
 
 	newBook.ID = cnv.ID
+	newBook.Version = cnv.Version
+	newBook.Authors = authors
+	newBook.Penulis = cnv.Penulis
 
 	return newBook, nil
 }
-func (bd *bookData) Update(userID uint, bookID uint, updatedData book.Core) (book.Core, error) {
-	getID := Books{}
-	err := bd.db.Where("id = ?", bookID).First(&getID).Error
 
+func (bd *bookData) ExistingISBN(ctx context.Context, userID uint, isbns []string) ([]string, error) {
+	res := []string{}
+	if len(isbns) == 0 {
+		return res, nil
+	}
+
+	err := uow.DB(ctx, bd.db).Model(&Books{}).
+		Where("user_id = ? AND isbn IN ?", userID, isbns).
+		Distinct().Pluck("isbn", &res).Error
 	if err != nil {
-		log.Println("get user book error", err.Error())
+		logger.Error(ctx, "get existing isbn error", logger.Fields{"error": err})
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (bd *bookData) List(ctx context.Context, filter book.Filter) ([]book.Core, int64, error) {
+	qry := uow.DB(ctx, bd.db).Model(&Books{}).Scopes(ActiveOwner)
+	if filter.UserID > 0 {
+		qry = qry.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Judul != "" {
+		qry = qry.Where("judul LIKE ?", "%"+filter.Judul+"%")
+	}
+	if filter.Penulis != "" {
+		qry = qry.Where("penulis LIKE ?", "%"+filter.Penulis+"%")
+	}
+	if filter.AuthorID > 0 {
+		qry = qry.Where("id IN (?)", bd.db.Model(&BookAuthor{}).Select("books_id").Where("author_id = ?", filter.AuthorID))
+	}
+	if filter.ISBN != "" {
+		qry = qry.Where("isbn = ?", filter.ISBN)
+	}
+	if filter.Penerbit != "" {
+		qry = qry.Where("penerbit = ?", filter.Penerbit)
+	}
+	if filter.Bahasa != "" {
+		qry = qry.Where("bahasa = ?", filter.Bahasa)
+	}
+	if filter.Genre != "" {
+		qry = qry.Where("id IN (?)", bd.db.Table("book_genres").
+			Select("book_genres.books_id").
+			Joins("JOIN genres ON genres.id = book_genres.genre_id").
+			Where("genres.nama = ?", filter.Genre))
+	}
+	if filter.MinHalaman > 0 {
+		qry = qry.Where("jumlah_halaman >= ?", filter.MinHalaman)
+	}
+	if filter.MaxHalaman > 0 {
+		qry = qry.Where("jumlah_halaman <= ?", filter.MaxHalaman)
+	}
+
+	var total int64
+	if err := qry.Count(&total).Error; err != nil {
+		logger.Error(ctx, "count book query error", logger.Fields{"error": err})
+		return nil, 0, err
+	}
+
+	order := "id DESC"
+	switch filter.Sort {
+	case book.SortRating:
+		order = "rating_rata DESC, jumlah_ulasan DESC, id DESC"
+	case book.SortUlasan:
+		order = "jumlah_ulasan DESC, id DESC"
+	}
+
+	rows := []Books{}
+	err := qry.Preload("Genres").Order(order).
+		Offset((filter.Page - 1) * filter.Limit).Limit(filter.Limit).
+		Find(&rows).Error
+	if err != nil {
+		logger.Error(ctx, "list book query error", logger.Fields{"error": err})
+		return nil, 0, err
+	}
+
+	owners, err := bd.owners(ctx, rows...)
+	if err != nil {
+		return nil, 0, err
+	}
+	authors, err := bd.authors(ctx, rows...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res := []book.Core{}
+	for _, r := range rows {
+		c := ToCore(r)
+		c.Pemilik = owners[r.UserID]
+		c.Authors = authors[r.ID]
+		res = append(res, c)
+	}
+
+	return res, total, nil
+}
+
+func (bd *bookData) Detail(ctx context.Context, bookID uint) (book.Core, error) {
+	res := Books{}
+	err := uow.DB(ctx, bd.db).Scopes(ActiveOwner).Preload("Genres").Where("id = ?", bookID).First(&res).Error
+	if err != nil {
+		logger.Error(ctx, "get book error", logger.Fields{"error": err, "book_id": bookID})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return book.Core{}, errors.New("data not found")
+		}
 		return book.Core{}, err
 	}
 
-	if getID.UserID != userID {
-		log.Println("tidak memiliki akses")
-		return book.Core{}, errors.New("tidak memiliki akses")
+	owners, err := bd.owners(ctx, res)
+	if err != nil {
+		return book.Core{}, err
+	}
+	authors, err := bd.authors(ctx, res)
+	if err != nil {
+		return book.Core{}, err
 	}
 
-	cnv := CoreToData(updatedData)
-	qry := bd.db.Where("id = ?", bookID).Updates(&cnv)
-	if qry.RowsAffected <= 0 {
-		log.Println("update book query error : data not found")
-		return book.Core{}, errors.New("not found")
+	cnv := ToCore(res)
+	cnv.Pemilik = owners[res.UserID]
+	cnv.Authors = authors[res.ID]
+
+	return cnv, nil
+}
+
+// bookColumns adalah field Core yang langsung disimpan sebagai kolom Books,
+// dipakai untuk memilih kolom yang ikut diubah saat update.
+var bookColumns = []string{"Judul", "TahunTerbit", "ISBN", "Penerbit", "Bahasa", "JumlahHalaman", "Deskripsi"}
+
+func (bd *bookData) Lock(ctx context.Context, userID uint, bookID uint) (book.Core, error) {
+	if _, err := ownBook(uow.DB(ctx, bd.db), userID, bookID); err != nil {
+		return book.Core{}, err
+	}
+	return bd.Detail(ctx, bookID)
+}
+
+func (bd *bookData) Update(ctx context.Context, userID uint, bookID uint, updatedData book.Core, fields []string) (book.Core, error) {
+	changed := map[string]bool{}
+	for _, f := range fields {
+		changed[f] = true
 	}
 
-	if err := qry.Error; err != nil {
-		log.Println("update book query error :", err.Error())
+	cnv := CoreToData(updatedData)
+	cnv.ID = 0
+	err := uow.DB(ctx, bd.db).Transaction(func(tx *gorm.DB) error {
+		old, err := ownBook(tx, userID, bookID)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(old, updatedData.Version); err != nil {
+			return err
+		}
+		before, err := snapshotOf(tx, bookID)
+		if err != nil {
+			return err
+		}
+
+		columns := []string{}
+		for _, c := range bookColumns {
+			if changed[c] {
+				columns = append(columns, c)
+			}
+		}
+
+		// penulis hanya diganti bila dikirim, baik berupa ID maupun teks
+		if changed["PenulisID"] || changed["Penulis"] {
+			authors, err := resolveAuthors(tx, updatedData.PenulisID, updatedData.Penulis)
+			if err != nil {
+				return err
+			}
+			cnv.Penulis = joinNames(authors)
+			columns = append(columns, "Penulis")
+			if err := linkAuthors(tx, bookID, authors); err != nil {
+				return err
+			}
+		}
+
+		if changed["Genre"] {
+			genres, err := findOrCreateGenres(tx, updatedData.Genre)
+			if err != nil {
+				return err
+			}
+			err = tx.Model(&Books{Model: gorm.Model{ID: bookID}}).Omit("Genres.*").Association("Genres").Replace(genres)
+			if err != nil {
+				return err
+			}
+		} else if len(columns) == 0 {
+			return nil
+		}
+
+		// Select membuat nilai kosong ikut tersimpan, berbeda dengan
+		// Updates biasa yang melewati zero value.
+		cnv.Version = old.Version + 1
+		columns = append(columns, "Version", "UpdatedAt")
+		if err := tx.Model(&Books{}).Where("id = ? AND user_id = ?", bookID, userID).Select(columns).Updates(&cnv).Error; err != nil {
+			return err
+		}
+		after, err := addRevision(tx, userID, old, before)
+		if err != nil {
+			return err
+		}
+		return publish(tx, webhook.EventBookUpdated, bookEvent{ID: bookID, UserID: userID, Version: cnv.Version, Book: &after})
+	})
+	if err != nil {
+		logger.Error(ctx, "update book query error", logger.Fields{"error": err, "book_id": bookID})
 		return book.Core{}, err
 	}
 
-	return ToCore(cnv), nil
+	return bd.Detail(ctx, bookID)
 }
 
-func (bd *bookData) Delete(userID uint, bookID uint) error {
-	getID := Books{}
-	err := bd.db.Where("id = ? ", bookID).First(&getID).Error
+func (bd *bookData) Delete(ctx context.Context, userID uint, bookID uint, version uint) error {
+	err := uow.DB(ctx, bd.db).Transaction(func(tx *gorm.DB) error {
+		old, err := ownBook(tx, userID, bookID)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(old, version); err != nil {
+			return err
+		}
 
+		qry := tx.Where("id = ? AND user_id = ?", bookID, userID).Delete(&Books{})
+		if err := qry.Error; err != nil {
+			return err
+		}
+		if qry.RowsAffected == 0 {
+			return errors.New("data not found")
+		}
+		return publish(tx, webhook.EventBookDeleted, bookEvent{ID: bookID, UserID: userID, Version: old.Version})
+	})
 	if err != nil {
-		log.Println("get user book error", err.Error())
-		return errors.New("failed to get user book data")
+		logger.Error(ctx, "delete book query error", logger.Fields{"error": err, "book_id": bookID})
+		return err
+	}
+
+	return nil
+}
+
+func (bd *bookData) UpdateCover(ctx context.Context, userID uint, bookID uint, coverKey, thumbnailKey string) (string, string, error) {
+	var old Books
+	err := uow.DB(ctx, bd.db).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if old, err = ownBook(tx, userID, bookID); err != nil {
+			return err
+		}
+
+		err = tx.Model(&Books{}).Where("id = ? AND user_id = ?", bookID, userID).Updates(map[string]interface{}{
+			"cover_key":     coverKey,
+			"thumbnail_key": thumbnailKey,
+			"version":       gorm.Expr("version + 1"),
+		}).Error
+		if err != nil {
+			return err
+		}
+		snap, err := snapshotOf(tx, bookID)
+		if err != nil {
+			return err
+		}
+		return publish(tx, webhook.EventBookUpdated, bookEvent{ID: bookID, UserID: userID, Version: old.Version + 1, Book: &snap})
+	})
+	if err != nil {
+		logger.Error(ctx, "update cover query error", logger.Fields{"error": err, "book_id": bookID})
+		return "", "", err
+	}
+
+	return old.CoverKey, old.ThumbnailKey, nil
+}
+
+// snapshotOf membaca metadata buku yang dicatat di revisi.
+func snapshotOf(tx *gorm.DB, bookID uint) (book.Snapshot, error) {
+	row := Books{}
+	if err := tx.Preload("Genres").Where("id = ?", bookID).First(&row).Error; err != nil {
+		return book.Snapshot{}, err
+	}
+	return ToSnapshot(row), nil
+}
+
+// addRevision mencatat isi buku sebelum dan sesudah diubah lalu
+// mengembalikan isi sesudahnya. Update yang tidak mengubah metadata,
+// misalnya mengirim nilai yang sama, tidak dicatat.
+func addRevision(tx *gorm.DB, userID uint, old Books, before book.Snapshot) (book.Snapshot, error) {
+	after, err := snapshotOf(tx, old.ID)
+	if err != nil {
+		return book.Snapshot{}, err
+	}
+	if reflect.DeepEqual(before, after) {
+		return after, nil
+	}
+
+	b, _ := json.Marshal(before)
+	a, _ := json.Marshal(after)
+	return after, tx.Create(&BookRevision{BooksID: old.ID, Rev: old.Version, UserID: userID, Before: string(b), After: string(a)}).Error
+}
+
+// bookEvent adalah data event buku yang ditulis ke outbox. Book hanya
+// diisi untuk event yang mengubah isi buku.
+type bookEvent struct {
+	ID      uint           `json:"id"`
+	UserID  uint           `json:"user_id"`
+	Version uint           `json:"version"`
+	Book    *book.Snapshot `json:"book,omitempty"`
+}
+
+// publish menulis event buku ke outbox di transaksi tx sehingga event hanya
+// diteruskan ke webhook bila perubahan bukunya ikut di-commit.
+func publish(tx *gorm.DB, event string, data bookEvent) error {
+	return outbox.Add(tx, event, data.UserID, data)
+}
+
+// publishUpdated menulis event book.updated berisi isi buku terbaru ke
+// pemiliknya saat ini, dipakai perubahan yang tidak lewat Update seperti
+// pemindahan buku dan perubahan nama penulis.
+func publishUpdated(tx *gorm.DB, bookID uint) error {
+	row := Books{}
+	if err := tx.Preload("Genres").Where("id = ?", bookID).First(&row).Error; err != nil {
+		return err
+	}
+	snap := ToSnapshot(row)
+	return publish(tx, webhook.EventBookUpdated, bookEvent{ID: row.ID, UserID: row.UserID, Version: row.Version, Book: &snap})
+}
+
+func (bd *bookData) History(ctx context.Context, userID uint, bookID uint, page, limit int) ([]book.Revision, int64, error) {
+	db := uow.DB(ctx, bd.db)
+	if err := checkOwner(db, userID, bookID); err != nil {
+		return nil, 0, err
+	}
+
+	qry := db.Model(&BookRevision{}).Where("books_id = ?", bookID)
+
+	var total int64
+	if err := qry.Count(&total).Error; err != nil {
+		logger.Error(ctx, "count revision query error", logger.Fields{"error": err, "book_id": bookID})
+		return nil, 0, err
 	}
 
-	if getID.UserID != userID {
-		log.Println("tidak memiliki akses")
-		return errors.New("tidak memiliki akses")
+	rows := []BookRevision{}
+	if err := qry.Order("rev DESC").Offset((page - 1) * limit).Limit(limit).Find(&rows).Error; err != nil {
+		logger.Error(ctx, "list revision query error", logger.Fields{"error": err, "book_id": bookID})
+		return nil, 0, err
 	}
 
-	qry := bd.db.Delete(&Books{}, bookID)
+	res := []book.Revision{}
+	for _, r := range rows {
+		res = append(res, RevisionToCore(r))
+	}
 
-	affRows := qry.RowsAffected
+	return res, total, nil
+}
 
-	if affRows <= 0 {
-		log.Println("no rows affected")
-		return errors.New("failed to delete user book, data not found")
+func (bd *bookData) Revision(ctx context.Context, userID uint, bookID uint, rev uint) (book.Revision, error) {
+	db := uow.DB(ctx, bd.db)
+	if err := checkOwner(db, userID, bookID); err != nil {
+		return book.Revision{}, err
+	}
+
+	row := BookRevision{}
+	err := db.Where("books_id = ? AND rev = ?", bookID, rev).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return book.Revision{}, errors.New("revision not found")
+	}
+	if err != nil {
+		logger.Error(ctx, "get revision error", logger.Fields{"error": err, "book_id": bookID, "rev": rev})
+		return book.Revision{}, err
+	}
+
+	return RevisionToCore(row), nil
+}
+
+// checkOwner seperti ownBook tetapi tanpa mengunci baris, dipakai untuk
+// membaca data yang hanya boleh dilihat pemilik buku.
+func checkOwner(db *gorm.DB, userID, bookID uint) error {
+	row := Books{}
+	err := db.Select("id", "user_id").Where("id = ?", bookID).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("data not found")
+	}
+	if err != nil {
+		return err
+	}
+	if row.UserID != userID {
+		return errors.New("forbidden: tidak memiliki akses")
 	}
 	return nil
+}
+
+// checkVersion menolak perubahan bila versi buku sudah berbeda dengan versi
+// yang dibaca client, 0 berarti tanpa pengecekan.
+func checkVersion(row Books, version uint) error {
+	if version != 0 && row.Version != version {
+		return fmt.Errorf("precondition failed: buku sudah diubah (versi %d)", row.Version)
+	}
+	return nil
+}
 
+// ownBook mengunci baris buku sampai transaksi selesai lalu memastikan buku
+// milik userID, sehingga pemilik tidak bisa berubah di antara pengecekan
+// dan penulisan. Buku yang tidak ada dibedakan dari buku milik user lain.
+func ownBook(tx *gorm.DB, userID, bookID uint) (Books, error) {
+	row := Books{}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", bookID).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Books{}, errors.New("data not found")
+	}
+	if err != nil {
+		return Books{}, err
+	}
+	if row.UserID != userID {
+		logger.Warn(tx.Statement.Context, "tidak memiliki akses", logger.Fields{"book_id": bookID, "user_id": userID})
+		return Books{}, errors.New("forbidden: tidak memiliki akses")
+	}
+	return row, nil
+}
+
+func (bd *bookData) CreateImportJob(ctx context.Context, job book.ImportJob) (book.ImportJob, error) {
+	cnv := CoreToJob(job)
+	if err := uow.DB(ctx, bd.db).Create(&cnv).Error; err != nil {
+		logger.Error(ctx, "create import job error", logger.Fields{"error": err})
+		return book.ImportJob{}, err
+	}
+
+	return JobToCore(cnv), nil
+}
+
+func (bd *bookData) UpdateImportJob(ctx context.Context, job book.ImportJob) error {
+	cnv := CoreToJob(job)
+	// job yang sudah dibatalkan tidak boleh ditimpa progress berikutnya
+	qry := uow.DB(ctx, bd.db).Model(&ImportJob{}).Where("id = ? AND status <> ?", job.ID, book.ImportCancelled).
+		Select("status", "total", "created", "skipped", "failed", "results", "finished_at").
+		Updates(&cnv)
+	if qry.Error != nil {
+		logger.Error(ctx, "update import job error", logger.Fields{"error": qry.Error, "job_id": job.ID})
+		return qry.Error
+	}
+	if qry.RowsAffected == 0 {
+		return errors.New("import cancelled")
+	}
+
+	return nil
+}
+
+func (bd *bookData) CancelImports(ctx context.Context, userID uint) error {
+	err := uow.DB(ctx, bd.db).Model(&ImportJob{}).
+		Where("user_id = ? AND status IN ?", userID, []string{book.ImportPending, book.ImportRunning}).
+		Updates(map[string]interface{}{"status": book.ImportCancelled, "finished_at": time.Now()}).Error
+	if err != nil {
+		logger.Error(ctx, "cancel import job error", logger.Fields{"error": err, "user_id": userID})
+		return err
+	}
+
+	return nil
+}
+
+func (bd *bookData) Transfer(ctx context.Context, fromUserID uint, toUserID uint) ([]uint, error) {
+	ids := []uint{}
+	err := uow.DB(ctx, bd.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Books{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", fromUserID).Order("id").Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		err = tx.Model(&Books{}).Where("id IN ?", ids).
+			Updates(map[string]interface{}{"user_id": toUserID, "version": gorm.Expr("version + 1")}).Error
+		if err != nil {
+			return err
+		}
+		// event dikirim ke pemilik baru
+		for _, id := range ids {
+			if err := publishUpdated(tx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error(ctx, "transfer book error", logger.Fields{"error": err, "from": fromUserID, "to": toUserID})
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// trashed memilih buku yang sudah dihapus tetapi belum dihapus permanen.
+func trashed(tx *gorm.DB) *gorm.DB {
+	return tx.Unscoped().Where("books.deleted_at IS NOT NULL")
+}
+
+func (bd *bookData) Trash(ctx context.Context, userID uint, page, limit int) ([]book.Core, int64, error) {
+	qry := trashed(uow.DB(ctx, bd.db)).Model(&Books{}).Where("user_id = ?", userID)
+
+	var total int64
+	if err := qry.Count(&total).Error; err != nil {
+		logger.Error(ctx, "count trash query error", logger.Fields{"error": err})
+		return nil, 0, err
+	}
+
+	rows := []Books{}
+	err := qry.Preload("Genres").Order("deleted_at DESC, id DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		logger.Error(ctx, "list trash query error", logger.Fields{"error": err})
+		return nil, 0, err
+	}
+
+	owners, err := bd.owners(ctx, rows...)
+	if err != nil {
+		return nil, 0, err
+	}
+	authors, err := bd.authors(ctx, rows...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res := []book.Core{}
+	for _, r := range rows {
+		c := ToCore(r)
+		c.Pemilik = owners[r.UserID]
+		c.Authors = authors[r.ID]
+		res = append(res, c)
+	}
+
+	return res, total, nil
+}
+
+func (bd *bookData) Restore(ctx context.Context, userID uint, bookID uint) (book.Core, error) {
+	err := uow.DB(ctx, bd.db).Transaction(func(tx *gorm.DB) error {
+		row, err := ownBook(trashed(tx), userID, bookID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().Model(&Books{}).Where("id = ?", bookID).Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		}).Error
+		if err != nil {
+			return err
+		}
+		return publish(tx, webhook.EventBookRestored, bookEvent{ID: bookID, UserID: userID, Version: row.Version + 1})
+	})
+	if err != nil {
+		logger.Error(ctx, "restore book query error", logger.Fields{"error": err, "book_id": bookID})
+		return book.Core{}, err
+	}
+
+	return bd.Detail(ctx, bookID)
+}
+
+func (bd *bookData) Purge(ctx context.Context, userID uint, bookID uint) (book.Core, error) {
+	var old Books
+	err := uow.DB(ctx, bd.db).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if old, err = ownBook(trashed(tx), userID, bookID); err != nil {
+			return err
+		}
+		if err := publish(tx, webhook.EventBookPurged, bookEvent{ID: bookID, UserID: userID, Version: old.Version}); err != nil {
+			return err
+		}
+
+		return HardDelete(tx, []uint{bookID})
+	})
+	if err != nil {
+		logger.Error(ctx, "purge book query error", logger.Fields{"error": err, "book_id": bookID})
+		return book.Core{}, err
+	}
+
+	return ToCore(old), nil
+}
+
+func (bd *bookData) PurgeTrash(ctx context.Context, before time.Time) ([]book.Core, error) {
+	rows := []Books{}
+	err := uow.DB(ctx, bd.db).Transaction(func(tx *gorm.DB) error {
+		err := trashed(tx).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("deleted_at < ?", before).Find(&rows).Error
+		if err != nil {
+			return err
+		}
+
+		ids := make([]uint, 0, len(rows))
+		for _, r := range rows {
+			ids = append(ids, r.ID)
+			if err := publish(tx, webhook.EventBookPurged, bookEvent{ID: r.ID, UserID: r.UserID, Version: r.Version}); err != nil {
+				return err
+			}
+		}
+		return HardDelete(tx, ids)
+	})
+	if err != nil {
+		logger.Error(ctx, "purge trash query error", logger.Fields{"error": err})
+		return nil, err
+	}
+
+	res := make([]book.Core, 0, len(rows))
+	for _, r := range rows {
+		res = append(res, ToCore(r))
+	}
+	return res, nil
+}
+
+// HardDelete menghapus permanen buku beserta genre, penulis, revisi, ulasan,
+// status baca dan isi rak yang merujuk buku tersebut. Tabel milik fitur lain
+// disebut dengan nama agar package ini tidak bergantung pada fitur tersebut.
+func HardDelete(tx *gorm.DB, bookIDs []uint) error {
+	if len(bookIDs) == 0 {
+		return nil
+	}
+	for _, table := range []string{"book_genres", "book_authors", "reviews", "readings", "shelf_books"} {
+		if err := tx.Exec("DELETE FROM "+table+" WHERE books_id IN ?", bookIDs).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Where("books_id IN ?", bookIDs).Delete(&BookRevision{}).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id IN ?", bookIDs).Delete(&Books{}).Error
+}
+
+// ActiveOwner menyaring buku milik user yang masih aktif. Buku user yang
+// sedang dinonaktifkan disembunyikan sampai akunnya dipulihkan.
+func ActiveOwner(db *gorm.DB) *gorm.DB {
+	return db.Where("books.user_id IN (SELECT id FROM users WHERE deleted_at IS NULL)")
+}
+
+func (bd *bookData) ImportJob(ctx context.Context, userID uint, jobID uint) (book.ImportJob, error) {
+	res := ImportJob{}
+	err := uow.DB(ctx, bd.db).Where("id = ? AND user_id = ?", jobID, userID).First(&res).Error
+	if err != nil {
+		logger.Error(ctx, "get import job error", logger.Fields{"error": err, "job_id": jobID})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return book.ImportJob{}, errors.New("data not found")
+		}
+		return book.ImportJob{}, err
+	}
+
+	return JobToCore(res), nil
+}
+
+// findOrCreateGenres mengembalikan genre sesuai nama, genre yang belum ada
+// dibuat terlebih dulu.
+func findOrCreateGenres(tx *gorm.DB, names []string) ([]Genre, error) {
+	if len(names) == 0 {
+		return []Genre{}, nil
+	}
+
+	rows := make([]Genre, 0, len(names))
+	for _, n := range names {
+		rows = append(rows, Genre{Nama: n})
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	res := []Genre{}
+	if err := tx.Where("nama IN ?", names).Find(&res).Error; err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// resolveAuthors mencari penulis berdasarkan ID sesuai urutan, atau bila ID
+// kosong, berdasarkan teks penulis dan membuat penulis yang belum ada.
+func resolveAuthors(tx *gorm.DB, ids []uint, penulis string) ([]book.Author, error) {
+	res := []book.Author{}
+	seen := map[uint]bool{}
+
+	if len(ids) > 0 {
+		rows := []authorRow{}
+		if err := tx.Where("id IN ?", ids).Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		byID := map[uint]authorRow{}
+		for _, r := range rows {
+			byID[r.ID] = r
+		}
+		for _, id := range ids {
+			r, ok := byID[id]
+			if !ok {
+				return nil, fmt.Errorf("author %d not found", id)
+			}
+			if !seen[id] {
+				seen[id] = true
+				res = append(res, book.Author{ID: r.ID, Nama: r.Nama})
+			}
+		}
+		return res, nil
+	}
+
+	for _, name := range SplitPenulis(penulis) {
+		r := authorRow{}
+		if err := tx.Where(authorRow{Nama: name}).FirstOrCreate(&r).Error; err != nil {
+			return nil, err
+		}
+		if !seen[r.ID] {
+			seen[r.ID] = true
+			res = append(res, book.Author{ID: r.ID, Nama: r.Nama})
+		}
+	}
+	return res, nil
+}
+
+// linkAuthors mengganti daftar penulis buku sesuai urutan.
+func linkAuthors(tx *gorm.DB, bookID uint, authors []book.Author) error {
+	if err := tx.Where("books_id = ?", bookID).Delete(&BookAuthor{}).Error; err != nil {
+		return err
+	}
+	if len(authors) == 0 {
+		return nil
+	}
+
+	rows := []BookAuthor{}
+	for i, a := range authors {
+		rows = append(rows, BookAuthor{BooksID: bookID, AuthorID: a.ID, Urutan: i + 1})
+	}
+	return tx.Create(&rows).Error
+}
+
+func joinNames(authors []book.Author) string {
+	names := []string{}
+	for _, a := range authors {
+		names = append(names, a.Nama)
+	}
+	return strings.Join(names, ", ")
+}
+
+// RefreshPenulis menyusun ulang kolom penulis pada buku-buku milik author,
+// dipanggil setelah nama author diubah atau digabung. Mengembalikan id buku
+// yang diubah.
+func RefreshPenulis(tx *gorm.DB, authorID uint) ([]uint, error) {
+	ids := []uint{}
+	if err := tx.Model(&BookAuthor{}).Where("author_id = ?", authorID).Pluck("books_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		authors, err := authorsOf(tx, id)
+		if err != nil {
+			return nil, err
+		}
+		err = tx.Model(&Books{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"penulis": joinNames(authors[id]),
+			"version": gorm.Expr("version + 1"),
+		}).Error
+		if err != nil {
+			return nil, err
+		}
+		if err := publishUpdated(tx, id); err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// authorsOf mengambil penulis setiap buku, diurutkan sesuai Urutan.
+func authorsOf(tx *gorm.DB, bookIDs ...uint) (map[uint][]book.Author, error) {
+	rows := []struct {
+		BooksID uint
+		ID      uint
+		Nama    string
+	}{}
+	err := tx.Table("book_authors").
+		Select("book_authors.books_id, authors.id, authors.nama").
+		Joins("JOIN authors ON authors.id = book_authors.author_id").
+		Where("book_authors.books_id IN ?", bookIDs).
+		Order("book_authors.books_id, book_authors.urutan").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[uint][]book.Author{}
+	for _, r := range rows {
+		res[r.BooksID] = append(res[r.BooksID], book.Author{ID: r.ID, Nama: r.Nama})
+	}
+	return res, nil
+}
+
+func (bd *bookData) authors(ctx context.Context, books ...Books) (map[uint][]book.Author, error) {
+	if len(books) == 0 {
+		return map[uint][]book.Author{}, nil
+	}
+	ids := []uint{}
+	for _, b := range books {
+		ids = append(ids, b.ID)
+	}
+
+	res, err := authorsOf(uow.DB(ctx, bd.db), ids...)
+	if err != nil {
+		logger.Error(ctx, "get book author error", logger.Fields{"error": err})
+		return nil, err
+	}
+	return res, nil
+}
+
+// owners mengambil nama pemilik untuk setiap buku.
+func (bd *bookData) owners(ctx context.Context, books ...Books) (map[uint]string, error) {
+	ids := []uint{}
+	for _, b := range books {
+		ids = append(ids, b.UserID)
+	}
+	if len(ids) == 0 {
+		return map[uint]string{}, nil
+	}
+
+	rows := []struct {
+		ID   uint
+		Nama string
+	}{}
+	err := uow.DB(ctx, bd.db).Table("users").Select("id, nama").Where("id IN ?", ids).Scan(&rows).Error
+	if err != nil {
+		logger.Error(ctx, "get book owner error", logger.Fields{"error": err})
+		return nil, err
+	}
+
+	res := map[uint]string{}
+	for _, r := range rows {
+		res[r.ID] = r.Nama
+	}
+	return res, nil
 }
 
 // func (bd *bookData) MyBook(userID int) ([]book.Core, error) {
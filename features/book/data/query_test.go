@@ -0,0 +1,439 @@
+package data_test
+
+import (
+	"api/cache"
+	"api/dbtest"
+	authors "api/features/author"
+	author "api/features/author/data"
+	"api/features/book"
+	"api/features/book/data"
+	review "api/features/review/data"
+	shelf "api/features/shelf/data"
+	user "api/features/user/data"
+	"api/features/webhook"
+	"api/outbox"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const workers = 20
+
+func newBookData(t *testing.T) (book.BookData, uint) {
+	db := dbtest.Open(t, user.User{}, data.Genre{}, data.Books{}, author.Author{}, data.BookAuthor{}, data.BookRevision{}, outbox.Message{})
+	require.NoError(t, db.Create(&user.User{Nama: "alif"}).Error)
+	require.NoError(t, db.Create(&user.User{Nama: "budi"}).Error)
+
+	bd := data.New(db)
+	res, err := bd.Add(context.Background(), 1, book.Core{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eiichiro Oda"})
+	require.NoError(t, err)
+	return bd, res.ID
+}
+
+func TestOwnership(t *testing.T) {
+	ctx := context.Background()
+	bd, bookID := newBookData(t)
+
+	t.Run("update buku user lain", func(t *testing.T) {
+		_, err := bd.Update(ctx, 2, bookID, book.Core{Judul: "Naruto"}, []string{"Judul"})
+		assert.ErrorContains(t, err, "forbidden")
+	})
+
+	t.Run("update buku yang tidak ada", func(t *testing.T) {
+		_, err := bd.Update(ctx, 1, 999, book.Core{Judul: "Naruto"}, []string{"Judul"})
+		assert.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("hapus buku user lain", func(t *testing.T) {
+		assert.ErrorContains(t, bd.Delete(ctx, 2, bookID, 0), "forbidden")
+	})
+
+	t.Run("ganti cover buku user lain", func(t *testing.T) {
+		_, _, err := bd.UpdateCover(ctx, 2, bookID, "covers/a.jpg", "covers/a_thumb.jpg")
+		assert.ErrorContains(t, err, "forbidden")
+	})
+
+	t.Run("kunci buku user lain", func(t *testing.T) {
+		_, err := bd.Lock(ctx, 2, bookID)
+		assert.ErrorContains(t, err, "forbidden")
+
+		res, err := bd.Lock(ctx, 1, bookID)
+		require.NoError(t, err)
+		assert.Equal(t, "One Piece", res.Judul)
+	})
+
+	t.Run("buku tidak berubah", func(t *testing.T) {
+		res, err := bd.Detail(ctx, bookID)
+		require.NoError(t, err)
+		assert.Equal(t, "One Piece", res.Judul)
+		assert.Empty(t, res.CoverKey)
+	})
+
+	t.Run("update tanpa perubahan tetap berhasil", func(t *testing.T) {
+		_, err := bd.Update(ctx, 1, bookID, book.Core{Judul: "One Piece"}, []string{"Judul"})
+		assert.NoError(t, err)
+	})
+}
+
+// TestVersion memastikan setiap perubahan menaikkan versi buku dan perubahan
+// dengan versi lama ditolak.
+func TestVersion(t *testing.T) {
+	ctx := context.Background()
+	bd, bookID := newBookData(t)
+
+	res, err := bd.Detail(ctx, bookID)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), res.Version)
+
+	res, err = bd.Update(ctx, 1, bookID, book.Core{Judul: "Naruto", Version: 1}, []string{"Judul"})
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), res.Version)
+
+	_, err = bd.Update(ctx, 1, bookID, book.Core{Judul: "Bleach", Version: 1}, []string{"Judul"})
+	assert.ErrorContains(t, err, "precondition")
+	assert.ErrorContains(t, bd.Delete(ctx, 1, bookID, 1), "precondition")
+
+	_, _, err = bd.UpdateCover(ctx, 1, bookID, "covers/a.jpg", "covers/a_thumb.jpg")
+	require.NoError(t, err)
+	res, err = bd.Detail(ctx, bookID)
+	require.NoError(t, err)
+	assert.Equal(t, "Naruto", res.Judul)
+	assert.Equal(t, uint(3), res.Version)
+
+	assert.NoError(t, bd.Delete(ctx, 1, bookID, 3))
+}
+
+// TestPartialUpdate memastikan hanya field yang disebut yang berubah,
+// termasuk yang dikosongkan, dan hasilnya dibaca ulang utuh.
+func TestPartialUpdate(t *testing.T) {
+	ctx := context.Background()
+	bd, bookID := newBookData(t)
+
+	res, err := bd.Update(ctx, 1, bookID, book.Core{Penerbit: "Shueisha", JumlahHalaman: 200, Genre: []string{"manga"}}, []string{"Penerbit", "JumlahHalaman", "Genre"})
+	require.NoError(t, err)
+	assert.Equal(t, "One Piece", res.Judul)
+	assert.Equal(t, "Eiichiro Oda", res.Penulis)
+	assert.Equal(t, "Shueisha", res.Penerbit)
+	assert.Equal(t, []string{"manga"}, res.Genre)
+
+	res, err = bd.Update(ctx, 1, bookID, book.Core{TahunTerbit: 1997}, []string{"Penerbit", "JumlahHalaman"})
+	require.NoError(t, err)
+	assert.Equal(t, 1997, res.TahunTerbit)
+	assert.Empty(t, res.Penerbit)
+	assert.Zero(t, res.JumlahHalaman)
+	assert.Equal(t, []string{"manga"}, res.Genre)
+	assert.Equal(t, uint(3), res.Version)
+
+	res, err = bd.Update(ctx, 1, bookID, book.Core{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), res.Version)
+}
+
+// TestHistory memastikan setiap update metadata dicatat sebagai revisi
+// beserta isi sebelum dan sesudahnya, dan update tanpa perubahan tidak.
+func TestHistory(t *testing.T) {
+	ctx := context.Background()
+	bd, bookID := newBookData(t)
+
+	_, err := bd.Update(ctx, 1, bookID, book.Core{Judul: "Naruto", Genre: []string{"manga"}}, []string{"Judul", "Genre"})
+	require.NoError(t, err)
+	_, err = bd.Update(ctx, 1, bookID, book.Core{Penerbit: "Shueisha"}, []string{"Penerbit"})
+	require.NoError(t, err)
+	_, err = bd.Update(ctx, 1, bookID, book.Core{Penerbit: "Shueisha"}, []string{"Penerbit"})
+	require.NoError(t, err)
+
+	res, total, err := bd.History(ctx, 1, bookID, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, res, 2)
+	assert.Equal(t, uint(2), res[0].Rev)
+	assert.Equal(t, "Shueisha", res[0].After.Penerbit)
+	assert.Equal(t, uint(1), res[1].Rev)
+	assert.Equal(t, book.Snapshot{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eiichiro Oda"}, res[1].Before)
+	assert.Equal(t, []string{"manga"}, res[1].After.Genre)
+
+	rev, err := bd.Revision(ctx, 1, bookID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "One Piece", rev.Before.Judul)
+	assert.Equal(t, uint(1), rev.UserID)
+
+	_, err = bd.Revision(ctx, 1, bookID, 9)
+	assert.ErrorContains(t, err, "revision not found")
+	_, _, err = bd.History(ctx, 2, bookID, 1, 10)
+	assert.ErrorContains(t, err, "forbidden")
+}
+
+// TestOutbox memastikan event buku ditulis ke outbox hanya bila
+// perubahannya berhasil di-commit.
+func TestOutbox(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t, user.User{}, data.Genre{}, data.Books{}, author.Author{}, data.BookAuthor{}, data.BookRevision{}, outbox.Message{},
+		review.Review{}, shelf.Reading{}, shelf.ShelfBook{})
+	require.NoError(t, db.Create(&user.User{Nama: "alif"}).Error)
+	bd := data.New(db)
+
+	events := func() []string {
+		res := []string{}
+		require.NoError(t, db.Model(&outbox.Message{}).Where("user_id = ?", 1).Order("id").Pluck("event", &res).Error)
+		return res
+	}
+
+	added, err := bd.Add(ctx, 1, book.Core{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eiichiro Oda"})
+	require.NoError(t, err)
+	_, err = bd.Update(ctx, 1, added.ID, book.Core{Judul: "Naruto", Version: 1}, []string{"Judul"})
+	require.NoError(t, err)
+
+	t.Run("perubahan yang gagal tidak menulis event", func(t *testing.T) {
+		_, err := bd.Update(ctx, 1, added.ID, book.Core{Judul: "Bleach", Version: 1}, []string{"Judul"})
+		assert.ErrorContains(t, err, "precondition")
+		assert.ErrorContains(t, bd.Delete(ctx, 2, added.ID, 0), "forbidden")
+		assert.Equal(t, []string{webhook.EventBookCreated, webhook.EventBookUpdated}, events())
+	})
+
+	t.Run("payload berisi isi buku sesudah diubah", func(t *testing.T) {
+		msg := outbox.Message{}
+		require.NoError(t, db.Where("event = ?", webhook.EventBookUpdated).First(&msg).Error)
+		assert.JSONEq(t, fmt.Sprintf(`{"id":%d,"user_id":1,"version":2,"book":{"judul":"Naruto","tahun_terbit":1997,"penulis":"Eiichiro Oda",
+			"isbn":"","penerbit":"","bahasa":"","jumlah_halaman":0,"deskripsi":"","genre":null}}`, added.ID), msg.Payload)
+	})
+
+	t.Run("hapus, pulihkan dan hapus permanen", func(t *testing.T) {
+		require.NoError(t, bd.Delete(ctx, 1, added.ID, 0))
+		_, err := bd.Restore(ctx, 1, added.ID)
+		require.NoError(t, err)
+		require.NoError(t, bd.Delete(ctx, 1, added.ID, 0))
+		_, err = bd.Purge(ctx, 1, added.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{webhook.EventBookCreated, webhook.EventBookUpdated, webhook.EventBookDeleted,
+			webhook.EventBookRestored, webhook.EventBookDeleted, webhook.EventBookPurged}, events())
+	})
+
+	t.Run("pindah pemilik dan ganti nama penulis", func(t *testing.T) {
+		require.NoError(t, db.Create(&user.User{Nama: "budi"}).Error)
+		b, err := bd.Add(ctx, 1, book.Core{Judul: "Bleach", TahunTerbit: 2001, Penulis: "Tite Kubo"})
+		require.NoError(t, err)
+		_, err = bd.Transfer(ctx, 1, 2)
+		require.NoError(t, err)
+		_, err = author.New(db, nil).Update(ctx, b.Authors[0].ID, authors.Core{Nama: "Kubo Tite"})
+		require.NoError(t, err)
+
+		msgs := []outbox.Message{}
+		require.NoError(t, db.Where("user_id = ?", 2).Order("id").Find(&msgs).Error)
+		require.Len(t, msgs, 2)
+		assert.Equal(t, webhook.EventBookUpdated, msgs[0].Event)
+		assert.Contains(t, msgs[0].Payload, `"version":2`)
+		assert.Equal(t, webhook.EventBookUpdated, msgs[1].Event)
+		assert.Contains(t, msgs[1].Payload, `"penulis":"Kubo Tite"`)
+	})
+}
+
+// TestCached memastikan detail buku dibaca dari cache sampai buku diubah
+// lewat BookData yang sama, buku yang dihapus tidak tersisa di cache, dan
+// buku yang dipindahkan atau nama penulisnya diubah ikut dihapus dari cache.
+func TestCached(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t, user.User{}, data.Genre{}, data.Books{}, author.Author{}, data.BookAuthor{}, data.BookRevision{}, outbox.Message{})
+	require.NoError(t, db.Create(&user.User{Nama: "alif"}).Error)
+	require.NoError(t, db.Create(&user.User{Nama: "budi"}).Error)
+	c := cache.NewMemory(10)
+	next := data.New(db)
+	bd := data.NewCached(next, c, time.Minute)
+
+	onePiece, err := next.Add(ctx, 1, book.Core{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eiichiro Oda"})
+	require.NoError(t, err)
+	naruto, err := next.Add(ctx, 1, book.Core{Judul: "Naruto", TahunTerbit: 1999, Penulis: "Masashi Kishimoto"})
+	require.NoError(t, err)
+
+	t.Run("diubah lewat decorator", func(t *testing.T) {
+		bookID := onePiece.ID
+		res, err := bd.Detail(ctx, bookID)
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), res.Version)
+
+		// perubahan yang tidak lewat decorator tidak menghapus cache
+		_, _, err = next.UpdateCover(ctx, 1, bookID, "books/1/cover.jpg", "books/1/thumb.jpg")
+		require.NoError(t, err)
+		res, err = bd.Detail(ctx, bookID)
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), res.Version)
+
+		_, err = bd.Update(ctx, 1, bookID, book.Core{Judul: "Naruto"}, []string{"Judul"})
+		require.NoError(t, err)
+		res, err = bd.Detail(ctx, bookID)
+		require.NoError(t, err)
+		assert.Equal(t, "Naruto", res.Judul)
+		assert.Equal(t, uint(3), res.Version)
+
+		require.NoError(t, bd.Delete(ctx, 1, bookID, 3))
+		_, err = bd.Detail(ctx, bookID)
+		assert.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("dipindahkan ke user lain", func(t *testing.T) {
+		_, err := bd.Detail(ctx, naruto.ID)
+		require.NoError(t, err)
+
+		ids, err := bd.Transfer(ctx, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{naruto.ID}, ids)
+
+		res, err := bd.Detail(ctx, naruto.ID)
+		require.NoError(t, err)
+		assert.Equal(t, uint(2), res.UserID)
+		assert.Equal(t, naruto.Version+1, res.Version)
+	})
+
+	t.Run("nama penulis diubah", func(t *testing.T) {
+		before, err := bd.Detail(ctx, naruto.ID)
+		require.NoError(t, err)
+
+		_, err = author.New(db, c).Update(ctx, before.Authors[0].ID, authors.Core{Nama: "Kishimoto"})
+		require.NoError(t, err)
+
+		res, err := bd.Detail(ctx, naruto.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Kishimoto", res.Penulis)
+		assert.Equal(t, before.Version+1, res.Version)
+	})
+}
+
+// TestTrash memastikan buku yang dihapus bisa dilihat dan dipulihkan
+// pemiliknya, lalu hilang beserta relasinya setelah dihapus permanen.
+func TestTrash(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t, user.User{}, data.Genre{}, data.Books{}, author.Author{}, data.BookAuthor{}, data.BookRevision{}, outbox.Message{}, review.Review{}, shelf.Reading{}, shelf.ShelfBook{})
+	require.NoError(t, db.Create(&user.User{Nama: "alif"}).Error)
+	require.NoError(t, db.Create(&user.User{Nama: "budi"}).Error)
+	bd := data.New(db)
+
+	b, err := bd.Add(ctx, 1, book.Core{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eiichiro Oda", Genre: []string{"manga"}})
+	require.NoError(t, err)
+	require.NoError(t, db.Create(&review.Review{BooksID: b.ID, UserID: 2, Rating: 5}).Error)
+	require.NoError(t, bd.Delete(ctx, 1, b.ID, 0))
+
+	res, total, err := bd.Trash(ctx, 1, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, []string{"manga"}, res[0].Genre)
+	assert.False(t, res[0].DeletedAt.IsZero())
+
+	_, err = bd.Restore(ctx, 2, b.ID)
+	assert.ErrorContains(t, err, "forbidden")
+
+	restored, err := bd.Restore(ctx, 1, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, b.Version+1, restored.Version)
+	_, err = bd.Restore(ctx, 1, b.ID)
+	assert.ErrorContains(t, err, "not found", "buku aktif tidak ada di sampah")
+	_, err = bd.Purge(ctx, 1, b.ID)
+	assert.ErrorContains(t, err, "not found", "buku aktif tidak bisa dihapus permanen")
+
+	require.NoError(t, bd.Delete(ctx, 1, b.ID, 0))
+	purged, err := bd.PurgeTrash(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, purged, "masa simpan belum lewat")
+
+	purged, err = bd.PurgeTrash(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, purged, 1)
+
+	var count int64
+	db.Unscoped().Model(&data.Books{}).Count(&count)
+	assert.Zero(t, count)
+	db.Model(&review.Review{}).Count(&count)
+	assert.Zero(t, count)
+	db.Table("book_genres").Count(&count)
+	assert.Zero(t, count)
+}
+
+// TestConcurrentDelete memastikan dari banyak delete bersamaan hanya satu
+// yang berhasil, sisanya melihat buku sudah tidak ada.
+func TestConcurrentDelete(t *testing.T) {
+	bd, bookID := newBookData(t)
+
+	errs := make(chan error, workers)
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- bd.Delete(context.Background(), 1, bookID, 0)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	success := 0
+	for err := range errs {
+		if err == nil {
+			success++
+			continue
+		}
+		assert.ErrorContains(t, err, "not found")
+	}
+	assert.Equal(t, 1, success)
+}
+
+// TestConcurrentUpdateDelete menjalankan update pemilik, update user lain
+// dan delete bersamaan. Update user lain selalu ditolak, update pemilik
+// hanya boleh gagal karena bukunya sudah dihapus.
+func TestConcurrentUpdateDelete(t *testing.T) {
+	bd, bookID := newBookData(t)
+
+	type result struct {
+		op  string
+		err error
+	}
+	results := make(chan result, workers*3)
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(3)
+		go func(i int) {
+			defer wg.Done()
+			_, err := bd.Update(context.Background(), 1, bookID, book.Core{Judul: fmt.Sprintf("Judul %d", i)}, []string{"Judul"})
+			results <- result{"owner", err}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			_, err := bd.Update(context.Background(), 2, bookID, book.Core{Judul: fmt.Sprintf("Rebut %d", i)}, []string{"Judul"})
+			results <- result{"other", err}
+		}(i)
+		go func() {
+			defer wg.Done()
+			results <- result{"delete", bd.Delete(context.Background(), 1, bookID, 0)}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	deleted := 0
+	for r := range results {
+		switch r.op {
+		case "other":
+			if assert.Error(t, r.err) {
+				assert.True(t, strings.Contains(r.err.Error(), "forbidden") || strings.Contains(r.err.Error(), "not found"), r.err.Error())
+			}
+		case "owner":
+			if r.err != nil {
+				assert.ErrorContains(t, r.err, "not found")
+			}
+		case "delete":
+			if r.err == nil {
+				deleted++
+			} else {
+				assert.ErrorContains(t, r.err, "not found")
+			}
+		}
+	}
+	assert.Equal(t, 1, deleted)
+
+	_, err := bd.Detail(context.Background(), bookID)
+	assert.ErrorContains(t, err, "not found")
+}
@@ -1,33 +1,259 @@
 package book
 
-import "github.com/labstack/echo/v4"
+import (
+	"context"
+	"mime/multipart"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
 
 type Core struct {
 	ID          uint
 	Judul       string `validate:"required"`
 	TahunTerbit int    `validate:"required"`
-	Penulis     string `validate:"required"`
-	UserID      uint
-	Pemilik     string
+	// Penulis adalah nama penulis yang digabung sesuai urutan, wajib diisi
+	// bila PenulisID kosong.
+	Penulis   string `validate:"required_without=PenulisID"`
+	PenulisID []uint `validate:"omitempty,max=10,dive,gt=0"`
+	Authors   []Author
+	UserID    uint
+	Pemilik   string
+	// ISBN disimpan tanpa tanda hubung/spasi, checksum ISBN-10 maupun
+	// ISBN-13 diperiksa oleh validator.
+	ISBN          string   `validate:"omitempty,isbn"`
+	Penerbit      string   `validate:"max=100"`
+	Bahasa        string   `validate:"omitempty,bcp47_language_tag"`
+	JumlahHalaman int      `validate:"gte=0,lte=100000"`
+	Deskripsi     string   `validate:"max=5000"`
+	Genre         []string `validate:"max=10,dive,required,max=50"`
+	// CoverKey dan ThumbnailKey adalah key blob di storage, sedangkan
+	// URL-nya diisi oleh service saat dikirim ke client.
+	CoverKey     string
+	ThumbnailKey string
+	CoverURL     string
+	ThumbnailURL string
+	// RatingRata dan JumlahUlasan diringkas dari ulasan buku, hanya dibaca.
+	RatingRata   float64
+	JumlahUlasan int
+	// Version naik setiap kali buku berubah. Saat update, Version berisi
+	// versi yang diharapkan client (0 berarti tanpa pengecekan).
+	Version uint
+	// DeletedAt adalah waktu buku dipindah ke sampah dan PurgeAt waktu buku
+	// akan dihapus permanen, keduanya kosong untuk buku aktif.
+	DeletedAt time.Time
+	PurgeAt   time.Time
+}
+
+// Author adalah penulis buku sesuai urutan penulisan.
+type Author struct {
+	ID   uint
+	Nama string
+}
+
+// Filter berisi kriteria pencarian daftar buku. Field kosong diabaikan.
+type Filter struct {
+	UserID     uint
+	Judul      string
+	Penulis    string
+	AuthorID   uint
+	ISBN       string
+	Penerbit   string
+	Bahasa     string
+	Genre      string
+	MinHalaman int
+	MaxHalaman int
+	// Sort bernilai "rating" atau "ulasan", selain itu buku terbaru di atas.
+	Sort  string
+	Page  int
+	Limit int
+}
+
+const (
+	SortRating = "rating"
+	SortUlasan = "ulasan"
+)
+
+const (
+	ImportPending = "pending"
+	ImportRunning = "running"
+	ImportDone    = "done"
+	// ImportCancelled dipakai bila pemilik job menonaktifkan akunnya
+	// sebelum import selesai.
+	ImportCancelled = "cancelled"
+
+	ImportCreated = "created"
+	ImportSkipped = "skipped"
+	// ImportFailed juga dipakai sebagai status job bila import berhenti
+	// karena panic.
+	ImportFailed = "failed"
+)
+
+// ImportRow adalah satu baris file import. Err diisi handler bila baris
+// tidak bisa dibaca sehingga langsung dilaporkan gagal.
+type ImportRow struct {
+	Line int
+	Book Core
+	Err  string
+}
+
+type ImportResult struct {
+	Line   int
+	Status string
+	BookID uint
+	Reason string
+}
+
+type ImportJob struct {
+	ID         uint
+	UserID     uint
+	Status     string
+	Total      int
+	Created    int
+	Skipped    int
+	Failed     int
+	Results    []ImportResult
+	CreatedAt  time.Time
+	FinishedAt *time.Time
+}
+
+// Snapshot adalah metadata buku yang disimpan di setiap revisi. Penulis
+// disimpan sebagai teks agar revisi tetap bisa dipulihkan walau penulisnya
+// sudah digabung atau dihapus.
+type Snapshot struct {
+	Judul         string   `json:"judul"`
+	TahunTerbit   int      `json:"tahun_terbit"`
+	Penulis       string   `json:"penulis"`
+	ISBN          string   `json:"isbn"`
+	Penerbit      string   `json:"penerbit"`
+	Bahasa        string   `json:"bahasa"`
+	JumlahHalaman int      `json:"jumlah_halaman"`
+	Deskripsi     string   `json:"deskripsi"`
+	Genre         []string `json:"genre"`
+}
+
+// Revision dicatat setiap kali metadata buku diubah. Rev adalah versi buku
+// sebelum diubah, Before isi buku pada versi itu dan After isi buku setelah
+// diubah oleh UserID. Changes diisi service dari Before dan After.
+type Revision struct {
+	Rev       uint
+	BookID    uint
+	UserID    uint
+	Before    Snapshot
+	After     Snapshot
+	Changes   map[string]Change
+	CreatedAt time.Time
+}
+
+// Change berisi nilai field sebelum dan sesudah diubah.
+type Change struct {
+	Before interface{}
+	After  interface{}
+}
+
+// MetadataProvider mencari metadata buku berdasarkan ISBN yang sudah
+// dinormalisasi. Field yang tidak diketahui provider dibiarkan kosong.
+type MetadataProvider interface {
+	Lookup(ctx context.Context, isbn string) (Core, error)
 }
 
 type BookHandler interface {
 	Add() echo.HandlerFunc
+	List() echo.HandlerFunc
+	Detail() echo.HandlerFunc
 	Update() echo.HandlerFunc
 	Delete() echo.HandlerFunc
+	UploadCover() echo.HandlerFunc
+	Import() echo.HandlerFunc
+	ImportStatus() echo.HandlerFunc
+	Trash() echo.HandlerFunc
+	Restore() echo.HandlerFunc
+	Purge() echo.HandlerFunc
+	History() echo.HandlerFunc
+	Revert() echo.HandlerFunc
 	// MyBook() echo.HandlerFunc
 }
 
 type BookService interface {
-	Add(token interface{}, newBook Core) (Core, error)
-	Update(token interface{}, bookID uint, updatedData Core) (Core, error)
-	Delete(token interface{}, bookID uint) error
+	Add(ctx context.Context, token interface{}, newBook Core) (Core, error)
+	List(ctx context.Context, filter Filter) ([]Core, int64, error)
+	Detail(ctx context.Context, bookID uint) (Core, error)
+	// Update hanya mengubah field Core yang disebut di fields, termasuk yang
+	// dikosongkan, lalu mengembalikan buku utuh hasil baca ulang.
+	Update(ctx context.Context, token interface{}, bookID uint, updatedData Core, fields []string) (Core, error)
+	// Delete menghapus buku bila versinya masih sama dengan version, 0
+	// berarti tanpa pengecekan versi.
+	Delete(ctx context.Context, token interface{}, bookID uint, version uint) error
+	UploadCover(ctx context.Context, token interface{}, bookID uint, file *multipart.FileHeader) (Core, error)
+	// Import memvalidasi dan menyimpan banyak buku sekaligus. Import kecil
+	// langsung selesai, import besar dikerjakan di background dan statusnya
+	// dipantau lewat ImportStatus.
+	Import(ctx context.Context, token interface{}, rows []ImportRow) (ImportJob, error)
+	ImportStatus(ctx context.Context, token interface{}, jobID uint) (ImportJob, error)
+	// WaitImports menunggu import di background selesai, dipanggil saat
+	// server berhenti. Hasilnya error ctx bila import belum selesai saat ctx
+	// berakhir.
+	WaitImports(ctx context.Context) error
+	// Trash mengembalikan satu halaman buku user yang sudah dihapus dan
+	// masih bisa dipulihkan.
+	Trash(ctx context.Context, token interface{}, page, limit int) ([]Core, int64, error)
+	// Restore mengembalikan buku dari sampah selama belum dihapus permanen.
+	Restore(ctx context.Context, token interface{}, bookID uint) (Core, error)
+	// Purge menghapus permanen buku di sampah beserta cover-nya.
+	Purge(ctx context.Context, token interface{}, bookID uint) error
+	// PurgeTrash menghapus permanen semua buku yang masa simpan sampahnya
+	// sudah lewat dan mengembalikan jumlahnya.
+	PurgeTrash(ctx context.Context) (int, error)
+	// History mengembalikan satu halaman revisi buku milik user, revisi
+	// terbaru di atas.
+	History(ctx context.Context, token interface{}, bookID uint, page, limit int) ([]Revision, int64, error)
+	// Revert mengembalikan metadata buku ke isi versi rev. Revert dicatat
+	// sebagai revisi baru sehingga bisa dibatalkan lagi.
+	Revert(ctx context.Context, token interface{}, bookID uint, rev uint, version uint) (Core, error)
 	// MyBook(token interface{}) ([]Core, error)
 }
 
 type BookData interface {
-	Add(userID uint, newBook Core) (Core, error)
-	Update(userID uint, bookID uint, updatedData Core) (Core, error)
-	Delete(userID uint, bookID uint) error
+	Add(ctx context.Context, userID uint, newBook Core) (Core, error)
+	// List mengembalikan satu halaman buku sesuai filter beserta jumlah
+	// seluruh buku yang cocok.
+	List(ctx context.Context, filter Filter) ([]Core, int64, error)
+	Detail(ctx context.Context, bookID uint) (Core, error)
+	// Lock mengunci buku milik userID sampai transaksi pada ctx selesai lalu
+	// mengembalikan datanya, dipanggil di dalam unit of work.
+	Lock(ctx context.Context, userID uint, bookID uint) (Core, error)
+	Update(ctx context.Context, userID uint, bookID uint, updatedData Core, fields []string) (Core, error)
+	Delete(ctx context.Context, userID uint, bookID uint, version uint) error
+	// UpdateCover menyimpan key cover baru dan mengembalikan key cover dan
+	// thumbnail sebelumnya agar blob lama bisa dihapus.
+	UpdateCover(ctx context.Context, userID uint, bookID uint, coverKey, thumbnailKey string) (oldCoverKey, oldThumbnailKey string, err error)
+	// AddBatch menyimpan semua buku dalam satu transaksi, bila satu gagal
+	// tidak ada yang tersimpan.
+	AddBatch(ctx context.Context, userID uint, newBooks []Core) ([]Core, error)
+	// ExistingISBN mengembalikan ISBN yang sudah ada di koleksi user.
+	ExistingISBN(ctx context.Context, userID uint, isbns []string) ([]string, error)
+	CreateImportJob(ctx context.Context, job ImportJob) (ImportJob, error)
+	UpdateImportJob(ctx context.Context, job ImportJob) error
+	ImportJob(ctx context.Context, userID uint, jobID uint) (ImportJob, error)
+	// Transfer memindahkan semua buku fromUserID ke toUserID dan
+	// mengembalikan id buku yang dipindahkan.
+	Transfer(ctx context.Context, fromUserID uint, toUserID uint) ([]uint, error)
+	// CancelImports membatalkan import user yang belum selesai.
+	CancelImports(ctx context.Context, userID uint) error
+	// Trash mengembalikan satu halaman buku user yang sudah dihapus tetapi
+	// belum dihapus permanen, yang terakhir dihapus di atas.
+	Trash(ctx context.Context, userID uint, page, limit int) ([]Core, int64, error)
+	Restore(ctx context.Context, userID uint, bookID uint) (Core, error)
+	// Purge menghapus permanen buku di sampah dan mengembalikan datanya agar
+	// blob cover bisa ikut dihapus.
+	Purge(ctx context.Context, userID uint, bookID uint) (Core, error)
+	// PurgeTrash menghapus permanen semua buku yang masuk sampah sebelum
+	// before.
+	PurgeTrash(ctx context.Context, before time.Time) ([]Core, error)
+	// History mengembalikan satu halaman revisi buku milik userID beserta
+	// jumlah seluruh revisinya.
+	History(ctx context.Context, userID uint, bookID uint, page, limit int) ([]Revision, int64, error)
+	// Revision mengembalikan revisi buku milik userID yang Rev-nya rev.
+	Revision(ctx context.Context, userID uint, bookID uint, rev uint) (Revision, error)
 	// MyBook(userID int) ([]Core, error)
 }
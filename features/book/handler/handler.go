@@ -3,9 +3,17 @@ package handler
 import (
 	"api/features/book"
 	"api/helper"
-	"log"
+	"api/logger"
+	"api/patch"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 )
@@ -20,6 +28,15 @@ func New(bs book.BookService) book.BookHandler {
 	}
 }
 
+// errIfMatch dikembalikan bila header If-Match bukan etag buku yang diminta.
+const errIfMatch = "precondition failed: If-Match tidak sesuai dengan buku"
+
+// setETag menulis versi buku ke header ETag agar client bisa mengirimnya
+// kembali lewat If-Match atau If-None-Match.
+func setETag(c echo.Context, res book.Core) {
+	c.Response().Header().Set("ETag", helper.ETag(res.ID, res.Version))
+}
+
 func (bh *bookHandle) Add() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		input := AddBookRequest{}
@@ -29,15 +46,54 @@ func (bh *bookHandle) Add() echo.HandlerFunc {
 
 		cnv := ToCore(input)
 
-		res, err := bh.srv.Add(c.Get("user"), *cnv)
+		res, err := bh.srv.Add(c.Request().Context(), c.Get("user"), *cnv)
 		if err != nil {
-			log.Println("trouble :  ", err.Error())
+			logger.Warn(c.Request().Context(), "add book error", logger.Fields{"error": err})
 			return c.JSON(helper.PrintErrorResponse(err.Error()))
 		}
 
-		return c.JSON(helper.PrintSuccessReponse(http.StatusCreated, "sukses menambahkan buku", res))
+		setETag(c, res)
+		return c.JSON(PrintSuccessReponse(http.StatusCreated, "sukses menambahkan buku", res))
 	}
 }
+
+func (bh *bookHandle) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := ListBookRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, total, err := bh.srv.List(c.Request().Context(), input.ToFilter())
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintListResponse(http.StatusOK, "sukses menampilkan daftar buku", res, helper.NewPagination(input.Page, input.Limit, total)))
+	}
+}
+
+func (bh *bookHandle) Detail() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		bookID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		res, err := bh.srv.Detail(c.Request().Context(), uint(bookID))
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		setETag(c, res)
+		if helper.ETagMatch(c.Request().Header.Get("If-None-Match"), helper.ETag(res.ID, res.Version)) {
+			return c.NoContent(http.StatusNotModified)
+		}
+		return c.JSON(PrintSuccessReponse(http.StatusOK, "sukses menampilkan detail buku", res))
+	}
+}
+
 func (bh *bookHandle) Update() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		token := c.Get("user")
@@ -47,25 +103,84 @@ func (bh *bookHandle) Update() echo.HandlerFunc {
 		bookID, err := strconv.Atoi(paramID)
 
 		if err != nil {
-			log.Println("convert id error", err.Error())
-			return c.JSON(http.StatusBadGateway, "masukan input sesuai pola")
+			logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
 		}
 
-		body := UpdateBookRequest{}
-		if err := c.Bind(&body); err != nil {
-			return c.JSON(http.StatusBadGateway, "masukan input sesuai pola yang benar")
+		version, ok := helper.IfMatchVersion(c.Request().Header.Get("If-Match"), uint(bookID))
+		if !ok {
+			return c.JSON(helper.PrintErrorResponse(errIfMatch))
+		}
+
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola yang benar")
 		}
+		ctype, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
 
-		res, err := bh.srv.Update(token, uint(bookID), *ToCore(body))
+		// tanpa If-Match, patch diterapkan ulang pada versi terbaru bila buku
+		// berubah di antara baca dan tulis
+		var res book.Core
+		for attempt := 1; ; attempt++ {
+			res, err = bh.patch(c.Request().Context(), token, uint(bookID), version, ctype, body)
+			if err == nil || version != 0 || attempt == maxPatchAttempts || !strings.Contains(err.Error(), "precondition") {
+				break
+			}
+		}
 
 		if err != nil {
 			return c.JSON(helper.PrintErrorResponse(err.Error()))
 		}
 
-		return c.JSON(helper.PrintSuccessReponse(http.StatusCreated, "berhasil update buku", res))
+		setETag(c, res)
+		return c.JSON(PrintSuccessReponse(http.StatusCreated, "berhasil update buku", res))
 	}
 }
 
+// maxPatchAttempts membatasi percobaan ulang patch tanpa If-Match.
+const maxPatchAttempts = 3
+
+// patch menerapkan merge patch atau JSON Patch pada dokumen buku saat ini
+// lalu menyimpan field yang berubah saja. version 0 berarti patch berlaku
+// untuk versi yang baru dibaca.
+func (bh *bookHandle) patch(ctx context.Context, token interface{}, bookID, version uint, ctype string, body []byte) (book.Core, error) {
+	cur, err := bh.srv.Detail(ctx, bookID)
+	if err != nil {
+		return book.Core{}, err
+	}
+	if version == 0 {
+		version = cur.Version
+	}
+
+	doc, err := json.Marshal(ToDocument(cur))
+	if err != nil {
+		return book.Core{}, err
+	}
+	patched, err := patch.Apply(ctype, doc, body)
+	if err != nil {
+		return book.Core{}, err
+	}
+	keys, err := patch.Changed(doc, patched)
+	if err != nil {
+		return book.Core{}, err
+	}
+
+	input := UpdateBookRequest{}
+	dec := json.NewDecoder(bytes.NewReader(patched))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&input); err != nil {
+		return book.Core{}, fmt.Errorf("format patch tidak sesuai: %w", err)
+	}
+
+	fields := make([]string, 0, len(keys))
+	for _, k := range keys {
+		fields = append(fields, updateFields[k])
+	}
+	cnv := ToCore(input)
+	cnv.Version = version
+	return bh.srv.Update(ctx, token, bookID, *cnv, fields)
+}
+
 func (bh *bookHandle) Delete() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		token := c.Get("user")
@@ -75,11 +190,16 @@ func (bh *bookHandle) Delete() echo.HandlerFunc {
 		bookID, err := strconv.Atoi(paramID)
 
 		if err != nil {
-			log.Println("convert id error", err.Error())
-			return c.JSON(http.StatusBadGateway, "masukan input sesuai pola")
+			logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		version, ok := helper.IfMatchVersion(c.Request().Header.Get("If-Match"), uint(bookID))
+		if !ok {
+			return c.JSON(helper.PrintErrorResponse(errIfMatch))
 		}
 
-		err = bh.srv.Delete(token, uint(bookID))
+		err = bh.srv.Delete(c.Request().Context(), token, uint(bookID), version)
 
 		if err != nil {
 			return c.JSON(helper.PrintErrorResponse(err.Error()))
@@ -88,3 +208,183 @@ func (bh *bookHandle) Delete() echo.HandlerFunc {
 		return c.JSON(http.StatusAccepted, "berhasil delete buku")
 	}
 }
+
+func (bh *bookHandle) Trash() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := TrashRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, total, err := bh.srv.Trash(c.Request().Context(), c.Get("user"), input.Page, input.Limit)
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintTrashResponse(http.StatusOK, "sukses menampilkan sampah buku", res, helper.NewPagination(input.Page, input.Limit, total)))
+	}
+}
+
+func (bh *bookHandle) Restore() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		bookID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		res, err := bh.srv.Restore(c.Request().Context(), c.Get("user"), uint(bookID))
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		setETag(c, res)
+		return c.JSON(PrintSuccessReponse(http.StatusOK, "berhasil memulihkan buku", res))
+	}
+}
+
+func (bh *bookHandle) Purge() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		bookID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		if err := bh.srv.Purge(c.Request().Context(), c.Get("user"), uint(bookID)); err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(http.StatusAccepted, "berhasil hapus permanen buku")
+	}
+}
+
+func (bh *bookHandle) History() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		bookID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		input := HistoryRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, total, err := bh.srv.History(c.Request().Context(), c.Get("user"), uint(bookID), input.Page, input.Limit)
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintHistoryResponse(http.StatusOK, "sukses menampilkan riwayat buku", res, helper.NewPagination(input.Page, input.Limit, total)))
+	}
+}
+
+func (bh *bookHandle) Revert() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		bookID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+		rev, err := strconv.Atoi(c.Param("rev"))
+		if err != nil || rev <= 0 {
+			logger.Warn(c.Request().Context(), "convert rev error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		version, ok := helper.IfMatchVersion(c.Request().Header.Get("If-Match"), uint(bookID))
+		if !ok {
+			return c.JSON(helper.PrintErrorResponse(errIfMatch))
+		}
+
+		res, err := bh.srv.Revert(c.Request().Context(), c.Get("user"), uint(bookID), uint(rev), version)
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		setETag(c, res)
+		return c.JSON(PrintSuccessReponse(http.StatusOK, "berhasil mengembalikan buku ke revisi lama", res))
+	}
+}
+
+func (bh *bookHandle) UploadCover() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Get("user")
+
+		bookID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		file, err := c.FormFile("cover")
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, err := bh.srv.UploadCover(c.Request().Context(), token, uint(bookID), file)
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		setETag(c, res)
+		return c.JSON(PrintSuccessReponse(http.StatusOK, "berhasil upload cover buku", res))
+	}
+}
+
+func (bh *bookHandle) Import() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := ImportBookRequest{Format: c.FormValue("format")}
+		file, err := c.FormFile("file")
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		format, err := importFormat(input.Format, file.Filename)
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		src, err := file.Open()
+		if err != nil {
+			logger.Error(c.Request().Context(), "open import file error", logger.Fields{"error": err})
+			return c.JSON(helper.PrintErrorResponse("terjadi kesalahan pada server"))
+		}
+		defer src.Close()
+
+		rows, err := parseImport(format, src)
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		res, err := bh.srv.Import(c.Request().Context(), c.Get("user"), rows)
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		if res.Status != book.ImportDone {
+			c.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("/books/import/%d", res.ID))
+			return c.JSON(PrintJobResponse(http.StatusAccepted, "import sedang diproses", res))
+		}
+		return c.JSON(PrintJobResponse(http.StatusOK, "import buku selesai", res))
+	}
+}
+
+func (bh *bookHandle) ImportStatus() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		jobID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		res, err := bh.srv.ImportStatus(c.Request().Context(), c.Get("user"), uint(jobID))
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintJobResponse(http.StatusOK, "sukses menampilkan status import", res))
+	}
+}
@@ -0,0 +1,134 @@
+package handler_test
+
+import (
+	"api/features/book"
+	"api/features/book/handler"
+	"api/mocks"
+	"api/patch"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type request struct {
+	method string
+	ctype  string
+	body   string
+	header map[string]string
+}
+
+func serve(h echo.HandlerFunc, r request) *httptest.ResponseRecorder {
+	e := echo.New()
+	req := httptest.NewRequest(r.method, "/books/7", strings.NewReader(r.body))
+	if r.ctype != "" {
+		req.Header.Set(echo.HeaderContentType, r.ctype)
+	}
+	for k, v := range r.header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+	h(c)
+	return rec
+}
+
+var current = book.Core{
+	ID: 7, Version: 3, Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eiichiro Oda",
+	Authors: []book.Author{{ID: 1, Nama: "Eiichiro Oda"}}, Penerbit: "Shueisha", Genre: []string{"manga"},
+}
+
+func TestConditionalRequest(t *testing.T) {
+	t.Run("detail dengan etag yang sama", func(t *testing.T) {
+		srv := mocks.NewBookService(t)
+		srv.On("Detail", mock.Anything, uint(7)).Return(current, nil).Twice()
+
+		rec := serve(handler.New(srv).Detail(), request{method: http.MethodGet})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"7-3"`, rec.Header().Get("ETag"))
+
+		rec = serve(handler.New(srv).Detail(), request{method: http.MethodGet, header: map[string]string{"If-None-Match": `W/"7-3"`}})
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("If-Match untuk buku lain", func(t *testing.T) {
+		srv := mocks.NewBookService(t)
+
+		rec := serve(handler.New(srv).Delete(), request{method: http.MethodDelete, header: map[string]string{"If-Match": `"8-3"`}})
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		srv.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPatch(t *testing.T) {
+	t.Run("merge patch dengan null dan nol", func(t *testing.T) {
+		srv := mocks.NewBookService(t)
+		srv.On("Detail", mock.Anything, uint(7)).Return(current, nil).Once()
+		want := book.Core{Judul: "One Piece", Penulis: "Eiichiro Oda", PenulisID: []uint{1}, Genre: []string{"manga"}, Version: 3}
+		srv.On("Update", mock.Anything, mock.Anything, uint(7), want, []string{"Penerbit", "TahunTerbit"}).
+			Return(book.Core{ID: 7, Version: 4, Judul: "One Piece"}, nil).Once()
+
+		rec := serve(handler.New(srv).Update(), request{
+			method: http.MethodPatch, ctype: patch.MIMEMergePatch, body: `{"penerbit":null,"tahun_terbit":0}`,
+			header: map[string]string{"If-Match": `"7-3"`},
+		})
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, `"7-4"`, rec.Header().Get("ETag"))
+	})
+
+	t.Run("json patch menambah genre", func(t *testing.T) {
+		srv := mocks.NewBookService(t)
+		srv.On("Detail", mock.Anything, uint(7)).Return(current, nil).Once()
+		srv.On("Update", mock.Anything, mock.Anything, uint(7), mock.MatchedBy(func(c book.Core) bool {
+			return assert.ObjectsAreEqual([]string{"manga", "aksi"}, c.Genre)
+		}), []string{"Genre"}).Return(book.Core{ID: 7, Version: 4}, nil).Once()
+
+		rec := serve(handler.New(srv).Update(), request{
+			method: http.MethodPatch, ctype: patch.MIMEJSONPatch, body: `[{"op":"add","path":"/genre/-","value":"aksi"}]`,
+		})
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("operasi test gagal", func(t *testing.T) {
+		srv := mocks.NewBookService(t)
+		srv.On("Detail", mock.Anything, uint(7)).Return(current, nil).Once()
+
+		rec := serve(handler.New(srv).Update(), request{
+			method: http.MethodPatch, ctype: patch.MIMEJSONPatch, body: `[{"op":"test","path":"/judul","value":"Naruto"}]`,
+		})
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("field tidak dikenal", func(t *testing.T) {
+		srv := mocks.NewBookService(t)
+		srv.On("Detail", mock.Anything, uint(7)).Return(current, nil).Once()
+
+		rec := serve(handler.New(srv).Update(), request{
+			method: http.MethodPatch, ctype: patch.MIMEJSONPatch, body: `[{"op":"add","path":"/sampul","value":"x"}]`,
+		})
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("tanpa If-Match diulang saat buku berubah", func(t *testing.T) {
+		srv := mocks.NewBookService(t)
+		srv.On("Detail", mock.Anything, uint(7)).Return(current, nil).Twice()
+		srv.On("Update", mock.Anything, mock.Anything, uint(7), mock.Anything, []string{"Judul"}).
+			Return(book.Core{}, errors.New("precondition failed: buku sudah diubah")).Once()
+		srv.On("Update", mock.Anything, mock.Anything, uint(7), mock.Anything, []string{"Judul"}).
+			Return(book.Core{ID: 7, Version: 5, Judul: "Naruto"}, nil).Once()
+
+		rec := serve(handler.New(srv).Update(), request{
+			method: http.MethodPatch, ctype: echo.MIMEApplicationJSON, body: `{"judul":"Naruto"}`,
+		})
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, `"7-5"`, rec.Header().Get("ETag"))
+	})
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"api/features/book"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// maxImportRows membatasi jumlah baris dalam satu file import.
+const maxImportRows = 5000
+
+var errTooManyRows = fmt.Errorf("format file import tidak sesuai, maksimal %d baris", maxImportRows)
+
+// importFormat menentukan format file dari field format atau ekstensi file.
+func importFormat(format, filename string) (string, error) {
+	if format != "" {
+		return format, nil
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return "csv", nil
+	case ".jsonl", ".ndjson", ".json":
+		return "jsonl", nil
+	}
+	return "", errors.New("format file import tidak sesuai, gunakan csv atau jsonl")
+}
+
+func parseImport(format string, r io.Reader) ([]book.ImportRow, error) {
+	if format == "csv" {
+		return parseCSV(r)
+	}
+	return parseJSONL(r)
+}
+
+// parseCSV membaca file CSV dengan baris pertama sebagai header. Kolom yang
+// dikenali sama dengan field AddBookRequest, genre dipisah dengan "|".
+func parseCSV(r io.Reader) ([]book.ImportRow, error) {
+	rd := csv.NewReader(r)
+	rd.TrimLeadingSpace = true
+
+	header, err := rd.Read()
+	if err != nil {
+		return nil, errors.New("format file import tidak sesuai, header CSV tidak bisa dibaca")
+	}
+	cols := map[string]int{}
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	if _, ok := cols["judul"]; !ok {
+		return nil, errors.New("format file import tidak sesuai, header CSV wajib memiliki kolom judul")
+	}
+
+	rows := []book.ImportRow{}
+	for {
+		rec, err := rd.Read()
+		if err == io.EOF {
+			break
+		}
+		if len(rows) == maxImportRows {
+			return nil, errTooManyRows
+		}
+
+		if err != nil {
+			line := 0
+			pe := &csv.ParseError{}
+			if errors.As(err, &pe) {
+				line = pe.Line
+			}
+			rows = append(rows, book.ImportRow{Line: line, Err: "format baris CSV tidak sesuai"})
+			continue
+		}
+		line, _ := rd.FieldPos(0)
+
+		get := func(name string) string {
+			if i, ok := cols[name]; ok && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+
+		row := book.ImportRow{Line: line}
+		req := AddBookRequest{
+			Judul:     get("judul"),
+			Penulis:   get("penulis"),
+			ISBN:      get("isbn"),
+			Penerbit:  get("penerbit"),
+			Bahasa:    get("bahasa"),
+			Deskripsi: get("deskripsi"),
+		}
+		if g := get("genre"); g != "" {
+			req.Genre = strings.Split(g, "|")
+		}
+		for name, dst := range map[string]*int{"tahun_terbit": &req.TahunTerbit, "jumlah_halaman": &req.JumlahHalaman} {
+			v := get(name)
+			if v == "" {
+				continue
+			}
+			if *dst, err = strconv.Atoi(v); err != nil {
+				row.Err = "format " + name + " tidak sesuai"
+			}
+		}
+		row.Book = *ToCore(req)
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// parseJSONL membaca satu objek AddBookRequest per baris, baris kosong
+// dilewati.
+func parseJSONL(r io.Reader) ([]book.ImportRow, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1<<20)
+
+	rows := []book.ImportRow{}
+	line := 0
+	for sc.Scan() {
+		line++
+		text := strings.TrimSpace(sc.Text())
+		if text == "" {
+			continue
+		}
+		if len(rows) == maxImportRows {
+			return nil, errTooManyRows
+		}
+
+		req := AddBookRequest{}
+		if err := json.Unmarshal([]byte(text), &req); err != nil {
+			rows = append(rows, book.ImportRow{Line: line, Err: "format JSON tidak sesuai"})
+			continue
+		}
+		rows = append(rows, book.ImportRow{Line: line, Book: *ToCore(req)})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, errors.New("format file import tidak sesuai, baris terlalu panjang")
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"api/features/book"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCSV(t *testing.T) {
+	t.Run("baris dan kolom dikenali", func(t *testing.T) {
+		file := "\ufeffJudul,Penulis,Tahun_Terbit,ISBN,Genre,Lainnya\n" +
+			"Laskar Pelangi,Andrea Hirata,2005,978-979-3062-79-2,novel|drama,x\n" +
+			"\"Good Omens\",\"Neil Gaiman, Terry Pratchett\",abc,,,\n"
+		rows, err := parseCSV(strings.NewReader(file))
+		assert.Nil(t, err)
+		assert.Equal(t, []book.ImportRow{
+			{Line: 2, Book: book.Core{Judul: "Laskar Pelangi", Penulis: "Andrea Hirata", TahunTerbit: 2005,
+				ISBN: "978-979-3062-79-2", Genre: []string{"novel", "drama"}}},
+			{Line: 3, Book: book.Core{Judul: "Good Omens", Penulis: "Neil Gaiman, Terry Pratchett"},
+				Err: "format tahun_terbit tidak sesuai"},
+		}, rows)
+	})
+
+	t.Run("jumlah kolom tidak sesuai", func(t *testing.T) {
+		rows, err := parseCSV(strings.NewReader("judul,penulis\nSatu,A\nDua\n"))
+		assert.Nil(t, err)
+		assert.Len(t, rows, 2)
+		assert.Equal(t, 3, rows[1].Line)
+		assert.NotEmpty(t, rows[1].Err)
+	})
+
+	t.Run("tanpa kolom judul", func(t *testing.T) {
+		_, err := parseCSV(strings.NewReader("title,author\nSatu,A\n"))
+		assert.ErrorContains(t, err, "format")
+	})
+}
+
+func TestParseJSONL(t *testing.T) {
+	file := `{"judul":"Laskar Pelangi","tahun_terbit":2005,"penulis":"Andrea Hirata"}
+
+{"judul":"Rusak"
+{"judul":"Good Omens","tahun_terbit":1990,"penulis_id":[1,2]}
+`
+	rows, err := parseJSONL(strings.NewReader(file))
+	assert.Nil(t, err)
+	assert.Equal(t, []book.ImportRow{
+		{Line: 1, Book: book.Core{Judul: "Laskar Pelangi", TahunTerbit: 2005, Penulis: "Andrea Hirata"}},
+		{Line: 3, Err: "format JSON tidak sesuai"},
+		{Line: 4, Book: book.Core{Judul: "Good Omens", TahunTerbit: 1990, PenulisID: []uint{1, 2}}},
+	}, rows)
+}
+
+func TestImportFormat(t *testing.T) {
+	format, err := importFormat("", "koleksi.CSV")
+	assert.Nil(t, err)
+	assert.Equal(t, "csv", format)
+
+	format, err = importFormat("jsonl", "koleksi.txt")
+	assert.Nil(t, err)
+	assert.Equal(t, "jsonl", format)
+
+	_, err = importFormat("", "koleksi.xlsx")
+	assert.ErrorContains(t, err, "format")
+}
@@ -1,17 +1,88 @@
 package handler
 
-import "api/features/book"
+import (
+	"api/features/book"
+	"mime/multipart"
+)
 
+// AddBookRequest boleh hanya berisi isbn, judul, tahun terbit dan penulis
+// dilengkapi dari metadata provider bila ditemukan.
 type AddBookRequest struct {
-	Judul       string `json:"judul"`
-	TahunTerbit int    `json:"tahun_terbit"`
-	Penulis     string `json:"penulis"`
+	Judul         string   `json:"judul"`
+	TahunTerbit   int      `json:"tahun_terbit"`
+	Penulis       string   `json:"penulis"`
+	PenulisID     []uint   `json:"penulis_id" validate:"max=10"`
+	ISBN          string   `json:"isbn"`
+	Penerbit      string   `json:"penerbit" validate:"max=100"`
+	Bahasa        string   `json:"bahasa"`
+	JumlahHalaman int      `json:"jumlah_halaman" validate:"gte=0,lte=100000"`
+	Deskripsi     string   `json:"deskripsi" validate:"max=5000"`
+	Genre         []string `json:"genre"`
 }
 
+// UpdateBookRequest adalah dokumen buku yang bisa diubah lewat PATCH. Body
+// merge patch berisi sebagian field, null berarti field dikosongkan.
 type UpdateBookRequest struct {
-	Judul       string `json:"judul"`
-	TahunTerbit int    `json:"tahun_terbit"`
-	Penulis     string `json:"penulis"`
+	Judul         *string   `json:"judul"`
+	TahunTerbit   *int      `json:"tahun_terbit"`
+	Penulis       *string   `json:"penulis"`
+	PenulisID     *[]uint   `json:"penulis_id" validate:"max=10"`
+	ISBN          *string   `json:"isbn"`
+	Penerbit      *string   `json:"penerbit" validate:"max=100"`
+	Bahasa        *string   `json:"bahasa"`
+	JumlahHalaman *int      `json:"jumlah_halaman" validate:"gte=0,lte=100000"`
+	Deskripsi     *string   `json:"deskripsi" validate:"max=5000"`
+	Genre         *[]string `json:"genre"`
+}
+
+// updateFields memetakan field dokumen UpdateBookRequest ke field book.Core.
+var updateFields = map[string]string{
+	"judul":          "Judul",
+	"tahun_terbit":   "TahunTerbit",
+	"penulis":        "Penulis",
+	"penulis_id":     "PenulisID",
+	"isbn":           "ISBN",
+	"penerbit":       "Penerbit",
+	"bahasa":         "Bahasa",
+	"jumlah_halaman": "JumlahHalaman",
+	"deskripsi":      "Deskripsi",
+	"genre":          "Genre",
+}
+
+type ListBookRequest struct {
+	Judul      string `query:"judul"`
+	Penulis    string `query:"penulis"`
+	PenulisID  uint   `query:"penulis_id"`
+	ISBN       string `query:"isbn"`
+	Penerbit   string `query:"penerbit"`
+	Bahasa     string `query:"bahasa"`
+	Genre      string `query:"genre"`
+	MinHalaman int    `query:"min_halaman" validate:"gte=0"`
+	MaxHalaman int    `query:"max_halaman" validate:"gte=0"`
+	Sort       string `query:"sort" validate:"omitempty,oneof=terbaru rating ulasan"`
+	Page       int    `query:"page" validate:"gte=1"`
+	Limit      int    `query:"limit" validate:"gte=1,lte=100"`
+}
+
+type TrashRequest struct {
+	Page  int `query:"page" validate:"gte=1"`
+	Limit int `query:"limit" validate:"gte=1,lte=100"`
+}
+
+type HistoryRequest struct {
+	Page  int `query:"page" validate:"gte=1"`
+	Limit int `query:"limit" validate:"gte=1,lte=100"`
+}
+
+type UploadCoverRequest struct {
+	Cover *multipart.FileHeader `form:"cover" validate:"required"`
+}
+
+// ImportBookRequest berisi file CSV atau JSON Lines, format ditebak dari
+// ekstensi file bila tidak diisi.
+type ImportBookRequest struct {
+	File   *multipart.FileHeader `form:"file" validate:"required"`
+	Format string                `form:"format" validate:"omitempty,oneof=csv jsonl"`
 }
 
 func ToCore(data interface{}) *book.Core {
@@ -23,14 +94,79 @@ func ToCore(data interface{}) *book.Core {
 		res.Judul = cnv.Judul
 		res.TahunTerbit = cnv.TahunTerbit
 		res.Penulis = cnv.Penulis
+		res.PenulisID = cnv.PenulisID
+		res.ISBN = cnv.ISBN
+		res.Penerbit = cnv.Penerbit
+		res.Bahasa = cnv.Bahasa
+		res.JumlahHalaman = cnv.JumlahHalaman
+		res.Deskripsi = cnv.Deskripsi
+		res.Genre = cnv.Genre
 	case UpdateBookRequest:
 		cnv := data.(UpdateBookRequest)
-		res.Judul = cnv.Judul
-		res.TahunTerbit = cnv.TahunTerbit
-		res.Penulis = cnv.Penulis
+		res.Judul = value(cnv.Judul)
+		res.TahunTerbit = value(cnv.TahunTerbit)
+		res.Penulis = value(cnv.Penulis)
+		res.PenulisID = value(cnv.PenulisID)
+		res.ISBN = value(cnv.ISBN)
+		res.Penerbit = value(cnv.Penerbit)
+		res.Bahasa = value(cnv.Bahasa)
+		res.JumlahHalaman = value(cnv.JumlahHalaman)
+		res.Deskripsi = value(cnv.Deskripsi)
+		res.Genre = value(cnv.Genre)
 	default:
 		return nil
 	}
 
 	return &res
 }
+
+// ToDocument membuat dokumen UpdateBookRequest dari buku saat ini, tempat
+// patch diterapkan.
+func ToDocument(data book.Core) UpdateBookRequest {
+	ids := []uint{}
+	for _, a := range data.Authors {
+		ids = append(ids, a.ID)
+	}
+	genre := data.Genre
+	if genre == nil {
+		genre = []string{}
+	}
+	return UpdateBookRequest{
+		Judul:         &data.Judul,
+		TahunTerbit:   &data.TahunTerbit,
+		Penulis:       &data.Penulis,
+		PenulisID:     &ids,
+		ISBN:          &data.ISBN,
+		Penerbit:      &data.Penerbit,
+		Bahasa:        &data.Bahasa,
+		JumlahHalaman: &data.JumlahHalaman,
+		Deskripsi:     &data.Deskripsi,
+		Genre:         &genre,
+	}
+}
+
+// value mengembalikan nilai pointer, atau zero value bila nil.
+func value[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+func (r ListBookRequest) ToFilter() book.Filter {
+	return book.Filter{
+		Judul:      r.Judul,
+		Penulis:    r.Penulis,
+		AuthorID:   r.PenulisID,
+		ISBN:       r.ISBN,
+		Penerbit:   r.Penerbit,
+		Bahasa:     r.Bahasa,
+		Genre:      r.Genre,
+		MinHalaman: r.MinHalaman,
+		MaxHalaman: r.MaxHalaman,
+		Sort:       r.Sort,
+		Page:       r.Page,
+		Limit:      r.Limit,
+	}
+}
@@ -1,13 +1,68 @@
 package handler
 
-import "api/features/book"
+import (
+	"api/features/book"
+	"api/helper"
+	"time"
+)
 
 type BookResponse struct {
-	ID          uint   `json:"id"`
-	Judul       string `json:"judul"`
-	TahunTerbit int    `json:"tahun_terbit"`
-	Penulis     string `json:"penulis"`
-	Pemilik     string `json:"pemilik"`
+	ID            uint                 `json:"id"`
+	Judul         string               `json:"judul"`
+	TahunTerbit   int                  `json:"tahun_terbit"`
+	Penulis       string               `json:"penulis"`
+	Authors       []BookAuthorResponse `json:"authors"`
+	Pemilik       string               `json:"pemilik"`
+	ISBN          string               `json:"isbn,omitempty"`
+	Penerbit      string               `json:"penerbit,omitempty"`
+	Bahasa        string               `json:"bahasa,omitempty"`
+	JumlahHalaman int                  `json:"jumlah_halaman,omitempty"`
+	Deskripsi     string               `json:"deskripsi,omitempty"`
+	Genre         []string             `json:"genre"`
+	CoverURL      string               `json:"cover_url,omitempty"`
+	ThumbnailURL  string               `json:"thumbnail_url,omitempty"`
+	RatingRata    float64              `json:"rating_rata"`
+	JumlahUlasan  int                  `json:"jumlah_ulasan"`
+}
+
+// TrashResponse adalah buku di sampah beserta waktu dihapus dan waktu
+// buku akan dihapus permanen.
+type TrashResponse struct {
+	BookResponse
+	DeletedAt time.Time `json:"deleted_at"`
+	PurgeAt   time.Time `json:"purge_at"`
+}
+
+// RevisionResponse adalah satu revisi buku, Snapshot berisi isi buku pada
+// versi Rev dan Changes field yang diubah dari versi itu.
+type RevisionResponse struct {
+	Rev       uint                      `json:"rev"`
+	UserID    uint                      `json:"user_id"`
+	Snapshot  SnapshotResponse          `json:"snapshot"`
+	Changes   map[string]ChangeResponse `json:"changes"`
+	CreatedAt time.Time                 `json:"created_at"`
+}
+
+type SnapshotResponse struct {
+	Judul         string   `json:"judul"`
+	TahunTerbit   int      `json:"tahun_terbit"`
+	Penulis       string   `json:"penulis"`
+	ISBN          string   `json:"isbn,omitempty"`
+	Penerbit      string   `json:"penerbit,omitempty"`
+	Bahasa        string   `json:"bahasa,omitempty"`
+	JumlahHalaman int      `json:"jumlah_halaman,omitempty"`
+	Deskripsi     string   `json:"deskripsi,omitempty"`
+	Genre         []string `json:"genre"`
+}
+
+type ChangeResponse struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type BookAuthorResponse struct {
+	ID   uint   `json:"id"`
+	Nama string `json:"nama"`
 }
 
 type AddBookResponse struct {
@@ -16,12 +71,158 @@ type AddBookResponse struct {
 	Penulis     string `json:"penulis"`
 }
 
+type ImportJobResponse struct {
+	ID         uint                   `json:"id"`
+	Status     string                 `json:"status"`
+	Total      int                    `json:"total"`
+	Created    int                    `json:"created"`
+	Skipped    int                    `json:"skipped"`
+	Failed     int                    `json:"failed"`
+	Results    []ImportResultResponse `json:"results"`
+	CreatedAt  time.Time              `json:"created_at"`
+	FinishedAt *time.Time             `json:"finished_at,omitempty"`
+}
+
+type ImportResultResponse struct {
+	Line   int    `json:"line"`
+	Status string `json:"status"`
+	BookID uint   `json:"book_id,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func ToJobResponse(data book.ImportJob) ImportJobResponse {
+	res := ImportJobResponse{
+		ID:         data.ID,
+		Status:     data.Status,
+		Total:      data.Total,
+		Created:    data.Created,
+		Skipped:    data.Skipped,
+		Failed:     data.Failed,
+		Results:    []ImportResultResponse{},
+		CreatedAt:  data.CreatedAt,
+		FinishedAt: data.FinishedAt,
+	}
+	for _, r := range data.Results {
+		res.Results = append(res.Results, ImportResultResponse(r))
+	}
+	return res
+}
+
 func ToResponse(data book.Core) BookResponse {
+	genre := data.Genre
+	if genre == nil {
+		genre = []string{}
+	}
+	authors := []BookAuthorResponse{}
+	for _, a := range data.Authors {
+		authors = append(authors, BookAuthorResponse{ID: a.ID, Nama: a.Nama})
+	}
 	return BookResponse{
-		ID:          data.ID,
-		Judul:       data.Judul,
-		TahunTerbit: data.TahunTerbit,
-		Penulis:     data.Penulis,
-		Pemilik:     data.Pemilik,
+		ID:            data.ID,
+		Judul:         data.Judul,
+		TahunTerbit:   data.TahunTerbit,
+		Penulis:       data.Penulis,
+		Authors:       authors,
+		Pemilik:       data.Pemilik,
+		ISBN:          data.ISBN,
+		Penerbit:      data.Penerbit,
+		Bahasa:        data.Bahasa,
+		JumlahHalaman: data.JumlahHalaman,
+		Deskripsi:     data.Deskripsi,
+		Genre:         genre,
+		CoverURL:      data.CoverURL,
+		ThumbnailURL:  data.ThumbnailURL,
+		RatingRata:    data.RatingRata,
+		JumlahUlasan:  data.JumlahUlasan,
 	}
 }
+
+func PrintSuccessReponse(code int, message string, data interface{}) (int, interface{}) {
+	resp := map[string]interface{}{}
+	resp["data"] = ToResponse(data.(book.Core))
+
+	if message != "" {
+		resp["message"] = message
+	}
+
+	return code, resp
+}
+
+func PrintListResponse(code int, message string, data []book.Core, pagination helper.Pagination) (int, interface{}) {
+	res := []BookResponse{}
+	for _, v := range data {
+		res = append(res, ToResponse(v))
+	}
+
+	resp := map[string]interface{}{}
+	resp["data"] = res
+	resp["pagination"] = pagination
+
+	if message != "" {
+		resp["message"] = message
+	}
+
+	return code, resp
+}
+
+func PrintTrashResponse(code int, message string, data []book.Core, pagination helper.Pagination) (int, interface{}) {
+	res := []TrashResponse{}
+	for _, v := range data {
+		res = append(res, TrashResponse{BookResponse: ToResponse(v), DeletedAt: v.DeletedAt, PurgeAt: v.PurgeAt})
+	}
+
+	resp := map[string]interface{}{}
+	resp["data"] = res
+	resp["pagination"] = pagination
+
+	if message != "" {
+		resp["message"] = message
+	}
+
+	return code, resp
+}
+
+func PrintJobResponse(code int, message string, data book.ImportJob) (int, interface{}) {
+	resp := map[string]interface{}{}
+	resp["data"] = ToJobResponse(data)
+
+	if message != "" {
+		resp["message"] = message
+	}
+
+	return code, resp
+}
+
+func ToRevisionResponse(data book.Revision) RevisionResponse {
+	res := RevisionResponse{
+		Rev:       data.Rev,
+		UserID:    data.UserID,
+		Snapshot:  SnapshotResponse(data.Before),
+		Changes:   map[string]ChangeResponse{},
+		CreatedAt: data.CreatedAt,
+	}
+	if res.Snapshot.Genre == nil {
+		res.Snapshot.Genre = []string{}
+	}
+	for k, v := range data.Changes {
+		res.Changes[k] = ChangeResponse(v)
+	}
+	return res
+}
+
+func PrintHistoryResponse(code int, message string, data []book.Revision, pagination helper.Pagination) (int, interface{}) {
+	res := []RevisionResponse{}
+	for _, v := range data {
+		res = append(res, ToRevisionResponse(v))
+	}
+
+	resp := map[string]interface{}{}
+	resp["data"] = res
+	resp["pagination"] = pagination
+
+	if message != "" {
+		resp["message"] = message
+	}
+
+	return code, resp
+}
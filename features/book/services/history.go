@@ -0,0 +1,120 @@
+package services
+
+import (
+	"api/features/audit"
+	"api/features/book"
+	"api/helper"
+	"api/logger"
+	"api/tracing"
+	"api/uow"
+	"context"
+	"errors"
+	"strings"
+)
+
+// revertFields adalah field Core yang dikembalikan saat revert, sama dengan
+// isi book.Snapshot.
+var revertFields = []string{"Judul", "TahunTerbit", "Penulis", "ISBN", "Penerbit", "Bahasa", "JumlahHalaman", "Deskripsi", "Genre"}
+
+func (bs *bookSrv) History(ctx context.Context, token interface{}, bookID uint, page, limit int) ([]book.Revision, int64, error) {
+	ctx, span := tracing.Start(ctx, "BookService.History")
+	defer span.End()
+
+	id := helper.ExtractToken(token)
+	if id <= 0 {
+		return nil, 0, errors.New("data not found")
+	}
+
+	page, limit = helper.PageLimit(page, limit)
+	res, total, err := bs.data.History(ctx, uint(id), bookID, page, limit)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			return nil, 0, errors.New("book not found")
+		case strings.Contains(err.Error(), "forbidden"):
+			return nil, 0, errors.New(errForbidden)
+		default:
+			return nil, 0, errors.New("terjadi kesalahan pada server")
+		}
+	}
+
+	for i := range res {
+		res[i].Changes = changes(ctx, res[i])
+	}
+
+	return res, total, nil
+}
+
+func (bs *bookSrv) Revert(ctx context.Context, token interface{}, bookID uint, rev uint, version uint) (book.Core, error) {
+	ctx, span := tracing.Start(ctx, "BookService.Revert")
+	defer span.End()
+
+	id := helper.ExtractToken(token)
+	if id <= 0 {
+		return book.Core{}, errors.New("data not found")
+	}
+
+	r, err := bs.data.Revision(ctx, uint(id), bookID, rev)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "revision"):
+			return book.Core{}, errors.New("revision not found")
+		case strings.Contains(err.Error(), "not found"):
+			return book.Core{}, errors.New("book not found")
+		case strings.Contains(err.Error(), "forbidden"):
+			return book.Core{}, errors.New(errForbidden)
+		default:
+			return book.Core{}, errors.New("terjadi kesalahan pada server")
+		}
+	}
+
+	target := book.Core{
+		Judul:         r.Before.Judul,
+		TahunTerbit:   r.Before.TahunTerbit,
+		Penulis:       r.Before.Penulis,
+		ISBN:          r.Before.ISBN,
+		Penerbit:      r.Before.Penerbit,
+		Bahasa:        r.Before.Bahasa,
+		JumlahHalaman: r.Before.JumlahHalaman,
+		Deskripsi:     r.Before.Deskripsi,
+		Genre:         r.Before.Genre,
+		Version:       version,
+	}
+	if target.Genre == nil {
+		target.Genre = []string{}
+	}
+
+	res := book.Core{}
+	err = bs.uow.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+		before, err := bs.snapshot(ctx, r.Books, uint(id), bookID)
+		if err != nil {
+			return err
+		}
+		res, err = r.Books.Update(ctx, uint(id), bookID, target, revertFields)
+		if err != nil {
+			return err
+		}
+		bs.record(ctx, audit.ActionRevert, audit.EntityBook, bookID, before, res)
+		return nil
+	})
+	if err != nil {
+		return book.Core{}, updateError(err)
+	}
+	logger.Info(ctx, "buku dikembalikan ke revisi lama", logger.Fields{"book_id": bookID, "rev": rev})
+
+	return bs.withURL(res), nil
+}
+
+// changes menghitung field yang berubah pada satu revisi.
+func changes(ctx context.Context, r book.Revision) map[string]book.Change {
+	res := map[string]book.Change{}
+	diff, err := audit.Diff(r.Before, r.After)
+	if err != nil {
+		logger.Error(ctx, "diff revisi error", logger.Fields{"error": err, "book_id": r.BookID, "rev": r.Rev})
+		return res
+	}
+	for k, v := range diff {
+		res[k] = book.Change{Before: v.Before, After: v.After}
+	}
+	return res
+}
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"api/features/audit"
+	"api/features/book"
+	"api/helper"
+	"api/logger"
+	"api/metrics"
+	"api/tracing"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	importBatchSize = 100
+	// syncImportLimit adalah jumlah baris maksimal yang langsung diproses
+	// di dalam request, import yang lebih besar dikerjakan di background.
+	syncImportLimit = 100
+)
+
+func (bs *bookSrv) Import(ctx context.Context, token interface{}, rows []book.ImportRow) (book.ImportJob, error) {
+	ctx, span := tracing.Start(ctx, "BookService.Import")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return book.ImportJob{}, errors.New("user not found")
+	}
+	if len(rows) == 0 {
+		return book.ImportJob{}, errors.New("format file import tidak sesuai, tidak ada data buku")
+	}
+
+	job, err := bs.data.CreateImportJob(ctx, book.ImportJob{UserID: uint(userID), Status: book.ImportPending, Total: len(rows)})
+	if err != nil {
+		return book.ImportJob{}, errors.New("terjadi kesalahan pada server")
+	}
+
+	if len(rows) <= syncImportLimit {
+		return bs.runImport(ctx, job, rows), nil
+	}
+
+	// request selesai lebih dulu, jadi job memakai context baru yang tetap
+	// membawa request ID agar log-nya bisa ditelusuri
+	jobCtx := logger.WithUserID(logger.WithRequestID(context.Background(), logger.RequestID(ctx)), logger.UserID(ctx))
+	jobCtx = logger.WithIP(jobCtx, logger.IP(ctx))
+	bs.imports.Add(1)
+	go func() {
+		defer bs.imports.Done()
+		bs.runImport(jobCtx, job, rows)
+	}()
+
+	return job, nil
+}
+
+func (bs *bookSrv) WaitImports(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		bs.imports.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (bs *bookSrv) ImportStatus(ctx context.Context, token interface{}, jobID uint) (book.ImportJob, error) {
+	ctx, span := tracing.Start(ctx, "BookService.ImportStatus")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return book.ImportJob{}, errors.New("user not found")
+	}
+
+	res, err := bs.data.ImportJob(ctx, uint(userID), jobID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return book.ImportJob{}, errors.New("import not found")
+		}
+		return book.ImportJob{}, errors.New("terjadi kesalahan pada server")
+	}
+
+	return res, nil
+}
+
+// runImport memvalidasi setiap baris dengan aturan yang sama seperti Add,
+// melewati ISBN yang sudah ada, lalu menyimpan buku per batch. Bila satu
+// batch gagal, buku di batch tersebut disimpan satu per satu agar baris
+// penyebabnya bisa dilaporkan. Baris yang belum lengkap dilengkapi dari
+// metadata provider seperti Add. Bila terjadi panic, job ditandai failed
+// dengan laporan terakhir yang tersimpan.
+func (bs *bookSrv) runImport(ctx context.Context, job book.ImportJob, rows []book.ImportRow) (res book.ImportJob) {
+	ctx, span := tracing.Start(ctx, "BookService.runImport")
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error(ctx, "import buku panic", logger.Fields{"panic": r, "job_id": job.ID})
+			now := time.Now()
+			job.Status = book.ImportFailed
+			job.FinishedAt = &now
+			if err := bs.data.UpdateImportJob(ctx, job); err != nil {
+				logger.Warn(ctx, "simpan status import gagal", logger.Fields{"error": err, "job_id": job.ID})
+			}
+			res = job
+		}
+	}()
+
+	results := make([]book.ImportResult, len(rows))
+	books := make([]book.Core, len(rows))
+	pending := []int{}
+	seen := map[string]int{}
+
+	for i, row := range rows {
+		results[i].Line = row.Line
+		if row.Err != "" {
+			results[i].Status, results[i].Reason = book.ImportFailed, row.Err
+			continue
+		}
+
+		b, err := bs.prepare(ctx, bs.fillMetadata(ctx, row.Book))
+		if err != nil {
+			results[i].Status, results[i].Reason = book.ImportFailed, err.Error()
+			continue
+		}
+		if b.ISBN != "" {
+			if line, ok := seen[b.ISBN]; ok {
+				results[i].Status, results[i].Reason = book.ImportSkipped, fmt.Sprintf("ISBN sama dengan baris %d", line)
+				continue
+			}
+			seen[b.ISBN] = row.Line
+		}
+		books[i] = b
+		pending = append(pending, i)
+	}
+
+	pending = bs.skipExisting(ctx, job.UserID, books, pending, results)
+
+	job.Status = book.ImportRunning
+	running := bs.saveProgress(ctx, &job, results)
+
+	for start := 0; running && start < len(pending); start += importBatchSize {
+		end := start + importBatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		batch := pending[start:end]
+
+		newBooks := make([]book.Core, 0, len(batch))
+		for _, i := range batch {
+			newBooks = append(newBooks, books[i])
+		}
+
+		added, err := bs.data.AddBatch(ctx, job.UserID, newBooks)
+		if err == nil {
+			for n, i := range batch {
+				results[i].Status, results[i].BookID = book.ImportCreated, added[n].ID
+			}
+		} else {
+			logger.Warn(ctx, "import batch gagal, menyimpan per baris", logger.Fields{"error": err, "job_id": job.ID})
+			for _, i := range batch {
+				res, err := bs.data.Add(ctx, job.UserID, books[i])
+				if err != nil {
+					results[i].Status, results[i].Reason = book.ImportFailed, addError(err).Error()
+					continue
+				}
+				results[i].Status, results[i].BookID = book.ImportCreated, res.ID
+			}
+		}
+		running = bs.saveProgress(ctx, &job, results)
+	}
+	if !running {
+		metrics.BooksAdded.Add(float64(job.Created))
+		logger.Info(ctx, "import buku dibatalkan", logger.Fields{"job_id": job.ID, "created": job.Created})
+		bs.recordImport(ctx, job)
+		return job
+	}
+
+	now := time.Now()
+	job.Status = book.ImportDone
+	job.FinishedAt = &now
+	bs.saveProgress(ctx, &job, results)
+	metrics.BooksAdded.Add(float64(job.Created))
+
+	logger.Info(ctx, "import buku selesai", logger.Fields{
+		"job_id": job.ID, "created": job.Created, "skipped": job.Skipped, "failed": job.Failed,
+	})
+	bs.recordImport(ctx, job)
+	return job
+}
+
+// recordImport mencatat satu ringkasan per job import, buku yang dibuat
+// bisa dilihat dari hasil job sehingga tidak dicatat satu per satu.
+func (bs *bookSrv) recordImport(ctx context.Context, job book.ImportJob) {
+	bs.record(ctx, audit.ActionImport, audit.EntityImportJob, job.ID, nil, map[string]interface{}{
+		"status": job.Status, "total": job.Total, "created": job.Created, "skipped": job.Skipped, "failed": job.Failed,
+	})
+}
+
+// skipExisting menandai baris dengan ISBN yang sudah ada di koleksi user
+// sebagai skipped dan mengembalikan sisa baris yang perlu disimpan.
+func (bs *bookSrv) skipExisting(ctx context.Context, userID uint, books []book.Core, pending []int, results []book.ImportResult) []int {
+	isbns := []string{}
+	for _, i := range pending {
+		if books[i].ISBN != "" {
+			isbns = append(isbns, books[i].ISBN)
+		}
+	}
+	if len(isbns) == 0 {
+		return pending
+	}
+
+	existing, err := bs.data.ExistingISBN(ctx, userID, isbns)
+	if err != nil {
+		for _, i := range pending {
+			results[i].Status, results[i].Reason = book.ImportFailed, "terjadi kesalahan pada server"
+		}
+		return nil
+	}
+
+	found := map[string]bool{}
+	for _, isbn := range existing {
+		found[isbn] = true
+	}
+
+	res := []int{}
+	for _, i := range pending {
+		if found[books[i].ISBN] {
+			results[i].Status, results[i].Reason = book.ImportSkipped, "ISBN sudah ada di koleksi"
+			continue
+		}
+		res = append(res, i)
+	}
+	return res
+}
+
+// saveProgress menghitung ulang ringkasan job dari hasil yang sudah ada lalu
+// menyimpannya agar bisa dipantau lewat ImportStatus. Hasilnya false bila job
+// sudah dibatalkan sehingga import harus berhenti.
+func (bs *bookSrv) saveProgress(ctx context.Context, job *book.ImportJob, results []book.ImportResult) bool {
+	job.Created, job.Skipped, job.Failed = 0, 0, 0
+	job.Results = []book.ImportResult{}
+	for _, r := range results {
+		switch r.Status {
+		case book.ImportCreated:
+			job.Created++
+		case book.ImportSkipped:
+			job.Skipped++
+		case book.ImportFailed:
+			job.Failed++
+		default:
+			continue
+		}
+		job.Results = append(job.Results, r)
+	}
+
+	err := bs.data.UpdateImportJob(ctx, *job)
+	if err != nil && strings.Contains(err.Error(), "cancelled") {
+		job.Status = book.ImportCancelled
+		return false
+	}
+	if err != nil {
+		logger.Warn(ctx, "simpan progress import gagal", logger.Fields{"error": err, "job_id": job.ID})
+	}
+	return true
+}
@@ -1,98 +1,670 @@
 package services
 
 import (
+	"api/features/audit"
 	"api/features/book"
 	"api/helper"
+	"api/logger"
+	"api/metrics"
+	"api/storage"
+	"api/tracing"
+	"api/uow"
+	"bytes"
+	"context"
 	"errors"
-	"log"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 )
 
+const (
+	maxCoverSize = 2 << 20
+	// maxCoverDimension membatasi lebar dan tinggi cover sebelum didecode.
+	// File 2MB bisa berisi gambar berdimensi sangat besar yang butuh
+	// memori berkali lipat saat didecode.
+	maxCoverDimension = 4096
+	thumbnailSize     = 256
+
+	errForbidden = "forbidden: buku milik user lain"
+	// errPrecondition dikembalikan bila If-Match tidak sesuai versi buku.
+	errPrecondition = "precondition failed: buku sudah diubah, muat ulang lalu coba lagi"
+)
+
+// fieldNames memetakan nama field Core ke nama field pada request.
+var fieldNames = map[string]string{
+	"PenulisID":     "penulis_id",
+	"ISBN":          "isbn",
+	"Penerbit":      "penerbit",
+	"Bahasa":        "bahasa",
+	"JumlahHalaman": "jumlah_halaman",
+	"Deskripsi":     "deskripsi",
+	"Genre":         "genre",
+}
+
+// coverTypes berisi tipe gambar cover yang diterima beserta ekstensinya.
+var coverTypes = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
 type bookSrv struct {
-	data book.BookData
-	vld  *validator.Validate
+	data  book.BookData
+	uow   uow.UnitOfWork
+	vld   *validator.Validate
+	blobs storage.BlobStore
+	meta  book.MetadataProvider
+	// retention adalah lama buku disimpan di sampah sebelum dihapus
+	// permanen oleh PurgeTrash.
+	retention time.Duration
+	audit     audit.Recorder
+	// imports menghitung import yang berjalan di background.
+	imports sync.WaitGroup
 }
 
-func New(d book.BookData) book.BookService {
+// New membuat BookService. uw menjalankan perubahan buku bersama catatan
+// audit lognya dalam satu transaksi. bs boleh nil, berarti cover tidak bisa
+// diunggah, mp boleh nil, berarti buku selalu diisi manual, rec boleh nil,
+// berarti perubahan buku tidak dicatat di audit log.
+func New(d book.BookData, uw uow.UnitOfWork, bs storage.BlobStore, mp book.MetadataProvider, retention time.Duration, rec audit.Recorder) book.BookService {
 	return &bookSrv{
-		data: d,
-		vld:  validator.New(),
+		data:      d,
+		uow:       uw,
+		vld:       validator.New(),
+		blobs:     bs,
+		meta:      mp,
+		retention: retention,
+		audit:     rec,
 	}
 }
 
-func (bs *bookSrv) Add(token interface{}, newBook book.Core) (book.Core, error) {
+// record mencatat perubahan ke audit log bila recorder dipasang.
+func (bs *bookSrv) record(ctx context.Context, action, entity string, id uint, before, after interface{}) {
+	if bs.audit == nil {
+		return
+	}
+	bs.audit.Record(ctx, action, entity, id, before, after)
+}
+
+// snapshot mengunci buku lalu mengambil datanya sebelum diubah untuk audit
+// log, dipanggil di dalam unit of work agar tidak ada perubahan lain di
+// antara pembacaan dan perubahan. Tanpa recorder buku tidak perlu dibaca.
+func (bs *bookSrv) snapshot(ctx context.Context, books book.BookData, userID, bookID uint) (interface{}, error) {
+	if bs.audit == nil {
+		return nil, nil
+	}
+	old, err := books.Lock(ctx, userID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	return old, nil
+}
+
+// withURL mengisi URL cover dari key blob yang tersimpan.
+func (bs *bookSrv) withURL(b book.Core) book.Core {
+	if bs.blobs == nil {
+		return b
+	}
+	if b.CoverKey != "" {
+		b.CoverURL = bs.blobs.URL(b.CoverKey)
+	}
+	if b.ThumbnailKey != "" {
+		b.ThumbnailURL = bs.blobs.URL(b.ThumbnailKey)
+	}
+	return b
+}
+
+func (bs *bookSrv) Add(ctx context.Context, token interface{}, newBook book.Core) (book.Core, error) {
+	ctx, span := tracing.Start(ctx, "BookService.Add")
+	defer span.End()
+
 	userID := helper.ExtractToken(token)
 	if userID <= 0 {
 		return book.Core{}, errors.New("user not found")
 	}
 
-	err := bs.vld.Struct(newBook)
+	newBook, err := bs.prepare(ctx, bs.fillMetadata(ctx, newBook))
 	if err != nil {
-		if _, ok := err.(*validator.InvalidValidationError); ok {
-			log.Println(err)
+		return book.Core{}, err
+	}
+
+	res := book.Core{}
+	err = bs.uow.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+		res, err = r.Books.Add(ctx, uint(userID), newBook)
+		if err != nil {
+			return err
 		}
-		return book.Core{}, errors.New("input buku tidak sesuai dengan arahan")
+		res.UserID = uint(userID)
+		bs.record(ctx, audit.ActionCreate, audit.EntityBook, res.ID, nil, res)
+		return nil
+	})
+	if err != nil {
+		return book.Core{}, addError(err)
 	}
+	metrics.BooksAdded.Inc()
+
+	return bs.withURL(res), nil
 
-	res, err := bs.data.Add(uint(userID), newBook)
+}
+func (bs *bookSrv) List(ctx context.Context, filter book.Filter) ([]book.Core, int64, error) {
+	ctx, span := tracing.Start(ctx, "BookService.List")
+	defer span.End()
+
+	filter.ISBN = normalizeISBN(filter.ISBN)
+	filter.Genre = strings.ToLower(strings.TrimSpace(filter.Genre))
+	filter.Page, filter.Limit = helper.PageLimit(filter.Page, filter.Limit)
+	if filter.MaxHalaman > 0 && filter.MinHalaman > filter.MaxHalaman {
+		return nil, 0, errors.New("format filter tidak sesuai, min_halaman lebih besar dari max_halaman")
+	}
+
+	res, total, err := bs.data.List(ctx, filter)
+	if err != nil {
+		return nil, 0, errors.New("terjadi kesalahan pada server")
+	}
+
+	for i := range res {
+		res[i] = bs.withURL(res[i])
+	}
+
+	return res, total, nil
+}
+
+func (bs *bookSrv) Detail(ctx context.Context, bookID uint) (book.Core, error) {
+	ctx, span := tracing.Start(ctx, "BookService.Detail")
+	defer span.End()
+
+	res, err := bs.data.Detail(ctx, bookID)
 	if err != nil {
-		msg := ""
 		if strings.Contains(err.Error(), "not found") {
-			msg = "user not found"
-		} else {
-			msg = "terjadi kesalahan pada server"
+			return book.Core{}, errors.New("book not found")
 		}
-		return book.Core{}, errors.New(msg)
+		return book.Core{}, errors.New("terjadi kesalahan pada server")
+	}
+
+	return bs.withURL(res), nil
+}
+
+func (bs *bookSrv) Update(ctx context.Context, token interface{}, bookID uint, updatedData book.Core, fields []string) (book.Core, error) {
+	ctx, span := tracing.Start(ctx, "BookService.Update")
+	defer span.End()
+
+	id := helper.ExtractToken(token)
+
+	if id <= 0 {
+		return book.Core{}, errors.New("data not found")
 	}
-	res.UserID = uint(userID)
 
-	return res, nil
+	// penulis dikirim sebagai teks atau ID, yang tidak diubah dikosongkan
+	// agar tidak ikut dipakai saat penulis diganti
+	changed := map[string]bool{}
+	for _, f := range fields {
+		changed[f] = true
+	}
+	if changed["Penulis"] && !changed["PenulisID"] {
+		updatedData.PenulisID = nil
+	} else if changed["PenulisID"] && !changed["Penulis"] {
+		updatedData.Penulis = ""
+	}
+	if changed["Genre"] && updatedData.Genre == nil {
+		updatedData.Genre = []string{}
+	}
+
+	// hanya field yang diubah yang divalidasi, field wajib tetap tidak
+	// boleh dikosongkan
+	updatedData = normalize(updatedData)
+	if len(fields) > 0 {
+		if err := bs.validate(ctx, bs.vld.StructPartial(updatedData, fields...)); err != nil {
+			return book.Core{}, err
+		}
+	}
+
+	res := book.Core{}
+	err := bs.uow.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+		before, err := bs.snapshot(ctx, r.Books, uint(id), bookID)
+		if err != nil {
+			return err
+		}
+		res, err = r.Books.Update(ctx, uint(id), bookID, updatedData, fields)
+		if err != nil {
+			return err
+		}
+		bs.record(ctx, audit.ActionUpdate, audit.EntityBook, bookID, before, res)
+		return nil
+	})
+	if err != nil {
+		return book.Core{}, updateError(err)
+	}
+
+	return bs.withURL(res), nil
 
 }
-func (bs *bookSrv) Update(token interface{}, bookID uint, updatedData book.Core) (book.Core, error) {
+
+func (bs *bookSrv) Delete(ctx context.Context, token interface{}, bookID uint, version uint) error {
+	ctx, span := tracing.Start(ctx, "BookService.Delete")
+	defer span.End()
+
 	id := helper.ExtractToken(token)
 
+	if id <= 0 {
+		return errors.New("data not found")
+	}
+
+	err := bs.uow.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+		before, err := bs.snapshot(ctx, r.Books, uint(id), bookID)
+		if err != nil {
+			return err
+		}
+		if err := r.Books.Delete(ctx, uint(id), bookID, version); err != nil {
+			return err
+		}
+		bs.record(ctx, audit.ActionDelete, audit.EntityBook, bookID, before, nil)
+		return nil
+	})
+	if err != nil {
+		logger.Error(ctx, "delete query error", logger.Fields{"error": err})
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			return errors.New("book not found")
+		case strings.Contains(err.Error(), "forbidden"):
+			return errors.New(errForbidden)
+		case strings.Contains(err.Error(), "precondition"):
+			return errors.New(errPrecondition)
+		default:
+			return errors.New("terjadi kesalahan pada server")
+		}
+	}
+
+	return nil
+}
+
+func (bs *bookSrv) Trash(ctx context.Context, token interface{}, page, limit int) ([]book.Core, int64, error) {
+	ctx, span := tracing.Start(ctx, "BookService.Trash")
+	defer span.End()
+
+	id := helper.ExtractToken(token)
+	if id <= 0 {
+		return nil, 0, errors.New("user not found")
+	}
+
+	page, limit = helper.PageLimit(page, limit)
+	res, total, err := bs.data.Trash(ctx, uint(id), page, limit)
+	if err != nil {
+		return nil, 0, errors.New("terjadi kesalahan pada server")
+	}
+
+	for i := range res {
+		res[i].PurgeAt = res[i].DeletedAt.Add(bs.retention)
+		res[i] = bs.withURL(res[i])
+	}
+
+	return res, total, nil
+}
+
+func (bs *bookSrv) Restore(ctx context.Context, token interface{}, bookID uint) (book.Core, error) {
+	ctx, span := tracing.Start(ctx, "BookService.Restore")
+	defer span.End()
+
+	id := helper.ExtractToken(token)
 	if id <= 0 {
 		return book.Core{}, errors.New("data not found")
 	}
 
-	res, err := bs.data.Update(uint(id), bookID, updatedData)
+	res := book.Core{}
+	err := bs.uow.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+		var err error
+		res, err = r.Books.Restore(ctx, uint(id), bookID)
+		if err != nil {
+			return err
+		}
+		bs.record(ctx, audit.ActionRestore, audit.EntityBook, bookID, nil, res)
+		return nil
+	})
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			return book.Core{}, errors.New("book not found")
+		case strings.Contains(err.Error(), "forbidden"):
+			return book.Core{}, errors.New(errForbidden)
+		default:
+			return book.Core{}, errors.New("terjadi kesalahan pada server")
+		}
+	}
+
+	return bs.withURL(res), nil
+}
+
+func (bs *bookSrv) Purge(ctx context.Context, token interface{}, bookID uint) error {
+	ctx, span := tracing.Start(ctx, "BookService.Purge")
+	defer span.End()
 
+	id := helper.ExtractToken(token)
+	if id <= 0 {
+		return errors.New("data not found")
+	}
+
+	old := book.Core{}
+	err := bs.uow.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+		var err error
+		old, err = r.Books.Purge(ctx, uint(id), bookID)
+		if err != nil {
+			return err
+		}
+		bs.record(ctx, audit.ActionPurge, audit.EntityBook, bookID, old, nil)
+		return nil
+	})
 	if err != nil {
-		msg := ""
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			return errors.New("book not found")
+		case strings.Contains(err.Error(), "forbidden"):
+			return errors.New(errForbidden)
+		default:
+			return errors.New("terjadi kesalahan pada server")
+		}
+	}
+	bs.deleteBlobs(ctx, old.CoverKey, old.ThumbnailKey)
+
+	return nil
+}
+
+func (bs *bookSrv) PurgeTrash(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "BookService.PurgeTrash")
+	defer span.End()
+
+	res := []book.Core{}
+	err := bs.uow.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+		var err error
+		res, err = r.Books.PurgeTrash(ctx, time.Now().Add(-bs.retention))
+		if err != nil {
+			return err
+		}
+		for _, b := range res {
+			bs.record(ctx, audit.ActionPurge, audit.EntityBook, b.ID, b, nil)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errors.New("terjadi kesalahan pada server")
+	}
+	for _, b := range res {
+		bs.deleteBlobs(ctx, b.CoverKey, b.ThumbnailKey)
+	}
+
+	return len(res), nil
+}
+
+func (bs *bookSrv) UploadCover(ctx context.Context, token interface{}, bookID uint, file *multipart.FileHeader) (book.Core, error) {
+	ctx, span := tracing.Start(ctx, "BookService.UploadCover")
+	defer span.End()
+
+	id := helper.ExtractToken(token)
+	if id <= 0 {
+		return book.Core{}, errors.New("data not found")
+	}
+	if bs.blobs == nil {
+		logger.Error(ctx, "upload cover tanpa blob store")
+		return book.Core{}, errors.New("terjadi kesalahan pada server")
+	}
+	if file == nil {
+		return book.Core{}, errors.New("format cover tidak sesuai, file wajib diisi")
+	}
+	if file.Size > maxCoverSize {
+		return book.Core{}, errors.New("format cover tidak sesuai, ukuran maksimal 2MB")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		logger.Error(ctx, "open cover error", logger.Fields{"error": err})
+		return book.Core{}, errors.New("terjadi kesalahan pada server")
+	}
+	defer src.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(src, maxCoverSize+1))
+	if err != nil {
+		logger.Error(ctx, "read cover error", logger.Fields{"error": err})
+		return book.Core{}, errors.New("terjadi kesalahan pada server")
+	}
+	if len(raw) > maxCoverSize {
+		return book.Core{}, errors.New("format cover tidak sesuai, ukuran maksimal 2MB")
+	}
+
+	ctype := http.DetectContentType(raw)
+	ext, ok := coverTypes[ctype]
+	if !ok {
+		return book.Core{}, errors.New("format cover tidak sesuai, gunakan jpeg, png atau gif")
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil {
+		logger.Warn(ctx, "decode cover config error", logger.Fields{"error": err})
+		return book.Core{}, errors.New("format cover tidak sesuai, gambar tidak dapat dibaca")
+	}
+	if cfg.Width > maxCoverDimension || cfg.Height > maxCoverDimension {
+		return book.Core{}, errors.New("format cover tidak sesuai, dimensi maksimal 4096x4096 piksel")
+	}
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		logger.Warn(ctx, "decode cover error", logger.Fields{"error": err})
+		return book.Core{}, errors.New("format cover tidak sesuai, gambar tidak dapat dibaca")
+	}
+
+	thumb := bytes.Buffer{}
+	if err := jpeg.Encode(&thumb, helper.Thumbnail(img, thumbnailSize), &jpeg.Options{Quality: 80}); err != nil {
+		logger.Error(ctx, "encode thumbnail error", logger.Fields{"error": err})
+		return book.Core{}, errors.New("terjadi kesalahan pada server")
+	}
+
+	// key dibuat unik agar cache CDN/browser tidak menyajikan cover lama
+	stamp := time.Now().UnixNano()
+	coverKey := fmt.Sprintf("books/%d/cover-%d.%s", bookID, stamp, ext)
+	thumbKey := fmt.Sprintf("books/%d/thumb-%d.jpg", bookID, stamp)
+
+	if err := bs.blobs.Put(ctx, coverKey, bytes.NewReader(raw), int64(len(raw)), ctype); err != nil {
+		logger.Error(ctx, "store cover error", logger.Fields{"error": err, "key": coverKey})
+		return book.Core{}, errors.New("terjadi kesalahan pada server")
+	}
+	if err := bs.blobs.Put(ctx, thumbKey, bytes.NewReader(thumb.Bytes()), int64(thumb.Len()), "image/jpeg"); err != nil {
+		logger.Error(ctx, "store thumbnail error", logger.Fields{"error": err, "key": thumbKey})
+		bs.deleteBlobs(ctx, coverKey)
+		return book.Core{}, errors.New("terjadi kesalahan pada server")
+	}
 
+	res := book.Core{}
+	oldCover, oldThumb := "", ""
+	err = bs.uow.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+		before, err := bs.snapshot(ctx, r.Books, uint(id), bookID)
+		if err != nil {
+			return err
+		}
+		oldCover, oldThumb, err = r.Books.UpdateCover(ctx, uint(id), bookID, coverKey, thumbKey)
+		if err != nil {
+			return err
+		}
+		// dibaca ulang agar respons sama lengkapnya dengan detail buku
+		res, err = r.Books.Detail(ctx, bookID)
+		if err != nil {
+			return err
+		}
+		bs.record(ctx, audit.ActionUpdate, audit.EntityBook, bookID, before, res)
+		return nil
+	})
+	if err != nil {
+		bs.deleteBlobs(ctx, coverKey, thumbKey)
+
+		msg := ""
 		if strings.Contains(err.Error(), "not found") {
 			msg = "book not found"
-		} else if strings.Contains(err.Error(), "unauthorized") {
-			msg = "unauthorized request"
+		} else if strings.Contains(err.Error(), "forbidden") {
+			msg = errForbidden
 		} else {
 			msg = "there is a problem with server"
 		}
 		return book.Core{}, errors.New(msg)
 	}
+	bs.deleteBlobs(ctx, oldCover, oldThumb)
+
+	return bs.withURL(res), nil
+}
+
+// prepare merapikan dan memvalidasi buku baru, dipakai juga oleh import.
+func (bs *bookSrv) prepare(ctx context.Context, newBook book.Core) (book.Core, error) {
+	newBook = normalize(newBook)
+	if err := bs.validate(ctx, bs.vld.Struct(newBook)); err != nil {
+		return book.Core{}, err
+	}
+	return newBook, nil
+}
+
+// fillMetadata melengkapi field yang kosong dari metadata provider bila
+// buku dikirim dengan ISBN. Lookup yang gagal tidak menggagalkan request,
+// buku tetap divalidasi dengan data yang diisi user.
+func (bs *bookSrv) fillMetadata(ctx context.Context, b book.Core) book.Core {
+	if bs.meta == nil || b.ISBN == "" {
+		return b
+	}
+	if b.Judul != "" && b.TahunTerbit != 0 && (b.Penulis != "" || len(b.PenulisID) > 0) {
+		return b
+	}
+	isbn := normalizeISBN(b.ISBN)
+	if bs.vld.Var(isbn, "isbn") != nil {
+		return b
+	}
 
-	res.ID = bookID
-	res.UserID = uint(id)
+	ctx, span := tracing.Start(ctx, "BookService.fillMetadata")
+	defer span.End()
 
-	return res, nil
+	meta, err := bs.meta.Lookup(ctx, isbn)
+	if err != nil {
+		logger.Warn(ctx, "lookup metadata error", logger.Fields{"error": err, "isbn": isbn})
+		return b
+	}
 
+	if b.Judul == "" {
+		b.Judul = meta.Judul
+	}
+	if b.TahunTerbit == 0 {
+		b.TahunTerbit = meta.TahunTerbit
+	}
+	if b.Penulis == "" && len(b.PenulisID) == 0 {
+		b.Penulis = meta.Penulis
+	}
+	if b.Penerbit == "" {
+		b.Penerbit = meta.Penerbit
+	}
+	if b.JumlahHalaman == 0 {
+		b.JumlahHalaman = meta.JumlahHalaman
+	}
+	if b.Deskripsi == "" {
+		b.Deskripsi = meta.Deskripsi
+	}
+	return b
 }
 
-func (bs *bookSrv) Delete(token interface{}, bookID uint) error {
-	id := helper.ExtractToken(token)
+// updateError menerjemahkan error update buku menjadi pesan untuk client.
+func updateError(err error) error {
+	msg := ""
 
-	if id <= 0 {
-		return errors.New("data not found")
+	if strings.Contains(err.Error(), "author") {
+		msg = "penulis not found"
+	} else if strings.Contains(err.Error(), "not found") {
+		msg = "book not found"
+	} else if strings.Contains(err.Error(), "forbidden") {
+		msg = errForbidden
+	} else if strings.Contains(err.Error(), "precondition") {
+		msg = errPrecondition
+	} else {
+		msg = "there is a problem with server"
 	}
+	return errors.New(msg)
+}
 
-	err := bs.data.Delete(uint(id), bookID)
+func addError(err error) error {
+	msg := ""
+	if strings.Contains(err.Error(), "author") {
+		msg = "penulis not found"
+	} else if strings.Contains(err.Error(), "not found") {
+		msg = "user not found"
+	} else {
+		msg = "terjadi kesalahan pada server"
+	}
+	return errors.New(msg)
+}
 
-	if err != nil {
-		log.Println("delete query error", err.Error())
-		return err
+// validate menerjemahkan error validator menjadi pesan untuk client. Field
+// wajib (judul, tahun terbit, penulis) yang kosong dilaporkan bersama.
+func (bs *bookSrv) validate(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	vErrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		logger.Error(ctx, "validasi buku error", logger.Fields{"error": err})
+		return errors.New("terjadi kesalahan pada server")
 	}
 
-	return nil
+	invalid := []string{}
+	for _, fe := range vErrs {
+		field, _, _ := strings.Cut(fe.StructField(), "[")
+		name, ok := fieldNames[field]
+		if !ok {
+			return errors.New("format input buku tidak sesuai, judul, tahun terbit dan penulis wajib diisi")
+		}
+		invalid = append(invalid, name)
+	}
+	return fmt.Errorf("format %s tidak sesuai", strings.Join(invalid, ", "))
+}
+
+// normalize merapikan metadata sebelum divalidasi dan disimpan. Genre nil
+// dibiarkan nil agar update tanpa genre tidak menghapus genre lama.
+func normalize(b book.Core) book.Core {
+	b.ISBN = normalizeISBN(b.ISBN)
+	b.Penerbit = strings.TrimSpace(b.Penerbit)
+	b.Bahasa = strings.TrimSpace(b.Bahasa)
+	if b.Genre == nil {
+		return b
+	}
+
+	genres := []string{}
+	seen := map[string]bool{}
+	for _, g := range b.Genre {
+		g = strings.ToLower(strings.TrimSpace(g))
+		if seen[g] {
+			continue
+		}
+		seen[g] = true
+		genres = append(genres, g)
+	}
+	b.Genre = genres
+	return b
+}
+
+// normalizeISBN membuang tanda hubung dan spasi, serta menyeragamkan digit
+// cek "x" pada ISBN-10.
+func normalizeISBN(isbn string) string {
+	isbn = strings.NewReplacer("-", "", " ", "").Replace(isbn)
+	return strings.ToUpper(isbn)
+}
+
+// deleteBlobs menghapus blob tanpa menggagalkan request, kegagalan hanya dicatat.
+func (bs *bookSrv) deleteBlobs(ctx context.Context, keys ...string) {
+	if bs.blobs == nil {
+		return
+	}
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if err := bs.blobs.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			logger.Warn(ctx, "delete blob error", logger.Fields{"error": err, "key": key})
+		}
+	}
 }
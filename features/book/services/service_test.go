@@ -1,29 +1,50 @@
 package services
 
 import (
+	"api/features/audit"
 	"api/features/book"
 	"api/helper"
 	"api/mocks"
+	"api/storage"
+	"api/uow"
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+// inTx membuat UnitOfWork yang langsung menjalankan fn dengan books.
+func inTx(t *testing.T, books book.BookData) *mocks.UnitOfWork {
+	uw := mocks.NewUnitOfWork(t)
+	uw.On("Do", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context, uow.Repos) error) error {
+		return fn(ctx, uow.Repos{Books: books})
+	}).Maybe()
+	return uw
+}
+
 func TestAdd(t *testing.T) {
 	repo := mocks.NewBookData(t)
 
 	t.Run("berhasil tambah buku", func(t *testing.T) {
 		inputBook := book.Core{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eichiro Oda"}
 		resBook := book.Core{ID: uint(1), Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eichiro Oda"}
-		repo.On("Add", uint(1), inputBook).Return(resBook, nil).Once()
+		repo.On("Add", mock.Anything, uint(1), inputBook).Return(resBook, nil).Once()
 
-		srv := New(repo)
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
 		_, token := helper.GenerateJWT(1)
 		pToken := token.(*jwt.Token)
 		pToken.Valid = true
-		res, err := srv.Add(pToken, inputBook)
+		res, err := srv.Add(context.Background(), pToken, inputBook)
 		assert.Nil(t, err)
 		assert.Equal(t, resBook.ID, res.ID)
 		repo.AssertExpectations(t)
@@ -32,12 +53,12 @@ func TestAdd(t *testing.T) {
 
 	t.Run("masalah di server", func(t *testing.T) {
 		inputBook := book.Core{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eichiro Oda"}
-		repo.On("Add", uint(1), inputBook).Return(book.Core{}, errors.New("terdapat masalah pada server")).Once()
-		srv := New(repo)
+		repo.On("Add", mock.Anything, uint(1), inputBook).Return(book.Core{}, errors.New("terdapat masalah pada server")).Once()
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
 		_, token := helper.GenerateJWT(1)
 		pToken := token.(*jwt.Token)
 		pToken.Valid = true
-		res, err := srv.Add(pToken, inputBook)
+		res, err := srv.Add(context.Background(), pToken, inputBook)
 		assert.NotNil(t, err)
 		assert.Equal(t, uint(0), res.ID)
 		assert.ErrorContains(t, err, "server")
@@ -46,28 +67,155 @@ func TestAdd(t *testing.T) {
 
 	t.Run("user tidak ditemukan", func(t *testing.T) {
 		inputBook := book.Core{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eichiro Oda"}
-		repo.On("Add", uint(1), inputBook).Return(book.Core{}, errors.New("not found")).Once()
-		srv := New(repo)
+		repo.On("Add", mock.Anything, uint(1), inputBook).Return(book.Core{}, errors.New("not found")).Once()
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
 		_, token := helper.GenerateJWT(1)
 		pToken := token.(*jwt.Token)
 		pToken.Valid = true
-		res, err := srv.Add(pToken, inputBook)
+		res, err := srv.Add(context.Background(), pToken, inputBook)
 		assert.NotNil(t, err)
 		assert.Equal(t, uint(0), res.ID)
 		assert.ErrorContains(t, err, "not found")
 		repo.AssertExpectations(t)
 	})
 
+	t.Run("field wajib tidak boleh dikosongkan", func(t *testing.T) {
+		srv := New(mocks.NewBookData(t), inTx(t, nil), nil, nil, 0, nil)
+		_, token := helper.GenerateJWT(1)
+		pToken := token.(*jwt.Token)
+		pToken.Valid = true
+		_, err := srv.Update(context.Background(), pToken, 1, book.Core{Penulis: "Oda", PenulisID: []uint{1}}, []string{"Judul", "Penulis"})
+		assert.ErrorContains(t, err, "format")
+	})
+
+	t.Run("penulis teks menggantikan penulis id", func(t *testing.T) {
+		inputBook := book.Core{Judul: "Naruto", Penulis: "Masashi Kishimoto"}
+		repo.On("Update", mock.Anything, uint(1), uint(5), inputBook, []string{"Penulis", "Penerbit"}).Return(book.Core{ID: 5}, nil).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		_, token := helper.GenerateJWT(1)
+		pToken := token.(*jwt.Token)
+		pToken.Valid = true
+		_, err := srv.Update(context.Background(), pToken, 5, book.Core{Judul: "Naruto", Penulis: "Masashi Kishimoto", PenulisID: []uint{3}}, []string{"Penulis", "Penerbit"})
+		assert.Nil(t, err)
+		repo.AssertExpectations(t)
+	})
+
 	t.Run("jwt tidak valid", func(t *testing.T) {
 		inputBook := book.Core{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eichiro Oda"}
-		srv := New(repo)
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
 
 		_, token := helper.GenerateJWT(1)
-		res, err := srv.Add(token, inputBook)
+		res, err := srv.Add(context.Background(), token, inputBook)
 		assert.NotNil(t, err)
 		assert.Equal(t, uint(0), res.ID)
 		assert.ErrorContains(t, err, "not found")
 	})
+
+	t.Run("metadata dinormalisasi", func(t *testing.T) {
+		inputBook := book.Core{Judul: "Laskar Pelangi", TahunTerbit: 2005, Penulis: "Andrea Hirata",
+			ISBN: "978-979-3062-79-2", Bahasa: "id", JumlahHalaman: 529, Genre: []string{" Novel", "novel", "Drama"}}
+		expected := inputBook
+		expected.ISBN = "9789793062792"
+		expected.Genre = []string{"novel", "drama"}
+		repo.On("Add", mock.Anything, uint(1), expected).Return(expected, nil).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		_, token := helper.GenerateJWT(1)
+		pToken := token.(*jwt.Token)
+		pToken.Valid = true
+		res, err := srv.Add(context.Background(), pToken, inputBook)
+		assert.Nil(t, err)
+		assert.Equal(t, "9789793062792", res.ISBN)
+		assert.Equal(t, []string{"novel", "drama"}, res.Genre)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("penulis tidak ditemukan", func(t *testing.T) {
+		inputBook := book.Core{Judul: "Good Omens", TahunTerbit: 1990, PenulisID: []uint{1, 99}}
+		repo.On("Add", mock.Anything, uint(1), inputBook).Return(book.Core{}, errors.New("author 99 not found")).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		_, token := helper.GenerateJWT(1)
+		pToken := token.(*jwt.Token)
+		pToken.Valid = true
+		_, err := srv.Add(context.Background(), pToken, inputBook)
+		assert.ErrorContains(t, err, "penulis not found")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("metadata tidak valid", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		_, token := helper.GenerateJWT(1)
+		pToken := token.(*jwt.Token)
+		pToken.Valid = true
+
+		cases := map[string]book.Core{
+			"isbn":           {ISBN: "978-979-3062-79-3"},
+			"bahasa":         {Bahasa: "bahasa indonesia"},
+			"jumlah_halaman": {JumlahHalaman: -1},
+			"genre":          {Genre: []string{""}},
+		}
+		for field, meta := range cases {
+			inputBook := meta
+			inputBook.Judul, inputBook.TahunTerbit, inputBook.Penulis = "One Piece", 1997, "Eichiro Oda"
+			_, err := srv.Add(context.Background(), pToken, inputBook)
+			assert.ErrorContains(t, err, "format "+field)
+		}
+	})
+}
+
+func TestAddWithMetadata(t *testing.T) {
+	_, token := helper.GenerateJWT(1)
+	pToken := token.(*jwt.Token)
+	pToken.Valid = true
+	found := book.Core{ISBN: "9789793062792", Judul: "Laskar Pelangi", TahunTerbit: 2005,
+		Penulis: "Andrea Hirata", Penerbit: "Bentang Pustaka", JumlahHalaman: 529}
+
+	t.Run("dilengkapi dari isbn", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		meta := mocks.NewMetadataProvider(t)
+		meta.On("Lookup", mock.Anything, "9789793062792").Return(found, nil).Once()
+		expected := found
+		expected.Judul = "Laskar Pelangi (Edisi Revisi)"
+		repo.On("Add", mock.Anything, uint(1), expected).Return(expected, nil).Once()
+
+		srv := New(repo, inTx(t, repo), nil, meta, 0, nil)
+		res, err := srv.Add(context.Background(), pToken, book.Core{ISBN: "978-979-3062-79-2", Judul: "Laskar Pelangi (Edisi Revisi)"})
+		assert.Nil(t, err)
+		assert.Equal(t, "Andrea Hirata", res.Penulis)
+		assert.Equal(t, 2005, res.TahunTerbit)
+	})
+
+	t.Run("lookup gagal tetap bisa diisi manual", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		meta := mocks.NewMetadataProvider(t)
+		meta.On("Lookup", mock.Anything, "9789793062792").Return(book.Core{}, errors.New("context deadline exceeded")).Once()
+		input := book.Core{ISBN: "9789793062792", Judul: "Laskar Pelangi", Penulis: "Andrea Hirata"}
+
+		srv := New(repo, inTx(t, repo), nil, meta, 0, nil)
+		_, err := srv.Add(context.Background(), pToken, input)
+		assert.ErrorContains(t, err, "format input buku tidak sesuai")
+
+		input.TahunTerbit = 2005
+		repo.On("Add", mock.Anything, uint(1), input).Return(input, nil).Once()
+		meta.On("Lookup", mock.Anything, "9789793062792").Return(book.Core{}, errors.New("context deadline exceeded")).Maybe()
+		_, err = srv.Add(context.Background(), pToken, input)
+		assert.Nil(t, err)
+	})
+
+	t.Run("data lengkap tidak lookup", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		meta := mocks.NewMetadataProvider(t)
+		input := book.Core{ISBN: "9789793062792", Judul: "Laskar Pelangi", TahunTerbit: 2005, Penulis: "Andrea Hirata"}
+		repo.On("Add", mock.Anything, uint(1), input).Return(input, nil).Once()
+
+		srv := New(repo, inTx(t, repo), nil, meta, 0, nil)
+		_, err := srv.Add(context.Background(), pToken, input)
+		assert.Nil(t, err)
+		meta.AssertNotCalled(t, "Lookup", mock.Anything, mock.Anything)
+	})
 }
 
 func TestUpdate(t *testing.T) {
@@ -76,13 +224,13 @@ func TestUpdate(t *testing.T) {
 	t.Run("suskes update data", func(t *testing.T) {
 		inputBook := book.Core{Judul: "Naruto", TahunTerbit: 1999, Penulis: "Masashi Kishimoto"}
 		resBook := book.Core{ID: uint(1), Judul: "Naruto", TahunTerbit: 1999, Penulis: "Masashi Kishimoto"}
-		repo.On("Update", uint(1), uint(1), inputBook).Return(resBook, nil).Once()
+		repo.On("Update", mock.Anything, uint(1), uint(1), inputBook, []string{"Judul"}).Return(resBook, nil).Once()
 
-		srv := New(repo)
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
 		_, token := helper.GenerateJWT(1)
 		pToken := token.(*jwt.Token)
 		pToken.Valid = true
-		res, err := srv.Update(pToken, uint(1), inputBook)
+		res, err := srv.Update(context.Background(), pToken, uint(1), inputBook, []string{"Judul"})
 		assert.Nil(t, err)
 		assert.Equal(t, resBook.ID, res.ID)
 		assert.Equal(t, inputBook.Judul, res.Judul)
@@ -94,12 +242,12 @@ func TestUpdate(t *testing.T) {
 
 	t.Run("jwt tidak valid", func(t *testing.T) {
 		inputBook := book.Core{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eichiro Oda"}
-		srv := New(repo)
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
 
 		_, token := helper.GenerateJWT(0)
 		pToken := token.(*jwt.Token)
 		pToken.Valid = true
-		res, err := srv.Update(pToken, 1, inputBook)
+		res, err := srv.Update(context.Background(), pToken, 1, inputBook, []string{"Judul"})
 		assert.NotNil(t, err)
 		assert.ErrorContains(t, err, "not found")
 		assert.Equal(t, uint(0), res.ID)
@@ -107,28 +255,54 @@ func TestUpdate(t *testing.T) {
 
 	t.Run("data tidak ditemukan", func(t *testing.T) {
 		inputBook := book.Core{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eichiro Oda"}
-		repo.On("Update", uint(2), uint(2), inputBook).Return(book.Core{}, errors.New("data not found")).Once()
+		repo.On("Update", mock.Anything, uint(2), uint(2), inputBook, []string{"Judul"}).Return(book.Core{}, errors.New("data not found")).Once()
 
-		srv := New(repo)
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
 		_, token := helper.GenerateJWT(2)
 		pToken := token.(*jwt.Token)
 		pToken.Valid = true
-		res, err := srv.Update(pToken, 2, inputBook)
+		res, err := srv.Update(context.Background(), pToken, 2, inputBook, []string{"Judul"})
 		assert.NotNil(t, err)
 		assert.ErrorContains(t, err, "not found")
 		assert.Equal(t, uint(0), res.ID)
 		repo.AssertExpectations(t)
 	})
 
+	t.Run("buku milik user lain", func(t *testing.T) {
+		inputBook := book.Core{Judul: "One Piece"}
+		repo.On("Update", mock.Anything, uint(1), uint(3), inputBook, []string{"Judul"}).Return(book.Core{}, errors.New("forbidden: tidak memiliki akses")).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		_, token := helper.GenerateJWT(1)
+		pToken := token.(*jwt.Token)
+		pToken.Valid = true
+		_, err := srv.Update(context.Background(), pToken, 3, inputBook, []string{"Judul"})
+		assert.ErrorContains(t, err, "forbidden")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("versi buku sudah berubah", func(t *testing.T) {
+		inputBook := book.Core{Judul: "One Piece", Version: 2}
+		repo.On("Update", mock.Anything, uint(1), uint(4), inputBook, []string{"Judul"}).Return(book.Core{}, errors.New("precondition failed: buku sudah diubah (versi 3)")).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		_, token := helper.GenerateJWT(1)
+		pToken := token.(*jwt.Token)
+		pToken.Valid = true
+		_, err := srv.Update(context.Background(), pToken, 4, inputBook, []string{"Judul"})
+		assert.ErrorContains(t, err, "precondition")
+		repo.AssertExpectations(t)
+	})
+
 	t.Run("masalah di server", func(t *testing.T) {
 		inputBook := book.Core{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eichiro Oda"}
-		repo.On("Update", uint(1), uint(1), inputBook).Return(book.Core{}, errors.New("terdapat masalah pada server")).Once()
+		repo.On("Update", mock.Anything, uint(1), uint(1), inputBook, []string{"Judul"}).Return(book.Core{}, errors.New("terdapat masalah pada server")).Once()
 
-		srv := New(repo)
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
 		_, token := helper.GenerateJWT(1)
 		pToken := token.(*jwt.Token)
 		pToken.Valid = true
-		res, err := srv.Update(pToken, 1, inputBook)
+		res, err := srv.Update(context.Background(), pToken, 1, inputBook, []string{"Judul"})
 		assert.NotNil(t, err)
 		assert.ErrorContains(t, err, "server")
 		assert.Equal(t, uint(0), res.ID)
@@ -141,38 +315,582 @@ func TestDelete(t *testing.T) {
 	repo := mocks.NewBookData(t)
 
 	t.Run("suskes hapus buku", func(t *testing.T) {
-		repo.On("Delete", uint(1), uint(1)).Return(nil).Once()
+		repo.On("Delete", mock.Anything, uint(1), uint(1), uint(0)).Return(nil).Once()
 
-		srv := New(repo)
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
 		_, token := helper.GenerateJWT(1)
 		pToken := token.(*jwt.Token)
 		pToken.Valid = true
-		err := srv.Delete(pToken, 1)
+		err := srv.Delete(context.Background(), pToken, 1, 0)
 		assert.Nil(t, err)
 		repo.AssertExpectations(t)
 
 	})
 
 	t.Run("jwt tidak valid", func(t *testing.T) {
-		srv := New(repo)
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
 
 		_, token := helper.GenerateJWT(0)
-		err := srv.Delete(token, 1)
+		err := srv.Delete(context.Background(), token, 1, 0)
 		assert.NotNil(t, err)
 		assert.ErrorContains(t, err, "not found")
 	})
 
 	t.Run("data tidak ditemukan", func(t *testing.T) {
-		repo.On("Delete", uint(2), uint(2)).Return(errors.New("data not found")).Once()
+		repo.On("Delete", mock.Anything, uint(2), uint(2), uint(0)).Return(errors.New("data not found")).Once()
 
-		srv := New(repo)
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
 		_, token := helper.GenerateJWT(2)
 		pToken := token.(*jwt.Token)
 		pToken.Valid = true
-		err := srv.Delete(pToken, 2)
+		err := srv.Delete(context.Background(), pToken, 2, 0)
 		assert.NotNil(t, err)
 		assert.ErrorContains(t, err, "not found")
 		repo.AssertExpectations(t)
 	})
 
+	t.Run("buku milik user lain", func(t *testing.T) {
+		repo.On("Delete", mock.Anything, uint(1), uint(3), uint(0)).Return(errors.New("forbidden: tidak memiliki akses")).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		_, token := helper.GenerateJWT(1)
+		pToken := token.(*jwt.Token)
+		pToken.Valid = true
+		err := srv.Delete(context.Background(), pToken, 3, 0)
+		assert.ErrorContains(t, err, "forbidden")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("versi buku sudah berubah", func(t *testing.T) {
+		repo.On("Delete", mock.Anything, uint(1), uint(4), uint(2)).Return(errors.New("precondition failed: buku sudah diubah (versi 3)")).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		_, token := helper.GenerateJWT(1)
+		pToken := token.(*jwt.Token)
+		pToken.Valid = true
+		err := srv.Delete(context.Background(), pToken, 4, 2)
+		assert.ErrorContains(t, err, "precondition")
+		repo.AssertExpectations(t)
+	})
+}
+
+// coverFile membuat file upload multipart seperti yang diterima handler.
+func coverFile(t *testing.T, content []byte) *multipart.FileHeader {
+	body := bytes.Buffer{}
+	w := multipart.NewWriter(&body)
+	part, _ := w.CreateFormFile("cover", "cover.png")
+	part.Write(content)
+	w.Close()
+
+	req := httptest.NewRequest("POST", "/", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	if err := req.ParseMultipartForm(1 << 20); err != nil {
+		t.Fatal(err)
+	}
+	return req.MultipartForm.File["cover"][0]
+}
+
+// TestDeleteAudit memastikan penghapusan buku dicatat di dalam transaksi
+// yang sama beserta data sebelum dihapus dari baris yang dikunci, dan hapus
+// yang gagal tidak dicatat.
+func TestDeleteAudit(t *testing.T) {
+	old := book.Core{ID: 1, Judul: "One Piece", UserID: 1, Version: 2}
+	_, token := helper.GenerateJWT(1)
+	pToken := token.(*jwt.Token)
+	pToken.Valid = true
+
+	t.Run("dicatat", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		rec := mocks.NewRecorder(t)
+		type txKey struct{}
+		uw := mocks.NewUnitOfWork(t)
+		uw.On("Do", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context, uow.Repos) error) error {
+			return fn(context.WithValue(ctx, txKey{}, true), uow.Repos{Books: repo})
+		}).Once()
+		inTx := mock.MatchedBy(func(ctx context.Context) bool { return ctx.Value(txKey{}) != nil })
+		repo.On("Lock", inTx, uint(1), uint(1)).Return(old, nil).Once()
+		repo.On("Delete", inTx, uint(1), uint(1), uint(2)).Return(nil).Once()
+		rec.On("Record", inTx, audit.ActionDelete, audit.EntityBook, uint(1), old, nil).Once()
+
+		err := New(repo, uw, nil, nil, 0, rec).Delete(context.Background(), pToken, 1, 2)
+		assert.Nil(t, err)
+	})
+
+	t.Run("gagal tidak dicatat", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		rec := mocks.NewRecorder(t)
+		repo.On("Lock", mock.Anything, uint(1), uint(1)).Return(old, nil).Once()
+		repo.On("Delete", mock.Anything, uint(1), uint(1), uint(1)).Return(errors.New("precondition failed: buku sudah diubah")).Once()
+
+		err := New(repo, inTx(t, repo), nil, nil, 0, rec).Delete(context.Background(), pToken, 1, 1)
+		assert.ErrorContains(t, err, "precondition")
+	})
+
+	t.Run("buku user lain tidak dicatat", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		rec := mocks.NewRecorder(t)
+		repo.On("Lock", mock.Anything, uint(1), uint(3)).Return(book.Core{}, errors.New("forbidden: tidak memiliki akses")).Once()
+
+		err := New(repo, inTx(t, repo), nil, nil, 0, rec).Delete(context.Background(), pToken, 3, 0)
+		assert.ErrorContains(t, err, "forbidden")
+	})
+}
+
+func pngImage(w, h int) []byte {
+	buf := bytes.Buffer{}
+	png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h)))
+	return buf.Bytes()
+}
+
+func TestTrash(t *testing.T) {
+	_, token := helper.GenerateJWT(1)
+	pToken := token.(*jwt.Token)
+	pToken.Valid = true
+
+	t.Run("sukses lihat sampah", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		deleted := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		repo.On("Trash", mock.Anything, uint(1), 1, helper.DefaultLimit).Return([]book.Core{{ID: 1, DeletedAt: deleted}}, int64(1), nil).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 24*time.Hour, nil)
+		res, total, err := srv.Trash(context.Background(), pToken, 0, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, deleted.Add(24*time.Hour), res[0].PurgeAt)
+	})
+
+	t.Run("sukses pulihkan buku", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		repo.On("Restore", mock.Anything, uint(1), uint(1)).Return(book.Core{ID: 1, Version: 3}, nil).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		res, err := srv.Restore(context.Background(), pToken, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, uint(3), res.Version)
+	})
+
+	t.Run("pulihkan buku user lain", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		repo.On("Restore", mock.Anything, uint(1), uint(2)).Return(book.Core{}, errors.New("forbidden: tidak memiliki akses")).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		_, err := srv.Restore(context.Background(), pToken, 2)
+		assert.ErrorContains(t, err, "forbidden")
+	})
+
+	t.Run("hapus permanen beserta cover", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		blobs := mocks.NewBlobStore(t)
+		repo.On("Purge", mock.Anything, uint(1), uint(1)).Return(book.Core{ID: 1, CoverKey: "books/1/cover.png", ThumbnailKey: "books/1/thumb.jpg"}, nil).Once()
+		blobs.On("Delete", mock.Anything, "books/1/cover.png").Return(nil).Once()
+		blobs.On("Delete", mock.Anything, "books/1/thumb.jpg").Return(nil).Once()
+
+		srv := New(repo, inTx(t, repo), blobs, nil, 0, nil)
+		assert.Nil(t, srv.Purge(context.Background(), pToken, 1))
+	})
+
+	t.Run("hapus permanen buku yang tidak di sampah", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		repo.On("Purge", mock.Anything, uint(1), uint(2)).Return(book.Core{}, errors.New("data not found")).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		assert.ErrorContains(t, srv.Purge(context.Background(), pToken, 2), "not found")
+	})
+
+	t.Run("purge otomatis setelah masa simpan", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		blobs := mocks.NewBlobStore(t)
+		before := mock.MatchedBy(func(before time.Time) bool {
+			return time.Since(before) >= 24*time.Hour
+		})
+		repo.On("PurgeTrash", mock.Anything, before).Return([]book.Core{{ID: 1, CoverKey: "books/1/cover.png"}, {ID: 2}}, nil).Once()
+		blobs.On("Delete", mock.Anything, "books/1/cover.png").Return(storage.ErrNotFound).Once()
+
+		srv := New(repo, inTx(t, repo), blobs, nil, 24*time.Hour, nil)
+		n, err := srv.PurgeTrash(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, 2, n)
+	})
+}
+
+func TestHistory(t *testing.T) {
+	_, token := helper.GenerateJWT(1)
+	pToken := token.(*jwt.Token)
+	pToken.Valid = true
+	rev := book.Revision{
+		Rev: 1, BookID: 1, UserID: 1,
+		Before: book.Snapshot{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eiichiro Oda", Penerbit: "Shueisha"},
+		After:  book.Snapshot{Judul: "Naruto", TahunTerbit: 1997, Penulis: "Eiichiro Oda", Genre: []string{"manga"}},
+	}
+
+	t.Run("sukses lihat riwayat", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		repo.On("History", mock.Anything, uint(1), uint(1), 1, helper.DefaultLimit).Return([]book.Revision{rev}, int64(1), nil).Once()
+
+		res, total, err := New(repo, inTx(t, repo), nil, nil, 0, nil).History(context.Background(), pToken, 1, 0, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, map[string]book.Change{
+			"judul":    {Before: "One Piece", After: "Naruto"},
+			"penerbit": {Before: "Shueisha"},
+			"genre":    {After: []interface{}{"manga"}},
+		}, res[0].Changes)
+	})
+
+	t.Run("riwayat buku user lain", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		repo.On("History", mock.Anything, uint(1), uint(2), 1, helper.DefaultLimit).Return(nil, int64(0), errors.New("forbidden: tidak memiliki akses")).Once()
+
+		_, _, err := New(repo, inTx(t, repo), nil, nil, 0, nil).History(context.Background(), pToken, 2, 0, 0)
+		assert.ErrorContains(t, err, "forbidden")
+	})
+
+	t.Run("sukses revert", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		repo.On("Revision", mock.Anything, uint(1), uint(1), uint(1)).Return(rev, nil).Once()
+		expected := book.Core{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eiichiro Oda", Penerbit: "Shueisha", Genre: []string{}, Version: 3}
+		repo.On("Update", mock.Anything, uint(1), uint(1), expected, revertFields).Return(book.Core{ID: 1, Judul: "One Piece", Version: 4}, nil).Once()
+
+		res, err := New(repo, inTx(t, repo), nil, nil, 0, nil).Revert(context.Background(), pToken, 1, 1, 3)
+		assert.Nil(t, err)
+		assert.Equal(t, uint(4), res.Version)
+	})
+
+	t.Run("revisi tidak ada", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		repo.On("Revision", mock.Anything, uint(1), uint(1), uint(9)).Return(book.Revision{}, errors.New("revision not found")).Once()
+
+		_, err := New(repo, inTx(t, repo), nil, nil, 0, nil).Revert(context.Background(), pToken, 1, 9, 0)
+		assert.ErrorContains(t, err, "revision not found")
+	})
+
+	t.Run("revert dengan versi lama", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		repo.On("Revision", mock.Anything, uint(1), uint(1), uint(1)).Return(rev, nil).Once()
+		repo.On("Update", mock.Anything, uint(1), uint(1), mock.Anything, revertFields).Return(book.Core{}, errors.New("precondition failed: buku sudah diubah (versi 4)")).Once()
+
+		_, err := New(repo, inTx(t, repo), nil, nil, 0, nil).Revert(context.Background(), pToken, 1, 1, 3)
+		assert.ErrorContains(t, err, "precondition")
+	})
+}
+
+func TestUploadCover(t *testing.T) {
+	repo := mocks.NewBookData(t)
+	blobs := mocks.NewBlobStore(t)
+
+	t.Run("sukses upload cover", func(t *testing.T) {
+		oldBook := book.Core{ID: 1, Judul: "One Piece", UserID: 1, CoverKey: "books/1/cover-lama.png", ThumbnailKey: "books/1/thumb-lama.jpg"}
+		var newCover string
+		blobs.On("Put", mock.Anything, mock.MatchedBy(func(key string) bool { return len(key) > 0 }), mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+		repo.On("UpdateCover", mock.Anything, uint(1), uint(1), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			newCover = args.String(3)
+		}).Return(oldBook.CoverKey, oldBook.ThumbnailKey, nil).Once()
+		// respons dibaca ulang setelah cover disimpan
+		repo.On("Detail", mock.Anything, uint(1)).Return(func(ctx context.Context, id uint) book.Core {
+			return book.Core{ID: 1, Judul: "One Piece", UserID: 1, Genre: []string{"manga"}, CoverKey: newCover, ThumbnailKey: "books/1/thumb-baru.jpg", Version: 2}
+		}, nil).Once()
+		blobs.On("Delete", mock.Anything, "books/1/cover-lama.png").Return(nil).Once()
+		blobs.On("Delete", mock.Anything, "books/1/thumb-lama.jpg").Return(nil).Once()
+		blobs.On("URL", mock.Anything).Return("http://cdn/cover").Twice()
+
+		srv := New(repo, inTx(t, repo), blobs, nil, 0, nil)
+		_, token := helper.GenerateJWT(1)
+		pToken := token.(*jwt.Token)
+		pToken.Valid = true
+		res, err := srv.UploadCover(context.Background(), pToken, 1, coverFile(t, pngImage(600, 900)))
+		assert.Nil(t, err)
+		assert.Equal(t, uint(1), res.ID)
+		assert.NotEqual(t, oldBook.CoverKey, res.CoverKey)
+		assert.Equal(t, newCover, res.CoverKey)
+		assert.Equal(t, []string{"manga"}, res.Genre)
+		assert.Equal(t, uint(2), res.Version)
+		assert.Equal(t, "http://cdn/cover", res.CoverURL)
+		assert.Equal(t, "http://cdn/cover", res.ThumbnailURL)
+		repo.AssertExpectations(t)
+		blobs.AssertExpectations(t)
+	})
+
+	t.Run("bukan gambar", func(t *testing.T) {
+		srv := New(repo, inTx(t, repo), blobs, nil, 0, nil)
+		_, token := helper.GenerateJWT(1)
+		pToken := token.(*jwt.Token)
+		pToken.Valid = true
+		_, err := srv.UploadCover(context.Background(), pToken, 1, coverFile(t, []byte("bukan gambar")))
+		assert.NotNil(t, err)
+		assert.ErrorContains(t, err, "format")
+	})
+
+	t.Run("dimensi terlalu besar ditolak sebelum didecode", func(t *testing.T) {
+		srv := New(repo, inTx(t, repo), blobs, nil, 0, nil)
+		_, token := helper.GenerateJWT(1)
+		pToken := token.(*jwt.Token)
+		pToken.Valid = true
+		_, err := srv.UploadCover(context.Background(), pToken, 1, coverFile(t, pngImage(maxCoverDimension+1, 10)))
+		assert.ErrorContains(t, err, "dimensi")
+	})
+
+	t.Run("tanpa blob store", func(t *testing.T) {
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		_, token := helper.GenerateJWT(1)
+		pToken := token.(*jwt.Token)
+		pToken.Valid = true
+		_, err := srv.UploadCover(context.Background(), pToken, 1, coverFile(t, pngImage(10, 10)))
+		assert.ErrorContains(t, err, "server")
+	})
+
+	t.Run("buku tidak ditemukan", func(t *testing.T) {
+		blobs.On("Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+		repo.On("UpdateCover", mock.Anything, uint(1), uint(2), mock.Anything, mock.Anything).Return("", "", errors.New("record not found")).Once()
+		blobs.On("Delete", mock.Anything, mock.Anything).Return(nil).Twice()
+
+		srv := New(repo, inTx(t, repo), blobs, nil, 0, nil)
+		_, token := helper.GenerateJWT(1)
+		pToken := token.(*jwt.Token)
+		pToken.Valid = true
+		_, err := srv.UploadCover(context.Background(), pToken, 2, coverFile(t, pngImage(10, 10)))
+		assert.NotNil(t, err)
+		assert.ErrorContains(t, err, "not found")
+		repo.AssertExpectations(t)
+		blobs.AssertExpectations(t)
+	})
+
+	t.Run("jwt tidak valid", func(t *testing.T) {
+		srv := New(repo, inTx(t, repo), blobs, nil, 0, nil)
+		_, token := helper.GenerateJWT(1)
+		_, err := srv.UploadCover(context.Background(), token, 1, coverFile(t, pngImage(10, 10)))
+		assert.NotNil(t, err)
+		assert.ErrorContains(t, err, "not found")
+	})
+}
+
+func TestList(t *testing.T) {
+	repo := mocks.NewBookData(t)
+
+	t.Run("berhasil menampilkan buku", func(t *testing.T) {
+		filter := book.Filter{ISBN: "0-306-40615-2", Genre: " Novel "}
+		expected := book.Filter{ISBN: "0306406152", Genre: "novel", Page: 1, Limit: helper.DefaultLimit}
+		resBook := []book.Core{{ID: 1, Judul: "One Piece", CoverKey: "books/1/cover.jpg"}}
+		repo.On("List", mock.Anything, expected).Return(resBook, int64(1), nil).Once()
+
+		blobs := mocks.NewBlobStore(t)
+		blobs.On("URL", "books/1/cover.jpg").Return("/files/books/1/cover.jpg").Once()
+
+		srv := New(repo, inTx(t, repo), blobs, nil, 0, nil)
+		res, total, err := srv.List(context.Background(), filter)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, "/files/books/1/cover.jpg", res[0].CoverURL)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("limit dibatasi", func(t *testing.T) {
+		expected := book.Filter{Page: 2, Limit: helper.MaxLimit}
+		repo.On("List", mock.Anything, expected).Return([]book.Core{}, int64(0), nil).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		_, _, err := srv.List(context.Background(), book.Filter{Page: 2, Limit: 1000})
+		assert.Nil(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rentang halaman tidak valid", func(t *testing.T) {
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		_, _, err := srv.List(context.Background(), book.Filter{MinHalaman: 300, MaxHalaman: 100})
+		assert.ErrorContains(t, err, "format")
+	})
+
+	t.Run("masalah di server", func(t *testing.T) {
+		repo.On("List", mock.Anything, mock.Anything).Return(nil, int64(0), errors.New("query error")).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		res, _, err := srv.List(context.Background(), book.Filter{})
+		assert.Nil(t, res)
+		assert.ErrorContains(t, err, "server")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestDetail(t *testing.T) {
+	repo := mocks.NewBookData(t)
+
+	t.Run("berhasil melihat detail", func(t *testing.T) {
+		resBook := book.Core{ID: 1, Judul: "One Piece", Genre: []string{"manga"}}
+		repo.On("Detail", mock.Anything, uint(1)).Return(resBook, nil).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		res, err := srv.Detail(context.Background(), 1)
+		assert.Nil(t, err)
+		assert.Equal(t, resBook, res)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("buku tidak ditemukan", func(t *testing.T) {
+		repo.On("Detail", mock.Anything, uint(2)).Return(book.Core{}, errors.New("data not found")).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		_, err := srv.Detail(context.Background(), 2)
+		assert.ErrorContains(t, err, "not found")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestImport(t *testing.T) {
+	_, token := helper.GenerateJWT(1)
+	pToken := token.(*jwt.Token)
+	pToken.Valid = true
+
+	valid := func(judul, isbn string) book.Core {
+		return book.Core{Judul: judul, TahunTerbit: 2005, Penulis: "Andrea Hirata", ISBN: isbn}
+	}
+	job := book.ImportJob{ID: 7, UserID: 1, Status: book.ImportPending, Total: 5}
+
+	t.Run("laporan per baris", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		rows := []book.ImportRow{
+			{Line: 2, Book: valid("Laskar Pelangi", "978-979-3062-79-2")},
+			{Line: 3, Book: valid("Sang Pemimpi", "9789793062921")},
+			{Line: 4, Book: valid("Laskar Pelangi", "9789793062792")},
+			{Line: 5, Book: book.Core{Judul: "Tanpa Penulis", TahunTerbit: 2005, ISBN: "123"}},
+			{Line: 6, Err: "format tahun_terbit tidak sesuai"},
+		}
+		repo.On("CreateImportJob", mock.Anything, book.ImportJob{UserID: 1, Status: book.ImportPending, Total: 5}).Return(job, nil).Once()
+		repo.On("ExistingISBN", mock.Anything, uint(1), []string{"9789793062792", "9789793062921"}).Return([]string{"9789793062921"}, nil).Once()
+		repo.On("AddBatch", mock.Anything, uint(1), []book.Core{valid("Laskar Pelangi", "9789793062792")}).
+			Return([]book.Core{{ID: 10}}, nil).Once()
+		repo.On("UpdateImportJob", mock.Anything, mock.Anything).Return(nil)
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		res, err := srv.Import(context.Background(), pToken, rows)
+		assert.Nil(t, err)
+		assert.Equal(t, book.ImportDone, res.Status)
+		assert.NotNil(t, res.FinishedAt)
+		assert.Equal(t, 1, res.Created)
+		assert.Equal(t, 2, res.Skipped)
+		assert.Equal(t, 2, res.Failed)
+		assert.Equal(t, book.ImportResult{Line: 2, Status: book.ImportCreated, BookID: 10}, res.Results[0])
+		assert.Equal(t, book.ImportResult{Line: 3, Status: book.ImportSkipped, Reason: "ISBN sudah ada di koleksi"}, res.Results[1])
+		assert.Equal(t, book.ImportResult{Line: 4, Status: book.ImportSkipped, Reason: "ISBN sama dengan baris 2"}, res.Results[2])
+		assert.Equal(t, book.ImportFailed, res.Results[3].Status)
+		assert.Equal(t, book.ImportResult{Line: 6, Status: book.ImportFailed, Reason: "format tahun_terbit tidak sesuai"}, res.Results[4])
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("batch gagal disimpan per baris", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		rows := []book.ImportRow{
+			{Line: 1, Book: valid("Laskar Pelangi", "")},
+			{Line: 2, Book: book.Core{Judul: "Good Omens", TahunTerbit: 1990, PenulisID: []uint{99}}},
+		}
+		repo.On("CreateImportJob", mock.Anything, mock.Anything).Return(job, nil).Once()
+		repo.On("AddBatch", mock.Anything, uint(1), mock.Anything).Return(nil, errors.New("author 99 not found")).Once()
+		repo.On("Add", mock.Anything, uint(1), rows[0].Book).Return(book.Core{ID: 11}, nil).Once()
+		repo.On("Add", mock.Anything, uint(1), rows[1].Book).Return(book.Core{}, errors.New("author 99 not found")).Once()
+		repo.On("UpdateImportJob", mock.Anything, mock.Anything).Return(nil)
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		res, err := srv.Import(context.Background(), pToken, rows)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, res.Created)
+		assert.Equal(t, 1, res.Failed)
+		assert.Equal(t, "penulis not found", res.Results[1].Reason)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("import besar diproses di background", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		rows := []book.ImportRow{}
+		for i := 0; i <= syncImportLimit; i++ {
+			rows = append(rows, book.ImportRow{Line: i + 1, Book: valid(fmt.Sprintf("Buku %d", i), "")})
+		}
+		done := make(chan struct{})
+		repo.On("CreateImportJob", mock.Anything, mock.Anything).Return(job, nil).Once()
+		repo.On("AddBatch", mock.Anything, uint(1), mock.Anything).Return(func(_ context.Context, _ uint, b []book.Core) []book.Core {
+			return b
+		}, nil).Twice()
+		repo.On("UpdateImportJob", mock.Anything, mock.MatchedBy(func(j book.ImportJob) bool {
+			return j.Status == book.ImportDone
+		})).Run(func(mock.Arguments) { close(done) }).Return(nil).Once()
+		repo.On("UpdateImportJob", mock.Anything, mock.Anything).Return(nil)
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		res, err := srv.Import(context.Background(), pToken, rows)
+		assert.Nil(t, err)
+		assert.Equal(t, book.ImportPending, res.Status)
+		<-done
+	})
+
+	t.Run("panic menandai job failed", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		rows := []book.ImportRow{}
+		for i := 0; i <= syncImportLimit; i++ {
+			rows = append(rows, book.ImportRow{Line: i + 1, Book: valid(fmt.Sprintf("Buku %d", i), "")})
+		}
+		repo.On("CreateImportJob", mock.Anything, mock.Anything).Return(job, nil).Once()
+		repo.On("AddBatch", mock.Anything, uint(1), mock.Anything).Run(func(mock.Arguments) { panic("boom") }).Once()
+		repo.On("UpdateImportJob", mock.Anything, mock.MatchedBy(func(j book.ImportJob) bool {
+			return j.Status == book.ImportRunning
+		})).Return(nil).Once()
+		repo.On("UpdateImportJob", mock.Anything, mock.MatchedBy(func(j book.ImportJob) bool {
+			return j.Status == book.ImportFailed && j.FinishedAt != nil
+		})).Return(nil).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		_, err := srv.Import(context.Background(), pToken, rows)
+		assert.Nil(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.Nil(t, srv.WaitImports(ctx))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("dilengkapi dari isbn", func(t *testing.T) {
+		repo := mocks.NewBookData(t)
+		meta := mocks.NewMetadataProvider(t)
+		found := book.Core{Judul: "Laskar Pelangi", Penulis: "Andrea Hirata", TahunTerbit: 2005}
+		meta.On("Lookup", mock.Anything, "9789793062792").Return(found, nil).Once()
+		expected := book.Core{Judul: "Laskar Pelangi", Penulis: "Andrea Hirata", TahunTerbit: 2005, ISBN: "9789793062792"}
+		repo.On("CreateImportJob", mock.Anything, mock.Anything).Return(job, nil).Once()
+		repo.On("ExistingISBN", mock.Anything, uint(1), []string{"9789793062792"}).Return(nil, nil).Once()
+		repo.On("AddBatch", mock.Anything, uint(1), []book.Core{expected}).Return([]book.Core{{ID: 12}}, nil).Once()
+		repo.On("UpdateImportJob", mock.Anything, mock.Anything).Return(nil)
+
+		srv := New(repo, inTx(t, repo), nil, meta, 0, nil)
+		res, err := srv.Import(context.Background(), pToken, []book.ImportRow{{Line: 2, Book: book.Core{ISBN: "978-979-3062-79-2"}}})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, res.Created)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("file kosong", func(t *testing.T) {
+		srv := New(mocks.NewBookData(t), inTx(t, nil), nil, nil, 0, nil)
+		_, err := srv.Import(context.Background(), pToken, nil)
+		assert.ErrorContains(t, err, "format")
+	})
+}
+
+func TestImportStatus(t *testing.T) {
+	repo := mocks.NewBookData(t)
+	_, token := helper.GenerateJWT(1)
+	pToken := token.(*jwt.Token)
+	pToken.Valid = true
+
+	t.Run("berhasil melihat status", func(t *testing.T) {
+		job := book.ImportJob{ID: 7, UserID: 1, Status: book.ImportRunning, Total: 500, Created: 100}
+		repo.On("ImportJob", mock.Anything, uint(1), uint(7)).Return(job, nil).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		res, err := srv.ImportStatus(context.Background(), pToken, 7)
+		assert.Nil(t, err)
+		assert.Equal(t, job, res)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("job milik user lain", func(t *testing.T) {
+		repo.On("ImportJob", mock.Anything, uint(1), uint(8)).Return(book.ImportJob{}, errors.New("data not found")).Once()
+
+		srv := New(repo, inTx(t, repo), nil, nil, 0, nil)
+		_, err := srv.ImportStatus(context.Background(), pToken, 8)
+		assert.ErrorContains(t, err, "not found")
+		repo.AssertExpectations(t)
+	})
 }
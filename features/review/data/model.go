@@ -0,0 +1,44 @@
+package data
+
+import (
+	"api/features/review"
+	"time"
+)
+
+type Review struct {
+	ID        uint `gorm:"primaryKey"`
+	BooksID   uint `gorm:"uniqueIndex:idx_review_book_user"`
+	UserID    uint `gorm:"uniqueIndex:idx_review_book_user;index"`
+	Rating    int
+	Ulasan    string `gorm:"type:text"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ReviewWithNama dipakai saat membaca ulasan beserta nama pengulasnya.
+type ReviewWithNama struct {
+	Review
+	Nama string
+}
+
+func ToCore(data Review) review.Core {
+	return review.Core{
+		ID:        data.ID,
+		BookID:    data.BooksID,
+		UserID:    data.UserID,
+		Rating:    data.Rating,
+		Ulasan:    data.Ulasan,
+		CreatedAt: data.CreatedAt,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
+
+func CoreToData(data review.Core) Review {
+	return Review{
+		ID:      data.ID,
+		BooksID: data.BookID,
+		UserID:  data.UserID,
+		Rating:  data.Rating,
+		Ulasan:  data.Ulasan,
+	}
+}
@@ -0,0 +1,201 @@
+package data
+
+import (
+	book "api/features/book/data"
+	"api/features/review"
+	"api/logger"
+	"api/uow"
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reviewData struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) review.ReviewData {
+	return &reviewData{
+		db: db,
+	}
+}
+
+func (rd *reviewData) Add(ctx context.Context, userID uint, bookID uint, newReview review.Core) (review.Core, error) {
+	cnv := CoreToData(newReview)
+	cnv.ID = 0
+	cnv.BooksID = bookID
+	cnv.UserID = userID
+
+	err := uow.DB(ctx, rd.db).Transaction(func(tx *gorm.DB) error {
+		// buku milik akun yang dinonaktifkan tidak bisa diulas
+		if err := lockBook(tx.Scopes(book.ActiveOwner), bookID); err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&Review{}).Where("books_id = ? AND user_id = ?", bookID, userID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New("conflict: buku ini sudah kamu ulas")
+		}
+
+		if err := tx.Create(&cnv).Error; err != nil {
+			if strings.Contains(err.Error(), "Duplicate") {
+				return errors.New("conflict: buku ini sudah kamu ulas")
+			}
+			return err
+		}
+
+		return refreshRating(tx, bookID)
+	})
+	if err != nil {
+		logger.Error(ctx, "add review query error", logger.Fields{"error": err, "book_id": bookID})
+		return review.Core{}, err
+	}
+
+	return rd.detail(ctx, cnv.ID)
+}
+
+func (rd *reviewData) List(ctx context.Context, bookID uint, page, limit int) ([]review.Core, int64, error) {
+	var exists int64
+	if err := uow.DB(ctx, rd.db).Model(&book.Books{}).Scopes(book.ActiveOwner).Where("id = ?", bookID).Count(&exists).Error; err != nil {
+		logger.Error(ctx, "get book error", logger.Fields{"error": err, "book_id": bookID})
+		return nil, 0, err
+	}
+	if exists == 0 {
+		return nil, 0, errors.New("book not found")
+	}
+
+	qry := uow.DB(ctx, rd.db).Model(&Review{}).Where("books_id = ?", bookID)
+
+	var total int64
+	if err := qry.Count(&total).Error; err != nil {
+		logger.Error(ctx, "count review query error", logger.Fields{"error": err, "book_id": bookID})
+		return nil, 0, err
+	}
+
+	rows := []ReviewWithNama{}
+	err := qry.Select("reviews.*, users.nama").
+		Joins("LEFT JOIN users ON users.id = reviews.user_id").
+		Order("reviews.created_at DESC, reviews.id DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		logger.Error(ctx, "list review query error", logger.Fields{"error": err, "book_id": bookID})
+		return nil, 0, err
+	}
+
+	res := []review.Core{}
+	for _, r := range rows {
+		c := ToCore(r.Review)
+		c.Nama = r.Nama
+		res = append(res, c)
+	}
+
+	return res, total, nil
+}
+
+func (rd *reviewData) Update(ctx context.Context, userID uint, reviewID uint, updatedData review.Core) (review.Core, error) {
+	cnv := CoreToData(updatedData)
+	cnv.ID, cnv.BooksID, cnv.UserID = 0, 0, 0
+
+	err := uow.DB(ctx, rd.db).Transaction(func(tx *gorm.DB) error {
+		old, err := ownReview(tx, userID, reviewID)
+		if err != nil {
+			return err
+		}
+		if err := lockBook(tx, old.BooksID); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&Review{}).Where("id = ?", reviewID).Updates(&cnv).Error; err != nil {
+			return err
+		}
+		if cnv.Rating == 0 || cnv.Rating == old.Rating {
+			return nil
+		}
+		return refreshRating(tx, old.BooksID)
+	})
+	if err != nil {
+		logger.Error(ctx, "update review query error", logger.Fields{"error": err, "review_id": reviewID})
+		return review.Core{}, err
+	}
+
+	return rd.detail(ctx, reviewID)
+}
+
+func (rd *reviewData) Delete(ctx context.Context, userID uint, reviewID uint) error {
+	err := uow.DB(ctx, rd.db).Transaction(func(tx *gorm.DB) error {
+		old, err := ownReview(tx, userID, reviewID)
+		if err != nil {
+			return err
+		}
+		if err := lockBook(tx, old.BooksID); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&Review{}, reviewID).Error; err != nil {
+			return err
+		}
+		return refreshRating(tx, old.BooksID)
+	})
+	if err != nil {
+		logger.Error(ctx, "delete review query error", logger.Fields{"error": err, "review_id": reviewID})
+		return err
+	}
+
+	return nil
+}
+
+func (rd *reviewData) detail(ctx context.Context, reviewID uint) (review.Core, error) {
+	row := ReviewWithNama{}
+	err := uow.DB(ctx, rd.db).Model(&Review{}).
+		Select("reviews.*, users.nama").
+		Joins("LEFT JOIN users ON users.id = reviews.user_id").
+		Where("reviews.id = ?", reviewID).
+		Take(&row).Error
+	if err != nil {
+		logger.Error(ctx, "get review error", logger.Fields{"error": err, "review_id": reviewID})
+		return review.Core{}, err
+	}
+
+	res := ToCore(row.Review)
+	res.Nama = row.Nama
+	return res, nil
+}
+
+// ownReview mengambil ulasan milik userID, ulasan user lain dianggap tidak
+// ada.
+func ownReview(tx *gorm.DB, userID, reviewID uint) (Review, error) {
+	row := Review{}
+	err := tx.Where("id = ? AND user_id = ?", reviewID, userID).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Review{}, errors.New("review not found")
+	}
+	return row, err
+}
+
+// lockBook memastikan buku ada dan mengunci barisnya sampai transaksi
+// selesai, sehingga ulasan yang masuk bersamaan tidak saling menimpa rating.
+func lockBook(tx *gorm.DB, bookID uint) error {
+	row := book.Books{}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", bookID).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("book not found")
+	}
+	return err
+}
+
+// refreshRating menghitung ulang rating rata-rata dan jumlah ulasan buku.
+// UpdateColumns dipakai agar updated_at buku tidak ikut berubah, version juga
+// tidak dinaikkan agar ulasan orang lain tidak membuat If-Match pemilik gagal.
+func refreshRating(tx *gorm.DB, bookID uint) error {
+	return tx.Model(&book.Books{}).Where("id = ?", bookID).UpdateColumns(map[string]interface{}{
+		"rating_rata":   tx.Model(&Review{}).Select("COALESCE(AVG(rating), 0)").Where("books_id = ?", bookID),
+		"jumlah_ulasan": tx.Model(&Review{}).Select("COUNT(*)").Where("books_id = ?", bookID),
+	}).Error
+}
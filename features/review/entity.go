@@ -0,0 +1,46 @@
+package review
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Core adalah ulasan satu user untuk satu buku, setiap user hanya boleh
+// memiliki satu ulasan per buku.
+type Core struct {
+	ID     uint
+	BookID uint
+	UserID uint
+	// Nama adalah nama user pengulas, hanya dibaca.
+	Nama      string
+	Rating    int    `validate:"required,min=1,max=5"`
+	Ulasan    string `validate:"max=5000"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ReviewHandler interface {
+	Add() echo.HandlerFunc
+	List() echo.HandlerFunc
+	Update() echo.HandlerFunc
+	Delete() echo.HandlerFunc
+}
+
+type ReviewService interface {
+	Add(ctx context.Context, token interface{}, bookID uint, newReview Core) (Core, error)
+	List(ctx context.Context, bookID uint, page, limit int) ([]Core, int64, error)
+	Update(ctx context.Context, token interface{}, reviewID uint, updatedData Core) (Core, error)
+	Delete(ctx context.Context, token interface{}, reviewID uint) error
+}
+
+// ReviewData menyimpan ulasan sekaligus menghitung ulang rating rata-rata
+// dan jumlah ulasan pada buku dalam transaksi yang sama.
+type ReviewData interface {
+	Add(ctx context.Context, userID uint, bookID uint, newReview Core) (Core, error)
+	List(ctx context.Context, bookID uint, page, limit int) ([]Core, int64, error)
+	// Update dan Delete hanya mengubah ulasan milik userID.
+	Update(ctx context.Context, userID uint, reviewID uint, updatedData Core) (Core, error)
+	Delete(ctx context.Context, userID uint, reviewID uint) error
+}
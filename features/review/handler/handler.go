@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"api/features/review"
+	"api/helper"
+	"api/logger"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type reviewHandle struct {
+	srv review.ReviewService
+}
+
+func New(rs review.ReviewService) review.ReviewHandler {
+	return &reviewHandle{
+		srv: rs,
+	}
+}
+
+func (rh *reviewHandle) Add() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		bookID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		input := AddReviewRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, err := rh.srv.Add(c.Request().Context(), c.Get("user"), uint(bookID), *ToCore(input))
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintSuccessReponse(http.StatusCreated, "sukses menambahkan ulasan", res))
+	}
+}
+
+func (rh *reviewHandle) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		bookID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		input := ListReviewRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, total, err := rh.srv.List(c.Request().Context(), uint(bookID), input.Page, input.Limit)
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintListResponse(http.StatusOK, "sukses menampilkan ulasan buku", res, helper.NewPagination(input.Page, input.Limit, total)))
+	}
+}
+
+func (rh *reviewHandle) Update() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		reviewID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		input := UpdateReviewRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, err := rh.srv.Update(c.Request().Context(), c.Get("user"), uint(reviewID), *ToCore(input))
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintSuccessReponse(http.StatusOK, "berhasil update ulasan", res))
+	}
+}
+
+func (rh *reviewHandle) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		reviewID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		if err := rh.srv.Delete(c.Request().Context(), c.Get("user"), uint(reviewID)); err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(http.StatusAccepted, "berhasil delete ulasan")
+	}
+}
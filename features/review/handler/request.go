@@ -0,0 +1,38 @@
+package handler
+
+import "api/features/review"
+
+type AddReviewRequest struct {
+	Rating int    `json:"rating" validate:"required,min=1,max=5"`
+	Ulasan string `json:"ulasan" validate:"max=5000"`
+}
+
+// UpdateReviewRequest mengubah ulasan sendiri, field kosong tidak diubah.
+type UpdateReviewRequest struct {
+	Rating int    `json:"rating" validate:"omitempty,min=1,max=5"`
+	Ulasan string `json:"ulasan" validate:"max=5000"`
+}
+
+type ListReviewRequest struct {
+	Page  int `query:"page" validate:"gte=1"`
+	Limit int `query:"limit" validate:"gte=1,lte=100"`
+}
+
+func ToCore(data interface{}) *review.Core {
+	res := review.Core{}
+
+	switch data.(type) {
+	case AddReviewRequest:
+		cnv := data.(AddReviewRequest)
+		res.Rating = cnv.Rating
+		res.Ulasan = cnv.Ulasan
+	case UpdateReviewRequest:
+		cnv := data.(UpdateReviewRequest)
+		res.Rating = cnv.Rating
+		res.Ulasan = cnv.Ulasan
+	default:
+		return nil
+	}
+
+	return &res
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"api/features/review"
+	"api/helper"
+	"time"
+)
+
+type ReviewResponse struct {
+	ID        uint      `json:"id"`
+	BookID    uint      `json:"book_id"`
+	UserID    uint      `json:"user_id"`
+	Nama      string    `json:"nama"`
+	Rating    int       `json:"rating"`
+	Ulasan    string    `json:"ulasan"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func ToResponse(data review.Core) ReviewResponse {
+	return ReviewResponse{
+		ID:        data.ID,
+		BookID:    data.BookID,
+		UserID:    data.UserID,
+		Nama:      data.Nama,
+		Rating:    data.Rating,
+		Ulasan:    data.Ulasan,
+		CreatedAt: data.CreatedAt,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
+
+func PrintSuccessReponse(code int, message string, data interface{}) (int, interface{}) {
+	resp := map[string]interface{}{}
+	resp["data"] = ToResponse(data.(review.Core))
+
+	if message != "" {
+		resp["message"] = message
+	}
+
+	return code, resp
+}
+
+func PrintListResponse(code int, message string, data []review.Core, pagination helper.Pagination) (int, interface{}) {
+	res := []ReviewResponse{}
+	for _, v := range data {
+		res = append(res, ToResponse(v))
+	}
+
+	resp := map[string]interface{}{}
+	resp["data"] = res
+	resp["pagination"] = pagination
+
+	if message != "" {
+		resp["message"] = message
+	}
+
+	return code, resp
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"api/features/review"
+	"api/helper"
+	"api/tracing"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type reviewSrv struct {
+	data review.ReviewData
+	vld  *validator.Validate
+}
+
+func New(d review.ReviewData) review.ReviewService {
+	return &reviewSrv{
+		data: d,
+		vld:  validator.New(),
+	}
+}
+
+func (rs *reviewSrv) Add(ctx context.Context, token interface{}, bookID uint, newReview review.Core) (review.Core, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.Add")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return review.Core{}, errors.New("user not found")
+	}
+
+	newReview.Ulasan = strings.TrimSpace(newReview.Ulasan)
+	if err := rs.vld.Struct(newReview); err != nil {
+		return review.Core{}, errors.New("format input ulasan tidak sesuai, rating wajib diisi 1 sampai 5")
+	}
+
+	res, err := rs.data.Add(ctx, uint(userID), bookID, newReview)
+	if err != nil {
+		return review.Core{}, errorMsg(err)
+	}
+
+	return res, nil
+}
+
+func (rs *reviewSrv) List(ctx context.Context, bookID uint, page, limit int) ([]review.Core, int64, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.List")
+	defer span.End()
+
+	page, limit = helper.PageLimit(page, limit)
+	res, total, err := rs.data.List(ctx, bookID, page, limit)
+	if err != nil {
+		return nil, 0, errorMsg(err)
+	}
+
+	return res, total, nil
+}
+
+func (rs *reviewSrv) Update(ctx context.Context, token interface{}, reviewID uint, updatedData review.Core) (review.Core, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.Update")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return review.Core{}, errors.New("user not found")
+	}
+
+	// rating 0 dan ulasan kosong berarti tidak diubah
+	updatedData.Ulasan = strings.TrimSpace(updatedData.Ulasan)
+	if updatedData.Rating == 0 && updatedData.Ulasan == "" {
+		return review.Core{}, errors.New("format input ulasan tidak sesuai, isi rating atau ulasan")
+	}
+	if err := rs.vld.StructExcept(updatedData, "Rating"); err != nil || updatedData.Rating < 0 || updatedData.Rating > 5 {
+		return review.Core{}, errors.New("format input ulasan tidak sesuai, rating 1 sampai 5")
+	}
+
+	res, err := rs.data.Update(ctx, uint(userID), reviewID, updatedData)
+	if err != nil {
+		return review.Core{}, errorMsg(err)
+	}
+
+	return res, nil
+}
+
+func (rs *reviewSrv) Delete(ctx context.Context, token interface{}, reviewID uint) error {
+	ctx, span := tracing.Start(ctx, "ReviewService.Delete")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return errors.New("user not found")
+	}
+
+	if err := rs.data.Delete(ctx, uint(userID), reviewID); err != nil {
+		return errorMsg(err)
+	}
+
+	return nil
+}
+
+func errorMsg(err error) error {
+	switch {
+	case strings.Contains(err.Error(), "book not found"):
+		return errors.New("book not found")
+	case strings.Contains(err.Error(), "not found"):
+		return errors.New("review not found")
+	case strings.Contains(err.Error(), "conflict"):
+		return err
+	default:
+		return errors.New("terjadi kesalahan pada server")
+	}
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"api/features/review"
+	"api/helper"
+	"api/mocks"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func validToken() interface{} {
+	_, token := helper.GenerateJWT(1)
+	pToken := token.(*jwt.Token)
+	pToken.Valid = true
+	return pToken
+}
+
+func TestAdd(t *testing.T) {
+	repo := mocks.NewReviewData(t)
+
+	t.Run("berhasil menambahkan ulasan", func(t *testing.T) {
+		expected := review.Core{Rating: 5, Ulasan: "Seru sekali"}
+		resData := review.Core{ID: 1, BookID: 3, UserID: 1, Nama: "alif", Rating: 5, Ulasan: "Seru sekali"}
+		repo.On("Add", mock.Anything, uint(1), uint(3), expected).Return(resData, nil).Once()
+
+		srv := New(repo)
+		res, err := srv.Add(context.Background(), validToken(), 3, review.Core{Rating: 5, Ulasan: "  Seru sekali "})
+		assert.Nil(t, err)
+		assert.Equal(t, resData, res)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rating di luar rentang", func(t *testing.T) {
+		srv := New(repo)
+		for _, rating := range []int{0, 6, -1} {
+			_, err := srv.Add(context.Background(), validToken(), 3, review.Core{Rating: rating})
+			assert.ErrorContains(t, err, "format")
+		}
+	})
+
+	t.Run("sudah pernah mengulas", func(t *testing.T) {
+		repo.On("Add", mock.Anything, uint(1), uint(3), mock.Anything).Return(review.Core{}, errors.New("conflict: buku ini sudah kamu ulas")).Once()
+
+		srv := New(repo)
+		_, err := srv.Add(context.Background(), validToken(), 3, review.Core{Rating: 4})
+		assert.ErrorContains(t, err, "conflict")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("buku tidak ditemukan", func(t *testing.T) {
+		repo.On("Add", mock.Anything, uint(1), uint(99), mock.Anything).Return(review.Core{}, errors.New("book not found")).Once()
+
+		srv := New(repo)
+		_, err := srv.Add(context.Background(), validToken(), 99, review.Core{Rating: 4})
+		assert.EqualError(t, err, "book not found")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("jwt tidak valid", func(t *testing.T) {
+		srv := New(repo)
+		_, token := helper.GenerateJWT(1)
+		_, err := srv.Add(context.Background(), token, 3, review.Core{Rating: 4})
+		assert.ErrorContains(t, err, "not found")
+	})
+}
+
+func TestList(t *testing.T) {
+	repo := mocks.NewReviewData(t)
+
+	t.Run("berhasil menampilkan ulasan", func(t *testing.T) {
+		resData := []review.Core{{ID: 2, BookID: 3, Rating: 4}, {ID: 1, BookID: 3, Rating: 5}}
+		repo.On("List", mock.Anything, uint(3), 1, helper.DefaultLimit).Return(resData, int64(2), nil).Once()
+
+		srv := New(repo)
+		res, total, err := srv.List(context.Background(), 3, 0, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Len(t, res, 2)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("buku tidak ditemukan", func(t *testing.T) {
+		repo.On("List", mock.Anything, uint(99), 1, 10).Return(nil, int64(0), errors.New("book not found")).Once()
+
+		srv := New(repo)
+		_, _, err := srv.List(context.Background(), 99, 1, 10)
+		assert.EqualError(t, err, "book not found")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("masalah di server", func(t *testing.T) {
+		repo.On("List", mock.Anything, uint(3), 1, 10).Return(nil, int64(0), errors.New("connection refused")).Once()
+
+		srv := New(repo)
+		_, _, err := srv.List(context.Background(), 3, 1, 10)
+		assert.ErrorContains(t, err, "server")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	repo := mocks.NewReviewData(t)
+
+	t.Run("berhasil mengubah rating", func(t *testing.T) {
+		resData := review.Core{ID: 1, BookID: 3, UserID: 1, Rating: 3, Ulasan: "Biasa saja"}
+		repo.On("Update", mock.Anything, uint(1), uint(1), review.Core{Rating: 3}).Return(resData, nil).Once()
+
+		srv := New(repo)
+		res, err := srv.Update(context.Background(), validToken(), 1, review.Core{Rating: 3})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, res.Rating)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("tidak ada yang diubah", func(t *testing.T) {
+		srv := New(repo)
+		_, err := srv.Update(context.Background(), validToken(), 1, review.Core{Ulasan: "  "})
+		assert.ErrorContains(t, err, "format")
+	})
+
+	t.Run("rating di luar rentang", func(t *testing.T) {
+		srv := New(repo)
+		_, err := srv.Update(context.Background(), validToken(), 1, review.Core{Rating: 6})
+		assert.ErrorContains(t, err, "format")
+	})
+
+	t.Run("bukan ulasan sendiri", func(t *testing.T) {
+		repo.On("Update", mock.Anything, uint(1), uint(7), mock.Anything).Return(review.Core{}, errors.New("review not found")).Once()
+
+		srv := New(repo)
+		_, err := srv.Update(context.Background(), validToken(), 7, review.Core{Rating: 1})
+		assert.EqualError(t, err, "review not found")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	repo := mocks.NewReviewData(t)
+
+	t.Run("berhasil menghapus ulasan", func(t *testing.T) {
+		repo.On("Delete", mock.Anything, uint(1), uint(1)).Return(nil).Once()
+
+		srv := New(repo)
+		assert.Nil(t, srv.Delete(context.Background(), validToken(), 1))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("bukan ulasan sendiri", func(t *testing.T) {
+		repo.On("Delete", mock.Anything, uint(1), uint(7)).Return(errors.New("review not found")).Once()
+
+		srv := New(repo)
+		assert.EqualError(t, srv.Delete(context.Background(), validToken(), 7), "review not found")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("jwt tidak valid", func(t *testing.T) {
+		srv := New(repo)
+		_, token := helper.GenerateJWT(1)
+		assert.ErrorContains(t, srv.Delete(context.Background(), token, 1), "not found")
+	})
+}
@@ -0,0 +1,88 @@
+package data
+
+import (
+	"api/features/shelf"
+	"time"
+)
+
+// Reading menyimpan status baca, satu baris per user per buku.
+type Reading struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"uniqueIndex:idx_reading_user_book"`
+	BooksID     uint   `gorm:"uniqueIndex:idx_reading_user_book;index"`
+	Status      string `gorm:"size:20;index"`
+	Halaman     int
+	MulaiBaca   *time.Time
+	SelesaiBaca *time.Time `gorm:"index"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Shelf struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"uniqueIndex:idx_shelf_user_nama"`
+	Nama      string `gorm:"size:50;uniqueIndex:idx_shelf_user_nama"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ShelfBook struct {
+	ShelfID   uint `gorm:"primaryKey;autoIncrement:false"`
+	BooksID   uint `gorm:"primaryKey;autoIncrement:false;index"`
+	CreatedAt time.Time
+}
+
+// ReadingWithBook dipakai saat membaca status baca beserta ringkasan buku.
+type ReadingWithBook struct {
+	Reading
+	Judul         string
+	Penulis       string
+	TahunTerbit   int
+	JumlahHalaman int
+}
+
+// ShelfWithCount dipakai saat membaca rak beserta jumlah bukunya.
+type ShelfWithCount struct {
+	Shelf
+	JumlahBuku int
+}
+
+func ToCore(data ReadingWithBook) shelf.Reading {
+	return shelf.Reading{
+		BookID:      data.BooksID,
+		UserID:      data.UserID,
+		Status:      data.Status,
+		Halaman:     data.Halaman,
+		MulaiBaca:   data.MulaiBaca,
+		SelesaiBaca: data.SelesaiBaca,
+		UpdatedAt:   data.UpdatedAt,
+		Book: shelf.Book{
+			ID:            data.BooksID,
+			Judul:         data.Judul,
+			Penulis:       data.Penulis,
+			TahunTerbit:   data.TahunTerbit,
+			JumlahHalaman: data.JumlahHalaman,
+		},
+	}
+}
+
+func CoreToData(data shelf.Reading) Reading {
+	return Reading{
+		UserID:      data.UserID,
+		BooksID:     data.BookID,
+		Status:      data.Status,
+		Halaman:     data.Halaman,
+		MulaiBaca:   data.MulaiBaca,
+		SelesaiBaca: data.SelesaiBaca,
+	}
+}
+
+func ShelfToCore(data ShelfWithCount) shelf.Shelf {
+	return shelf.Shelf{
+		ID:         data.ID,
+		UserID:     data.UserID,
+		Nama:       data.Nama,
+		JumlahBuku: data.JumlahBuku,
+		CreatedAt:  data.CreatedAt,
+	}
+}
@@ -0,0 +1,350 @@
+package data
+
+import (
+	book "api/features/book/data"
+	"api/features/shelf"
+	"api/logger"
+	"api/uow"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	readingColumns = "readings.*, books.judul, books.penulis, books.tahun_terbit, books.jumlah_halaman"
+	joinBooks      = "JOIN books ON books.id = readings.books_id AND books.deleted_at IS NULL"
+	// jumlahBuku menghitung buku aktif di rak untuk kolom jumlah_buku.
+	jumlahBuku = `(SELECT COUNT(*) FROM shelf_books
+	JOIN books ON books.id = shelf_books.books_id AND books.deleted_at IS NULL
+	WHERE shelf_books.shelf_id = shelves.id) AS jumlah_buku`
+)
+
+type shelfData struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) shelf.ShelfData {
+	return &shelfData{
+		db: db,
+	}
+}
+
+func (sd *shelfData) Reading(ctx context.Context, userID, bookID uint) (shelf.Reading, error) {
+	row := ReadingWithBook{}
+	err := uow.DB(ctx, sd.db).Model(&Reading{}).Select(readingColumns).Joins(joinBooks).
+		Where("readings.user_id = ? AND readings.books_id = ?", userID, bookID).
+		Take(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return shelf.Reading{}, errors.New("data not found")
+		}
+		logger.Error(ctx, "get reading error", logger.Fields{"error": err, "book_id": bookID})
+		return shelf.Reading{}, err
+	}
+
+	return ToCore(row), nil
+}
+
+func (sd *shelfData) SetReading(ctx context.Context, userID, bookID uint, reading shelf.Reading) (shelf.Reading, error) {
+	b, err := sd.book(ctx, bookID)
+	if err != nil {
+		return shelf.Reading{}, err
+	}
+
+	cnv := CoreToData(reading)
+	cnv.UserID = userID
+	cnv.BooksID = bookID
+	if b.JumlahHalaman > 0 {
+		if cnv.Halaman > b.JumlahHalaman {
+			return shelf.Reading{}, fmt.Errorf("format halaman melebihi jumlah halaman buku (%d)", b.JumlahHalaman)
+		}
+		if cnv.Status == shelf.StatusFinished && cnv.Halaman == 0 {
+			cnv.Halaman = b.JumlahHalaman
+		}
+	}
+
+	err = uow.DB(ctx, sd.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "books_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "halaman", "mulai_baca", "selesai_baca", "updated_at"}),
+	}).Create(&cnv).Error
+	if err != nil {
+		logger.Error(ctx, "set reading query error", logger.Fields{"error": err, "book_id": bookID})
+		return shelf.Reading{}, err
+	}
+
+	return sd.Reading(ctx, userID, bookID)
+}
+
+func (sd *shelfData) DeleteReading(ctx context.Context, userID, bookID uint) error {
+	qry := uow.DB(ctx, sd.db).Where("user_id = ? AND books_id = ?", userID, bookID).Delete(&Reading{})
+	if err := qry.Error; err != nil {
+		logger.Error(ctx, "delete reading query error", logger.Fields{"error": err, "book_id": bookID})
+		return err
+	}
+	if qry.RowsAffected == 0 {
+		return errors.New("data not found")
+	}
+	return nil
+}
+
+func (sd *shelfData) ListReading(ctx context.Context, userID uint, status string, page, limit int) ([]shelf.Reading, int64, error) {
+	qry := uow.DB(ctx, sd.db).Model(&Reading{}).Joins(joinBooks).Where("readings.user_id = ?", userID)
+	if status != "" {
+		qry = qry.Where("readings.status = ?", status)
+	}
+
+	var total int64
+	if err := qry.Count(&total).Error; err != nil {
+		logger.Error(ctx, "count reading query error", logger.Fields{"error": err})
+		return nil, 0, err
+	}
+
+	rows := []ReadingWithBook{}
+	err := qry.Select(readingColumns).Order("readings.updated_at DESC, readings.id DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		logger.Error(ctx, "list reading query error", logger.Fields{"error": err})
+		return nil, 0, err
+	}
+
+	res := []shelf.Reading{}
+	for _, r := range rows {
+		res = append(res, ToCore(r))
+	}
+	return res, total, nil
+}
+
+func (sd *shelfData) Stats(ctx context.Context, userID uint) (shelf.Stats, error) {
+	qry := uow.DB(ctx, sd.db).Model(&Reading{}).Joins(joinBooks).Where("readings.user_id = ?", userID)
+
+	counts := []struct {
+		Status  string
+		Jumlah  int
+		Halaman int
+	}{}
+	err := qry.Session(&gorm.Session{}).
+		Select("readings.status, COUNT(*) AS jumlah, COALESCE(SUM(readings.halaman), 0) AS halaman").
+		Group("readings.status").
+		Scan(&counts).Error
+	if err != nil {
+		logger.Error(ctx, "reading stats query error", logger.Fields{"error": err})
+		return shelf.Stats{}, err
+	}
+
+	res := shelf.Stats{PerTahun: []shelf.YearStat{}}
+	for _, c := range counts {
+		switch c.Status {
+		case shelf.StatusWant:
+			res.InginDibaca = c.Jumlah
+		case shelf.StatusReading:
+			res.SedangDibaca = c.Jumlah
+			res.HalamanDibaca += c.Halaman
+		case shelf.StatusFinished:
+			res.Selesai = c.Jumlah
+			res.HalamanDibaca += c.Halaman
+		}
+	}
+
+	// dikelompokkan per tahun di Go karena fungsi tahun berbeda di setiap
+	// database
+	finished := []struct {
+		SelesaiBaca time.Time
+		Halaman     int
+	}{}
+	err = qry.Session(&gorm.Session{}).
+		Select("readings.selesai_baca, readings.halaman").
+		Where("readings.status = ? AND readings.selesai_baca IS NOT NULL", shelf.StatusFinished).
+		Scan(&finished).Error
+	if err != nil {
+		logger.Error(ctx, "reading stats per year query error", logger.Fields{"error": err})
+		return shelf.Stats{}, err
+	}
+
+	perTahun := map[int]*shelf.YearStat{}
+	for _, f := range finished {
+		tahun := f.SelesaiBaca.Year()
+		if perTahun[tahun] == nil {
+			perTahun[tahun] = &shelf.YearStat{Tahun: tahun}
+		}
+		perTahun[tahun].JumlahBuku++
+		perTahun[tahun].JumlahHalaman += f.Halaman
+	}
+	for _, ys := range perTahun {
+		res.PerTahun = append(res.PerTahun, *ys)
+	}
+	sort.Slice(res.PerTahun, func(i, j int) bool { return res.PerTahun[i].Tahun > res.PerTahun[j].Tahun })
+
+	return res, nil
+}
+
+func (sd *shelfData) AddShelf(ctx context.Context, userID uint, newShelf shelf.Shelf) (shelf.Shelf, error) {
+	if err := sd.checkNama(ctx, userID, 0, newShelf.Nama); err != nil {
+		return shelf.Shelf{}, err
+	}
+
+	row := Shelf{UserID: userID, Nama: newShelf.Nama}
+	if err := uow.DB(ctx, sd.db).Create(&row).Error; err != nil {
+		logger.Error(ctx, "add shelf query error", logger.Fields{"error": err})
+		return shelf.Shelf{}, err
+	}
+
+	return ShelfToCore(ShelfWithCount{Shelf: row}), nil
+}
+
+func (sd *shelfData) ListShelf(ctx context.Context, userID uint) ([]shelf.Shelf, error) {
+	rows := []ShelfWithCount{}
+	err := uow.DB(ctx, sd.db).Model(&Shelf{}).Select("shelves.*, "+jumlahBuku).
+		Where("user_id = ?", userID).Order("nama").
+		Scan(&rows).Error
+	if err != nil {
+		logger.Error(ctx, "list shelf query error", logger.Fields{"error": err})
+		return nil, err
+	}
+
+	res := []shelf.Shelf{}
+	for _, r := range rows {
+		res = append(res, ShelfToCore(r))
+	}
+	return res, nil
+}
+
+func (sd *shelfData) UpdateShelf(ctx context.Context, userID, shelfID uint, updatedData shelf.Shelf) (shelf.Shelf, error) {
+	if _, err := sd.ownShelf(uow.DB(ctx, sd.db), userID, shelfID); err != nil {
+		return shelf.Shelf{}, err
+	}
+	if err := sd.checkNama(ctx, userID, shelfID, updatedData.Nama); err != nil {
+		return shelf.Shelf{}, err
+	}
+
+	err := uow.DB(ctx, sd.db).Model(&Shelf{}).Where("id = ?", shelfID).Update("nama", updatedData.Nama).Error
+	if err != nil {
+		logger.Error(ctx, "update shelf query error", logger.Fields{"error": err, "shelf_id": shelfID})
+		return shelf.Shelf{}, err
+	}
+
+	row := ShelfWithCount{}
+	err = uow.DB(ctx, sd.db).Model(&Shelf{}).Select("shelves.*, "+jumlahBuku).Where("id = ?", shelfID).Take(&row).Error
+	if err != nil {
+		logger.Error(ctx, "get shelf error", logger.Fields{"error": err, "shelf_id": shelfID})
+		return shelf.Shelf{}, err
+	}
+	return ShelfToCore(row), nil
+}
+
+func (sd *shelfData) DeleteShelf(ctx context.Context, userID, shelfID uint) error {
+	err := uow.DB(ctx, sd.db).Transaction(func(tx *gorm.DB) error {
+		if _, err := sd.ownShelf(tx, userID, shelfID); err != nil {
+			return err
+		}
+		if err := tx.Where("shelf_id = ?", shelfID).Delete(&ShelfBook{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&Shelf{}, shelfID).Error
+	})
+	if err != nil {
+		logger.Error(ctx, "delete shelf query error", logger.Fields{"error": err, "shelf_id": shelfID})
+		return err
+	}
+	return nil
+}
+
+func (sd *shelfData) ShelfBooks(ctx context.Context, userID, shelfID uint, page, limit int) ([]shelf.Book, int64, error) {
+	if _, err := sd.ownShelf(uow.DB(ctx, sd.db), userID, shelfID); err != nil {
+		return nil, 0, err
+	}
+
+	qry := uow.DB(ctx, sd.db).Model(&ShelfBook{}).
+		Joins("JOIN books ON books.id = shelf_books.books_id AND books.deleted_at IS NULL").
+		Where("shelf_books.shelf_id = ?", shelfID)
+
+	var total int64
+	if err := qry.Count(&total).Error; err != nil {
+		logger.Error(ctx, "count shelf book query error", logger.Fields{"error": err, "shelf_id": shelfID})
+		return nil, 0, err
+	}
+
+	res := []shelf.Book{}
+	err := qry.Select("books.id, books.judul, books.penulis, books.tahun_terbit, books.jumlah_halaman").
+		Order("shelf_books.created_at DESC, books.id DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Scan(&res).Error
+	if err != nil {
+		logger.Error(ctx, "list shelf book query error", logger.Fields{"error": err, "shelf_id": shelfID})
+		return nil, 0, err
+	}
+	return res, total, nil
+}
+
+func (sd *shelfData) AddToShelf(ctx context.Context, userID, shelfID, bookID uint) error {
+	if _, err := sd.ownShelf(uow.DB(ctx, sd.db), userID, shelfID); err != nil {
+		return err
+	}
+	if _, err := sd.book(ctx, bookID); err != nil {
+		return err
+	}
+
+	err := uow.DB(ctx, sd.db).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&ShelfBook{ShelfID: shelfID, BooksID: bookID}).Error
+	if err != nil {
+		logger.Error(ctx, "add shelf book query error", logger.Fields{"error": err, "shelf_id": shelfID, "book_id": bookID})
+		return err
+	}
+	return nil
+}
+
+func (sd *shelfData) RemoveFromShelf(ctx context.Context, userID, shelfID, bookID uint) error {
+	if _, err := sd.ownShelf(uow.DB(ctx, sd.db), userID, shelfID); err != nil {
+		return err
+	}
+
+	qry := uow.DB(ctx, sd.db).Where("shelf_id = ? AND books_id = ?", shelfID, bookID).Delete(&ShelfBook{})
+	if err := qry.Error; err != nil {
+		logger.Error(ctx, "remove shelf book query error", logger.Fields{"error": err, "shelf_id": shelfID, "book_id": bookID})
+		return err
+	}
+	if qry.RowsAffected == 0 {
+		return errors.New("book not found")
+	}
+	return nil
+}
+
+// ownShelf mengambil rak milik userID, rak user lain dianggap tidak ada.
+func (sd *shelfData) ownShelf(tx *gorm.DB, userID, shelfID uint) (Shelf, error) {
+	row := Shelf{}
+	err := tx.Where("id = ? AND user_id = ?", shelfID, userID).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Shelf{}, errors.New("shelf not found")
+	}
+	return row, err
+}
+
+func (sd *shelfData) book(ctx context.Context, bookID uint) (book.Books, error) {
+	row := book.Books{}
+	err := uow.DB(ctx, sd.db).Scopes(book.ActiveOwner).Select("id, jumlah_halaman").Where("id = ?", bookID).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return book.Books{}, errors.New("book not found")
+	}
+	return row, err
+}
+
+func (sd *shelfData) checkNama(ctx context.Context, userID, shelfID uint, nama string) error {
+	var count int64
+	err := uow.DB(ctx, sd.db).Model(&Shelf{}).
+		Where("user_id = ? AND LOWER(nama) = ? AND id <> ?", userID, strings.ToLower(nama), shelfID).
+		Count(&count).Error
+	if err != nil {
+		logger.Error(ctx, "check shelf nama error", logger.Fields{"error": err})
+		return err
+	}
+	if count > 0 {
+		return errors.New("conflict: nama rak sudah dipakai")
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package data_test
+
+import (
+	"api/dbtest"
+	author "api/features/author/data"
+	"api/features/book"
+	bookdata "api/features/book/data"
+	"api/features/shelf"
+	"api/features/shelf/data"
+	user "api/features/user/data"
+	"api/outbox"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newShelfData menyiapkan dua user dan tiga buku milik user 1.
+func newShelfData(t *testing.T) (shelf.ShelfData, []uint) {
+	db := dbtest.Open(t, user.User{}, bookdata.Genre{}, bookdata.Books{}, author.Author{}, bookdata.BookAuthor{}, bookdata.BookRevision{},
+		outbox.Message{}, data.Reading{}, data.Shelf{}, data.ShelfBook{})
+	require.NoError(t, db.Create(&user.User{Nama: "alif"}).Error)
+	require.NoError(t, db.Create(&user.User{Nama: "budi"}).Error)
+
+	bd := bookdata.New(db)
+	ids := []uint{}
+	for _, b := range []book.Core{
+		{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eiichiro Oda", JumlahHalaman: 200},
+		{Judul: "Laskar Pelangi", TahunTerbit: 2005, Penulis: "Andrea Hirata", JumlahHalaman: 300},
+		{Judul: "Bumi Manusia", TahunTerbit: 1980, Penulis: "Pramoedya Ananta Toer"},
+	} {
+		res, err := bd.Add(context.Background(), 1, b)
+		require.NoError(t, err)
+		ids = append(ids, res.ID)
+	}
+	return data.New(db), ids
+}
+
+func TestReading(t *testing.T) {
+	ctx := context.Background()
+	sd, ids := newShelfData(t)
+
+	t.Run("ingin dibaca lalu sedang dibaca", func(t *testing.T) {
+		res, err := sd.SetReading(ctx, 1, ids[0], shelf.Reading{Status: shelf.StatusWant})
+		require.NoError(t, err)
+		assert.Equal(t, shelf.StatusWant, res.Status)
+		assert.Equal(t, "One Piece", res.Book.Judul)
+
+		mulai := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+		res, err = sd.SetReading(ctx, 1, ids[0], shelf.Reading{Status: shelf.StatusReading, Halaman: 50, MulaiBaca: &mulai})
+		require.NoError(t, err)
+		assert.Equal(t, shelf.StatusReading, res.Status)
+		assert.Equal(t, 50, res.Halaman)
+		require.NotNil(t, res.MulaiBaca)
+	})
+
+	t.Run("halaman melebihi jumlah halaman buku", func(t *testing.T) {
+		_, err := sd.SetReading(ctx, 1, ids[0], shelf.Reading{Status: shelf.StatusReading, Halaman: 201})
+		assert.ErrorContains(t, err, "format")
+
+		res, err := sd.Reading(ctx, 1, ids[0])
+		require.NoError(t, err)
+		assert.Equal(t, 50, res.Halaman)
+	})
+
+	t.Run("selesai mengisi halaman dari jumlah halaman buku", func(t *testing.T) {
+		selesai := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+		res, err := sd.SetReading(ctx, 1, ids[0], shelf.Reading{Status: shelf.StatusFinished, SelesaiBaca: &selesai})
+		require.NoError(t, err)
+		assert.Equal(t, shelf.StatusFinished, res.Status)
+		assert.Equal(t, 200, res.Halaman)
+	})
+
+	t.Run("buku tidak ada", func(t *testing.T) {
+		_, err := sd.SetReading(ctx, 1, 999, shelf.Reading{Status: shelf.StatusWant})
+		assert.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("hapus status baca", func(t *testing.T) {
+		_, err := sd.SetReading(ctx, 2, ids[1], shelf.Reading{Status: shelf.StatusWant})
+		require.NoError(t, err)
+		require.NoError(t, sd.DeleteReading(ctx, 2, ids[1]))
+		assert.ErrorContains(t, sd.DeleteReading(ctx, 2, ids[1]), "not found")
+
+		_, total, err := sd.ListReading(ctx, 2, "", 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), total)
+	})
+}
+
+func TestStats(t *testing.T) {
+	ctx := context.Background()
+	sd, ids := newShelfData(t)
+
+	finished := func(bookID uint, halaman int, selesai time.Time) {
+		_, err := sd.SetReading(ctx, 1, bookID, shelf.Reading{Status: shelf.StatusFinished, Halaman: halaman, SelesaiBaca: &selesai})
+		require.NoError(t, err)
+	}
+	finished(ids[0], 0, time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC))
+	finished(ids[1], 0, time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC))
+	finished(ids[2], 120, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+	_, err := sd.SetReading(ctx, 2, ids[0], shelf.Reading{Status: shelf.StatusReading, Halaman: 40})
+	require.NoError(t, err)
+
+	res, err := sd.Stats(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.Selesai)
+	assert.Equal(t, 0, res.SedangDibaca)
+	assert.Equal(t, 620, res.HalamanDibaca)
+	assert.Equal(t, []shelf.YearStat{
+		{Tahun: 2024, JumlahBuku: 2, JumlahHalaman: 420},
+		{Tahun: 2023, JumlahBuku: 1, JumlahHalaman: 200},
+	}, res.PerTahun)
+
+	res, err = sd.Stats(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.SedangDibaca)
+	assert.Equal(t, 40, res.HalamanDibaca)
+	assert.Equal(t, []shelf.YearStat{}, res.PerTahun)
+}
+
+func TestShelf(t *testing.T) {
+	ctx := context.Background()
+	sd, ids := newShelfData(t)
+
+	res, err := sd.AddShelf(ctx, 1, shelf.Shelf{Nama: "Favorit"})
+	require.NoError(t, err)
+	shelfID := res.ID
+
+	t.Run("nama rak sudah dipakai", func(t *testing.T) {
+		_, err := sd.AddShelf(ctx, 1, shelf.Shelf{Nama: "favorit"})
+		assert.ErrorContains(t, err, "conflict")
+
+		_, err = sd.AddShelf(ctx, 2, shelf.Shelf{Nama: "Favorit"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("tambah dan hapus buku di rak", func(t *testing.T) {
+		require.NoError(t, sd.AddToShelf(ctx, 1, shelfID, ids[0]))
+		require.NoError(t, sd.AddToShelf(ctx, 1, shelfID, ids[1]))
+		// menambahkan buku yang sama tidak membuat baris ganda
+		require.NoError(t, sd.AddToShelf(ctx, 1, shelfID, ids[1]))
+
+		books, total, err := sd.ShelfBooks(ctx, 1, shelfID, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Len(t, books, 2)
+
+		require.NoError(t, sd.RemoveFromShelf(ctx, 1, shelfID, ids[0]))
+		assert.ErrorContains(t, sd.RemoveFromShelf(ctx, 1, shelfID, ids[0]), "not found")
+
+		list, err := sd.ListShelf(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, 1, list[0].JumlahBuku)
+	})
+
+	t.Run("rak user lain", func(t *testing.T) {
+		assert.ErrorContains(t, sd.AddToShelf(ctx, 2, shelfID, ids[2]), "not found")
+		_, err := sd.UpdateShelf(ctx, 2, shelfID, shelf.Shelf{Nama: "Punya Budi"})
+		assert.ErrorContains(t, err, "not found")
+		assert.ErrorContains(t, sd.DeleteShelf(ctx, 2, shelfID), "not found")
+	})
+
+	t.Run("ganti nama lalu hapus rak", func(t *testing.T) {
+		res, err := sd.UpdateShelf(ctx, 1, shelfID, shelf.Shelf{Nama: "Dibaca Ulang"})
+		require.NoError(t, err)
+		assert.Equal(t, "Dibaca Ulang", res.Nama)
+		assert.Equal(t, 1, res.JumlahBuku)
+
+		require.NoError(t, sd.DeleteShelf(ctx, 1, shelfID))
+		list, err := sd.ListShelf(ctx, 1)
+		require.NoError(t, err)
+		assert.Empty(t, list)
+	})
+}
@@ -0,0 +1,109 @@
+package shelf
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	StatusWant     = "want_to_read"
+	StatusReading  = "reading"
+	StatusFinished = "finished"
+)
+
+// Reading adalah status baca user untuk satu buku di katalog, tidak harus
+// buku miliknya sendiri. Halaman adalah progres halaman terakhir yang
+// dibaca.
+type Reading struct {
+	BookID      uint
+	UserID      uint
+	Status      string `validate:"required,oneof=want_to_read reading finished"`
+	Halaman     int    `validate:"gte=0,lte=100000"`
+	MulaiBaca   *time.Time
+	SelesaiBaca *time.Time
+	Book        Book
+	UpdatedAt   time.Time
+}
+
+// Book adalah ringkasan buku yang ditampilkan pada daftar baca dan rak.
+type Book struct {
+	ID            uint
+	Judul         string
+	Penulis       string
+	TahunTerbit   int
+	JumlahHalaman int
+}
+
+// Shelf adalah rak buatan user dengan nama bebas.
+type Shelf struct {
+	ID         uint
+	UserID     uint
+	Nama       string `validate:"required,max=50"`
+	JumlahBuku int
+	CreatedAt  time.Time
+}
+
+type YearStat struct {
+	Tahun         int
+	JumlahBuku    int
+	JumlahHalaman int
+}
+
+// Stats adalah statistik baca user. HalamanDibaca menjumlahkan halaman buku
+// yang selesai dan progres buku yang sedang dibaca.
+type Stats struct {
+	InginDibaca   int
+	SedangDibaca  int
+	Selesai       int
+	HalamanDibaca int
+	PerTahun      []YearStat
+}
+
+type ShelfHandler interface {
+	SetReading() echo.HandlerFunc
+	DeleteReading() echo.HandlerFunc
+	ListReading() echo.HandlerFunc
+	Stats() echo.HandlerFunc
+	AddShelf() echo.HandlerFunc
+	ListShelf() echo.HandlerFunc
+	UpdateShelf() echo.HandlerFunc
+	DeleteShelf() echo.HandlerFunc
+	ShelfBooks() echo.HandlerFunc
+	AddToShelf() echo.HandlerFunc
+	RemoveFromShelf() echo.HandlerFunc
+}
+
+type ShelfService interface {
+	SetReading(ctx context.Context, token interface{}, bookID uint, reading Reading) (Reading, error)
+	DeleteReading(ctx context.Context, token interface{}, bookID uint) error
+	ListReading(ctx context.Context, token interface{}, status string, page, limit int) ([]Reading, int64, error)
+	Stats(ctx context.Context, token interface{}) (Stats, error)
+	AddShelf(ctx context.Context, token interface{}, newShelf Shelf) (Shelf, error)
+	ListShelf(ctx context.Context, token interface{}) ([]Shelf, error)
+	UpdateShelf(ctx context.Context, token interface{}, shelfID uint, updatedData Shelf) (Shelf, error)
+	DeleteShelf(ctx context.Context, token interface{}, shelfID uint) error
+	ShelfBooks(ctx context.Context, token interface{}, shelfID uint, page, limit int) ([]Book, int64, error)
+	AddToShelf(ctx context.Context, token interface{}, shelfID, bookID uint) error
+	RemoveFromShelf(ctx context.Context, token interface{}, shelfID, bookID uint) error
+}
+
+// ShelfData selalu dibatasi pada userID, rak dan status baca user lain
+// dianggap tidak ada.
+type ShelfData interface {
+	Reading(ctx context.Context, userID, bookID uint) (Reading, error)
+	// SetReading menyimpan status baca. Halaman buku yang selesai dibaca
+	// diisi jumlah halaman buku bila kosong.
+	SetReading(ctx context.Context, userID, bookID uint, reading Reading) (Reading, error)
+	DeleteReading(ctx context.Context, userID, bookID uint) error
+	ListReading(ctx context.Context, userID uint, status string, page, limit int) ([]Reading, int64, error)
+	Stats(ctx context.Context, userID uint) (Stats, error)
+	AddShelf(ctx context.Context, userID uint, newShelf Shelf) (Shelf, error)
+	ListShelf(ctx context.Context, userID uint) ([]Shelf, error)
+	UpdateShelf(ctx context.Context, userID, shelfID uint, updatedData Shelf) (Shelf, error)
+	DeleteShelf(ctx context.Context, userID, shelfID uint) error
+	ShelfBooks(ctx context.Context, userID, shelfID uint, page, limit int) ([]Book, int64, error)
+	AddToShelf(ctx context.Context, userID, shelfID, bookID uint) error
+	RemoveFromShelf(ctx context.Context, userID, shelfID, bookID uint) error
+}
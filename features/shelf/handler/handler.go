@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"api/features/shelf"
+	"api/helper"
+	"api/logger"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type shelfHandle struct {
+	srv shelf.ShelfService
+}
+
+func New(ss shelf.ShelfService) shelf.ShelfHandler {
+	return &shelfHandle{
+		srv: ss,
+	}
+}
+
+// pathID membaca path parameter berupa ID.
+func pathID(c echo.Context, name string) (uint, bool) {
+	id, err := strconv.Atoi(c.Param(name))
+	if err != nil {
+		logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err, "param": name})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (sh *shelfHandle) SetReading() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		bookID, ok := pathID(c, "id")
+		if !ok {
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		input := SetReadingRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, err := sh.srv.SetReading(c.Request().Context(), c.Get("user"), bookID, input.ToCore())
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintSuccessReponse(http.StatusOK, "berhasil menyimpan status baca", res))
+	}
+}
+
+func (sh *shelfHandle) DeleteReading() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		bookID, ok := pathID(c, "id")
+		if !ok {
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		if err := sh.srv.DeleteReading(c.Request().Context(), c.Get("user"), bookID); err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(http.StatusAccepted, "berhasil menghapus status baca")
+	}
+}
+
+func (sh *shelfHandle) ListReading() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := ListReadingRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, total, err := sh.srv.ListReading(c.Request().Context(), c.Get("user"), input.Status, input.Page, input.Limit)
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintListResponse(http.StatusOK, "sukses menampilkan daftar baca", res, helper.NewPagination(input.Page, input.Limit, total)))
+	}
+}
+
+func (sh *shelfHandle) Stats() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		res, err := sh.srv.Stats(c.Request().Context(), c.Get("user"))
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintSuccessReponse(http.StatusOK, "sukses menampilkan statistik baca", res))
+	}
+}
+
+func (sh *shelfHandle) AddShelf() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := ShelfRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, err := sh.srv.AddShelf(c.Request().Context(), c.Get("user"), input.ToCore())
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintSuccessReponse(http.StatusCreated, "sukses menambahkan rak", res))
+	}
+}
+
+func (sh *shelfHandle) ListShelf() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		res, err := sh.srv.ListShelf(c.Request().Context(), c.Get("user"))
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintSuccessReponse(http.StatusOK, "sukses menampilkan rak", res))
+	}
+}
+
+func (sh *shelfHandle) UpdateShelf() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		shelfID, ok := pathID(c, "id")
+		if !ok {
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		input := ShelfRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, err := sh.srv.UpdateShelf(c.Request().Context(), c.Get("user"), shelfID, input.ToCore())
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintSuccessReponse(http.StatusOK, "berhasil update rak", res))
+	}
+}
+
+func (sh *shelfHandle) DeleteShelf() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		shelfID, ok := pathID(c, "id")
+		if !ok {
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		if err := sh.srv.DeleteShelf(c.Request().Context(), c.Get("user"), shelfID); err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(http.StatusAccepted, "berhasil delete rak")
+	}
+}
+
+func (sh *shelfHandle) ShelfBooks() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		shelfID, ok := pathID(c, "id")
+		if !ok {
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		input := ListShelfBookRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, total, err := sh.srv.ShelfBooks(c.Request().Context(), c.Get("user"), shelfID, input.Page, input.Limit)
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintListResponse(http.StatusOK, "sukses menampilkan buku di rak", res, helper.NewPagination(input.Page, input.Limit, total)))
+	}
+}
+
+func (sh *shelfHandle) AddToShelf() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		shelfID, ok := pathID(c, "id")
+		if !ok {
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+		bookID, ok := pathID(c, "book_id")
+		if !ok {
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		if err := sh.srv.AddToShelf(c.Request().Context(), c.Get("user"), shelfID, bookID); err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(http.StatusOK, "berhasil menambahkan buku ke rak")
+	}
+}
+
+func (sh *shelfHandle) RemoveFromShelf() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		shelfID, ok := pathID(c, "id")
+		if !ok {
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+		bookID, ok := pathID(c, "book_id")
+		if !ok {
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		if err := sh.srv.RemoveFromShelf(c.Request().Context(), c.Get("user"), shelfID, bookID); err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(http.StatusAccepted, "berhasil mengeluarkan buku dari rak")
+	}
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"api/features/shelf"
+	"time"
+)
+
+// SetReadingRequest mengubah status baca, tanggal yang kosong diisi
+// otomatis sesuai status.
+type SetReadingRequest struct {
+	Status      string     `json:"status" validate:"required,oneof=want_to_read reading finished"`
+	Halaman     int        `json:"halaman" validate:"gte=0,lte=100000"`
+	MulaiBaca   *time.Time `json:"mulai_baca"`
+	SelesaiBaca *time.Time `json:"selesai_baca"`
+}
+
+type ListReadingRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=want_to_read reading finished"`
+	Page   int    `query:"page" validate:"gte=1"`
+	Limit  int    `query:"limit" validate:"gte=1,lte=100"`
+}
+
+type ShelfRequest struct {
+	Nama string `json:"nama" validate:"required,max=50"`
+}
+
+type ListShelfBookRequest struct {
+	Page  int `query:"page" validate:"gte=1"`
+	Limit int `query:"limit" validate:"gte=1,lte=100"`
+}
+
+func (r SetReadingRequest) ToCore() shelf.Reading {
+	return shelf.Reading{
+		Status:      r.Status,
+		Halaman:     r.Halaman,
+		MulaiBaca:   r.MulaiBaca,
+		SelesaiBaca: r.SelesaiBaca,
+	}
+}
+
+func (r ShelfRequest) ToCore() shelf.Shelf {
+	return shelf.Shelf{Nama: r.Nama}
+}
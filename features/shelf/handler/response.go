@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"api/features/shelf"
+	"api/helper"
+	"time"
+)
+
+type ReadingResponse struct {
+	BookID      uint              `json:"book_id"`
+	Status      string            `json:"status"`
+	Halaman     int               `json:"halaman"`
+	MulaiBaca   *time.Time        `json:"mulai_baca,omitempty"`
+	SelesaiBaca *time.Time        `json:"selesai_baca,omitempty"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	Buku        ShelfBookResponse `json:"buku"`
+}
+
+type ShelfBookResponse struct {
+	ID            uint   `json:"id"`
+	Judul         string `json:"judul"`
+	Penulis       string `json:"penulis"`
+	TahunTerbit   int    `json:"tahun_terbit"`
+	JumlahHalaman int    `json:"jumlah_halaman,omitempty"`
+}
+
+type ShelfResponse struct {
+	ID         uint      `json:"id"`
+	Nama       string    `json:"nama"`
+	JumlahBuku int       `json:"jumlah_buku"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ReadingStatsResponse struct {
+	InginDibaca   int                `json:"ingin_dibaca"`
+	SedangDibaca  int                `json:"sedang_dibaca"`
+	Selesai       int                `json:"selesai"`
+	HalamanDibaca int                `json:"halaman_dibaca"`
+	PerTahun      []YearStatResponse `json:"per_tahun"`
+}
+
+type YearStatResponse struct {
+	Tahun         int `json:"tahun"`
+	JumlahBuku    int `json:"jumlah_buku"`
+	JumlahHalaman int `json:"jumlah_halaman"`
+}
+
+func ToBookResponse(data shelf.Book) ShelfBookResponse {
+	return ShelfBookResponse(data)
+}
+
+func ToReadingResponse(data shelf.Reading) ReadingResponse {
+	return ReadingResponse{
+		BookID:      data.BookID,
+		Status:      data.Status,
+		Halaman:     data.Halaman,
+		MulaiBaca:   data.MulaiBaca,
+		SelesaiBaca: data.SelesaiBaca,
+		UpdatedAt:   data.UpdatedAt,
+		Buku:        ToBookResponse(data.Book),
+	}
+}
+
+func ToShelfResponse(data shelf.Shelf) ShelfResponse {
+	return ShelfResponse{
+		ID:         data.ID,
+		Nama:       data.Nama,
+		JumlahBuku: data.JumlahBuku,
+		CreatedAt:  data.CreatedAt,
+	}
+}
+
+func ToStatsResponse(data shelf.Stats) ReadingStatsResponse {
+	res := ReadingStatsResponse{
+		InginDibaca:   data.InginDibaca,
+		SedangDibaca:  data.SedangDibaca,
+		Selesai:       data.Selesai,
+		HalamanDibaca: data.HalamanDibaca,
+		PerTahun:      []YearStatResponse{},
+	}
+	for _, y := range data.PerTahun {
+		res.PerTahun = append(res.PerTahun, YearStatResponse(y))
+	}
+	return res
+}
+
+// PrintSuccessReponse menerima shelf.Reading, shelf.Shelf, shelf.Stats atau
+// []shelf.Shelf.
+func PrintSuccessReponse(code int, message string, data interface{}) (int, interface{}) {
+	resp := map[string]interface{}{}
+	switch v := data.(type) {
+	case shelf.Reading:
+		resp["data"] = ToReadingResponse(v)
+	case shelf.Shelf:
+		resp["data"] = ToShelfResponse(v)
+	case shelf.Stats:
+		resp["data"] = ToStatsResponse(v)
+	case []shelf.Shelf:
+		res := []ShelfResponse{}
+		for _, s := range v {
+			res = append(res, ToShelfResponse(s))
+		}
+		resp["data"] = res
+	}
+
+	if message != "" {
+		resp["message"] = message
+	}
+
+	return code, resp
+}
+
+// PrintListResponse menerima []shelf.Reading atau []shelf.Book.
+func PrintListResponse(code int, message string, data interface{}, pagination helper.Pagination) (int, interface{}) {
+	resp := map[string]interface{}{}
+	switch v := data.(type) {
+	case []shelf.Reading:
+		res := []ReadingResponse{}
+		for _, r := range v {
+			res = append(res, ToReadingResponse(r))
+		}
+		resp["data"] = res
+	case []shelf.Book:
+		res := []ShelfBookResponse{}
+		for _, b := range v {
+			res = append(res, ToBookResponse(b))
+		}
+		resp["data"] = res
+	}
+	resp["pagination"] = pagination
+
+	if message != "" {
+		resp["message"] = message
+	}
+
+	return code, resp
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"api/features/shelf"
+	"api/helper"
+	"api/tracing"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type shelfSrv struct {
+	data shelf.ShelfData
+	vld  *validator.Validate
+	now  func() time.Time
+}
+
+func New(d shelf.ShelfData) shelf.ShelfService {
+	return &shelfSrv{
+		data: d,
+		vld:  validator.New(),
+		now:  time.Now,
+	}
+}
+
+func (ss *shelfSrv) SetReading(ctx context.Context, token interface{}, bookID uint, reading shelf.Reading) (shelf.Reading, error) {
+	ctx, span := tracing.Start(ctx, "ShelfService.SetReading")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return shelf.Reading{}, errors.New("user not found")
+	}
+	if err := ss.vld.Struct(reading); err != nil {
+		return shelf.Reading{}, errors.New("format input status baca tidak sesuai, status want_to_read, reading atau finished")
+	}
+
+	old, err := ss.data.Reading(ctx, uint(userID), bookID)
+	if err != nil && !strings.Contains(err.Error(), "not found") {
+		return shelf.Reading{}, errors.New("terjadi kesalahan pada server")
+	}
+
+	reading, err = ss.fillDates(old, reading)
+	if err != nil {
+		return shelf.Reading{}, err
+	}
+
+	res, err := ss.data.SetReading(ctx, uint(userID), bookID, reading)
+	if err != nil {
+		return shelf.Reading{}, errorMsg(err)
+	}
+
+	return res, nil
+}
+
+// fillDates melengkapi tanggal dan progres dari status sebelumnya, sehingga
+// user cukup mengirim status baru tanpa mengulang tanggal mulai baca.
+func (ss *shelfSrv) fillDates(old, r shelf.Reading) (shelf.Reading, error) {
+	now := ss.now()
+	switch r.Status {
+	case shelf.StatusWant:
+		r.Halaman, r.MulaiBaca, r.SelesaiBaca = 0, nil, nil
+	case shelf.StatusReading:
+		r.SelesaiBaca = nil
+		if r.MulaiBaca == nil {
+			r.MulaiBaca = old.MulaiBaca
+		}
+		if r.MulaiBaca == nil {
+			r.MulaiBaca = &now
+		}
+		if r.Halaman == 0 && old.Status == shelf.StatusReading {
+			r.Halaman = old.Halaman
+		}
+	case shelf.StatusFinished:
+		if r.SelesaiBaca == nil {
+			r.SelesaiBaca = &now
+		}
+		if r.MulaiBaca == nil {
+			r.MulaiBaca = old.MulaiBaca
+		}
+		if r.MulaiBaca == nil {
+			r.MulaiBaca = r.SelesaiBaca
+		}
+	}
+
+	for _, t := range []*time.Time{r.MulaiBaca, r.SelesaiBaca} {
+		if t != nil && t.After(now) {
+			return shelf.Reading{}, errors.New("format tanggal baca tidak boleh di masa depan")
+		}
+	}
+	if r.MulaiBaca != nil && r.SelesaiBaca != nil && r.SelesaiBaca.Before(*r.MulaiBaca) {
+		return shelf.Reading{}, errors.New("format selesai_baca tidak boleh sebelum mulai_baca")
+	}
+	return r, nil
+}
+
+func (ss *shelfSrv) DeleteReading(ctx context.Context, token interface{}, bookID uint) error {
+	ctx, span := tracing.Start(ctx, "ShelfService.DeleteReading")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return errors.New("user not found")
+	}
+
+	if err := ss.data.DeleteReading(ctx, uint(userID), bookID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return errors.New("status baca not found")
+		}
+		return errorMsg(err)
+	}
+	return nil
+}
+
+func (ss *shelfSrv) ListReading(ctx context.Context, token interface{}, status string, page, limit int) ([]shelf.Reading, int64, error) {
+	ctx, span := tracing.Start(ctx, "ShelfService.ListReading")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return nil, 0, errors.New("user not found")
+	}
+	if err := ss.vld.Var(status, "omitempty,oneof=want_to_read reading finished"); err != nil {
+		return nil, 0, errors.New("format status tidak sesuai")
+	}
+
+	page, limit = helper.PageLimit(page, limit)
+	res, total, err := ss.data.ListReading(ctx, uint(userID), status, page, limit)
+	if err != nil {
+		return nil, 0, errorMsg(err)
+	}
+	return res, total, nil
+}
+
+func (ss *shelfSrv) Stats(ctx context.Context, token interface{}) (shelf.Stats, error) {
+	ctx, span := tracing.Start(ctx, "ShelfService.Stats")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return shelf.Stats{}, errors.New("user not found")
+	}
+
+	res, err := ss.data.Stats(ctx, uint(userID))
+	if err != nil {
+		return shelf.Stats{}, errorMsg(err)
+	}
+	return res, nil
+}
+
+func (ss *shelfSrv) AddShelf(ctx context.Context, token interface{}, newShelf shelf.Shelf) (shelf.Shelf, error) {
+	ctx, span := tracing.Start(ctx, "ShelfService.AddShelf")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return shelf.Shelf{}, errors.New("user not found")
+	}
+	newShelf.Nama = normalizeNama(newShelf.Nama)
+	if err := ss.vld.Struct(newShelf); err != nil {
+		return shelf.Shelf{}, errors.New("format input rak tidak sesuai, nama wajib diisi maksimal 50 karakter")
+	}
+
+	res, err := ss.data.AddShelf(ctx, uint(userID), newShelf)
+	if err != nil {
+		return shelf.Shelf{}, errorMsg(err)
+	}
+	return res, nil
+}
+
+func (ss *shelfSrv) ListShelf(ctx context.Context, token interface{}) ([]shelf.Shelf, error) {
+	ctx, span := tracing.Start(ctx, "ShelfService.ListShelf")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return nil, errors.New("user not found")
+	}
+
+	res, err := ss.data.ListShelf(ctx, uint(userID))
+	if err != nil {
+		return nil, errorMsg(err)
+	}
+	return res, nil
+}
+
+func (ss *shelfSrv) UpdateShelf(ctx context.Context, token interface{}, shelfID uint, updatedData shelf.Shelf) (shelf.Shelf, error) {
+	ctx, span := tracing.Start(ctx, "ShelfService.UpdateShelf")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return shelf.Shelf{}, errors.New("user not found")
+	}
+	updatedData.Nama = normalizeNama(updatedData.Nama)
+	if err := ss.vld.Struct(updatedData); err != nil {
+		return shelf.Shelf{}, errors.New("format input rak tidak sesuai, nama wajib diisi maksimal 50 karakter")
+	}
+
+	res, err := ss.data.UpdateShelf(ctx, uint(userID), shelfID, updatedData)
+	if err != nil {
+		return shelf.Shelf{}, errorMsg(err)
+	}
+	return res, nil
+}
+
+func (ss *shelfSrv) DeleteShelf(ctx context.Context, token interface{}, shelfID uint) error {
+	ctx, span := tracing.Start(ctx, "ShelfService.DeleteShelf")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return errors.New("user not found")
+	}
+
+	if err := ss.data.DeleteShelf(ctx, uint(userID), shelfID); err != nil {
+		return errorMsg(err)
+	}
+	return nil
+}
+
+func (ss *shelfSrv) ShelfBooks(ctx context.Context, token interface{}, shelfID uint, page, limit int) ([]shelf.Book, int64, error) {
+	ctx, span := tracing.Start(ctx, "ShelfService.ShelfBooks")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return nil, 0, errors.New("user not found")
+	}
+
+	page, limit = helper.PageLimit(page, limit)
+	res, total, err := ss.data.ShelfBooks(ctx, uint(userID), shelfID, page, limit)
+	if err != nil {
+		return nil, 0, errorMsg(err)
+	}
+	return res, total, nil
+}
+
+func (ss *shelfSrv) AddToShelf(ctx context.Context, token interface{}, shelfID, bookID uint) error {
+	ctx, span := tracing.Start(ctx, "ShelfService.AddToShelf")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return errors.New("user not found")
+	}
+
+	if err := ss.data.AddToShelf(ctx, uint(userID), shelfID, bookID); err != nil {
+		return errorMsg(err)
+	}
+	return nil
+}
+
+func (ss *shelfSrv) RemoveFromShelf(ctx context.Context, token interface{}, shelfID, bookID uint) error {
+	ctx, span := tracing.Start(ctx, "ShelfService.RemoveFromShelf")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return errors.New("user not found")
+	}
+
+	if err := ss.data.RemoveFromShelf(ctx, uint(userID), shelfID, bookID); err != nil {
+		return errorMsg(err)
+	}
+	return nil
+}
+
+// normalizeNama merapikan spasi berlebih pada nama rak.
+func normalizeNama(nama string) string {
+	return strings.Join(strings.Fields(nama), " ")
+}
+
+// errorMsg meneruskan pesan "not found", "conflict" dan "format" dari data
+// apa adanya, selain itu dianggap kesalahan server.
+func errorMsg(err error) error {
+	msg := err.Error()
+	if strings.Contains(msg, "not found") || strings.Contains(msg, "conflict") || strings.Contains(msg, "format") {
+		return err
+	}
+	return errors.New("terjadi kesalahan pada server")
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"api/features/shelf"
+	"api/helper"
+	"api/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func validToken() interface{} {
+	_, token := helper.GenerateJWT(1)
+	pToken := token.(*jwt.Token)
+	pToken.Valid = true
+	return pToken
+}
+
+func date(y int, m time.Month, d int) *time.Time {
+	t := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func TestSetReading(t *testing.T) {
+	now := *date(2024, 3, 10)
+	newSrv := func(repo shelf.ShelfData) *shelfSrv {
+		srv := New(repo).(*shelfSrv)
+		srv.now = func() time.Time { return now }
+		return srv
+	}
+
+	t.Run("mulai membaca", func(t *testing.T) {
+		repo := mocks.NewShelfData(t)
+		repo.On("Reading", mock.Anything, uint(1), uint(3)).Return(shelf.Reading{}, errors.New("data not found")).Once()
+		expected := shelf.Reading{Status: shelf.StatusReading, Halaman: 20, MulaiBaca: &now}
+		repo.On("SetReading", mock.Anything, uint(1), uint(3), expected).Return(expected, nil).Once()
+
+		res, err := newSrv(repo).SetReading(context.Background(), validToken(), 3, shelf.Reading{Status: shelf.StatusReading, Halaman: 20})
+		assert.Nil(t, err)
+		assert.Equal(t, &now, res.MulaiBaca)
+	})
+
+	t.Run("selesai memakai tanggal mulai sebelumnya", func(t *testing.T) {
+		repo := mocks.NewShelfData(t)
+		old := shelf.Reading{Status: shelf.StatusReading, Halaman: 120, MulaiBaca: date(2024, 2, 1)}
+		repo.On("Reading", mock.Anything, uint(1), uint(3)).Return(old, nil).Once()
+		expected := shelf.Reading{Status: shelf.StatusFinished, MulaiBaca: old.MulaiBaca, SelesaiBaca: &now}
+		repo.On("SetReading", mock.Anything, uint(1), uint(3), expected).Return(expected, nil).Once()
+
+		_, err := newSrv(repo).SetReading(context.Background(), validToken(), 3, shelf.Reading{Status: shelf.StatusFinished})
+		assert.Nil(t, err)
+	})
+
+	t.Run("progres lama dipertahankan", func(t *testing.T) {
+		repo := mocks.NewShelfData(t)
+		old := shelf.Reading{Status: shelf.StatusReading, Halaman: 120, MulaiBaca: date(2024, 2, 1)}
+		repo.On("Reading", mock.Anything, uint(1), uint(3)).Return(old, nil).Once()
+		repo.On("SetReading", mock.Anything, uint(1), uint(3), old).Return(old, nil).Once()
+
+		_, err := newSrv(repo).SetReading(context.Background(), validToken(), 3, shelf.Reading{Status: shelf.StatusReading})
+		assert.Nil(t, err)
+	})
+
+	t.Run("tanggal tidak valid", func(t *testing.T) {
+		repo := mocks.NewShelfData(t)
+		repo.On("Reading", mock.Anything, uint(1), uint(3)).Return(shelf.Reading{}, errors.New("data not found"))
+		srv := newSrv(repo)
+
+		_, err := srv.SetReading(context.Background(), validToken(), 3, shelf.Reading{Status: shelf.StatusFinished,
+			MulaiBaca: date(2024, 3, 1), SelesaiBaca: date(2024, 2, 1)})
+		assert.ErrorContains(t, err, "format selesai_baca")
+
+		_, err = srv.SetReading(context.Background(), validToken(), 3, shelf.Reading{Status: shelf.StatusReading, MulaiBaca: date(2025, 1, 1)})
+		assert.ErrorContains(t, err, "masa depan")
+	})
+
+	t.Run("status tidak dikenal", func(t *testing.T) {
+		_, err := newSrv(mocks.NewShelfData(t)).SetReading(context.Background(), validToken(), 3, shelf.Reading{Status: "dropped"})
+		assert.ErrorContains(t, err, "format")
+	})
+
+	t.Run("halaman melebihi buku", func(t *testing.T) {
+		repo := mocks.NewShelfData(t)
+		repo.On("Reading", mock.Anything, uint(1), uint(3)).Return(shelf.Reading{}, errors.New("data not found")).Once()
+		repo.On("SetReading", mock.Anything, uint(1), uint(3), mock.Anything).Return(shelf.Reading{}, errors.New("format halaman melebihi jumlah halaman buku (100)")).Once()
+
+		_, err := newSrv(repo).SetReading(context.Background(), validToken(), 3, shelf.Reading{Status: shelf.StatusReading, Halaman: 500})
+		assert.ErrorContains(t, err, "format halaman")
+	})
+
+	t.Run("buku tidak ditemukan", func(t *testing.T) {
+		repo := mocks.NewShelfData(t)
+		repo.On("Reading", mock.Anything, uint(1), uint(99)).Return(shelf.Reading{}, errors.New("data not found")).Once()
+		repo.On("SetReading", mock.Anything, uint(1), uint(99), mock.Anything).Return(shelf.Reading{}, errors.New("book not found")).Once()
+
+		_, err := newSrv(repo).SetReading(context.Background(), validToken(), 99, shelf.Reading{Status: shelf.StatusWant})
+		assert.EqualError(t, err, "book not found")
+	})
+}
+
+func TestStats(t *testing.T) {
+	repo := mocks.NewShelfData(t)
+
+	t.Run("berhasil menampilkan statistik", func(t *testing.T) {
+		stats := shelf.Stats{Selesai: 3, HalamanDibaca: 900, PerTahun: []shelf.YearStat{{Tahun: 2024, JumlahBuku: 3, JumlahHalaman: 850}}}
+		repo.On("Stats", mock.Anything, uint(1)).Return(stats, nil).Once()
+
+		res, err := New(repo).Stats(context.Background(), validToken())
+		assert.Nil(t, err)
+		assert.Equal(t, stats, res)
+	})
+
+	t.Run("masalah di server", func(t *testing.T) {
+		repo.On("Stats", mock.Anything, uint(1)).Return(shelf.Stats{}, errors.New("connection refused")).Once()
+
+		_, err := New(repo).Stats(context.Background(), validToken())
+		assert.ErrorContains(t, err, "server")
+	})
+
+	t.Run("jwt tidak valid", func(t *testing.T) {
+		_, token := helper.GenerateJWT(1)
+		_, err := New(repo).Stats(context.Background(), token)
+		assert.ErrorContains(t, err, "not found")
+	})
+}
+
+func TestShelf(t *testing.T) {
+	repo := mocks.NewShelfData(t)
+
+	t.Run("berhasil membuat rak", func(t *testing.T) {
+		repo.On("AddShelf", mock.Anything, uint(1), shelf.Shelf{Nama: "Fiksi Favorit"}).Return(shelf.Shelf{ID: 1, Nama: "Fiksi Favorit"}, nil).Once()
+
+		res, err := New(repo).AddShelf(context.Background(), validToken(), shelf.Shelf{Nama: "  Fiksi   Favorit "})
+		assert.Nil(t, err)
+		assert.Equal(t, uint(1), res.ID)
+	})
+
+	t.Run("nama kosong", func(t *testing.T) {
+		_, err := New(repo).AddShelf(context.Background(), validToken(), shelf.Shelf{Nama: "  "})
+		assert.ErrorContains(t, err, "format")
+	})
+
+	t.Run("nama rak sudah dipakai", func(t *testing.T) {
+		repo.On("UpdateShelf", mock.Anything, uint(1), uint(2), shelf.Shelf{Nama: "Fiksi"}).Return(shelf.Shelf{}, errors.New("conflict: nama rak sudah dipakai")).Once()
+
+		_, err := New(repo).UpdateShelf(context.Background(), validToken(), 2, shelf.Shelf{Nama: "Fiksi"})
+		assert.ErrorContains(t, err, "conflict")
+	})
+
+	t.Run("rak milik user lain", func(t *testing.T) {
+		repo.On("AddToShelf", mock.Anything, uint(1), uint(9), uint(3)).Return(errors.New("shelf not found")).Once()
+
+		err := New(repo).AddToShelf(context.Background(), validToken(), 9, 3)
+		assert.EqualError(t, err, "shelf not found")
+	})
+
+	t.Run("daftar buku di rak", func(t *testing.T) {
+		books := []shelf.Book{{ID: 3, Judul: "Laskar Pelangi"}}
+		repo.On("ShelfBooks", mock.Anything, uint(1), uint(2), 1, helper.DefaultLimit).Return(books, int64(1), nil).Once()
+
+		res, total, err := New(repo).ShelfBooks(context.Background(), validToken(), 2, 0, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, books, res)
+	})
+}
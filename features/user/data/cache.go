@@ -0,0 +1,97 @@
+package data
+
+import (
+	"api/cache"
+	"api/features/user"
+	"api/replica"
+	"api/uow"
+	"context"
+	"fmt"
+	"time"
+)
+
+// invalidating menghapus cache profil setiap kali akun diubah tanpa
+// menyimpan apa pun ke cache.
+type invalidating struct {
+	user.UserData
+	cache cache.Cache
+}
+
+// NewInvalidating membungkus next agar perubahan akun menghapus cache
+// profil setelah di-commit. Dipakai untuk repository di dalam unit of work,
+// yang tidak boleh membaca dari atau mengisi cache karena datanya belum
+// di-commit.
+func NewInvalidating(next user.UserData, c cache.Cache) user.UserData {
+	return &invalidating{
+		UserData: next,
+		cache:    c,
+	}
+}
+
+// invalidate menghapus cache profil id setelah transaksi pada ctx
+// di-commit. Di luar unit of work cache langsung dihapus, termasuk bila
+// perubahan gagal, karena commit yang gagal tidak bisa dibedakan dari
+// perubahan yang sudah tersimpan.
+func (iv *invalidating) invalidate(ctx context.Context, id uint) {
+	uow.AfterCommit(ctx, func(ctx context.Context) {
+		cache.Invalidate(ctx, iv.cache, profileKey(id))
+	})
+}
+
+func (iv *invalidating) Update(ctx context.Context, id uint, updateData user.Core, fields []string) (user.Core, error) {
+	defer iv.invalidate(ctx, id)
+	return iv.UserData.Update(ctx, id, updateData, fields)
+}
+
+func (iv *invalidating) Deactive(ctx context.Context, id uint, version uint) error {
+	defer iv.invalidate(ctx, id)
+	return iv.UserData.Deactive(ctx, id, version)
+}
+
+func (iv *invalidating) Restore(ctx context.Context, id uint) (user.Core, error) {
+	defer iv.invalidate(ctx, id)
+	return iv.UserData.Restore(ctx, id)
+}
+
+func (iv *invalidating) Purge(ctx context.Context, id uint) error {
+	defer iv.invalidate(ctx, id)
+	return iv.UserData.Purge(ctx, id)
+}
+
+// cachedData menyimpan hasil Profile di cache dan menghapusnya setiap kali
+// akun diubah. Method lain diteruskan langsung ke next.
+type cachedData struct {
+	user.UserData
+	cache cache.Cache
+	group cache.Group
+	ttl   time.Duration
+}
+
+// NewCached membungkus next dengan cache. Perubahan yang tidak lewat
+// UserData ini (mis. role yang diubah langsung di database) baru terlihat
+// setelah ttl.
+func NewCached(next user.UserData, c cache.Cache, ttl time.Duration) user.UserData {
+	return &cachedData{
+		UserData: NewInvalidating(next, c),
+		cache:    c,
+		ttl:      ttl,
+	}
+}
+
+func profileKey(id uint) string {
+	return fmt.Sprintf("user:%d", id)
+}
+
+// Isi cache selalu dibaca dari primary agar data replica yang tertinggal
+// tidak ikut tersimpan sampai TTL habis. Di dalam unit of work cache
+// dilewati agar data yang belum di-commit tidak tersimpan.
+func (cd *cachedData) Profile(ctx context.Context, id uint) (user.Core, error) {
+	if uow.InTx(ctx) {
+		return cd.UserData.Profile(ctx, id)
+	}
+	res := user.Core{}
+	err := cache.Load(ctx, cd.cache, &cd.group, "user", profileKey(id), cd.ttl, &res, func() (interface{}, error) {
+		return cd.UserData.Profile(replica.Primary(ctx), id)
+	})
+	return res, err
+}
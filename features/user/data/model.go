@@ -3,29 +3,40 @@ package data
 import (
 	"api/features/book/data"
 	"api/features/user"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type User struct {
 	gorm.Model
+	Version  uint `gorm:"not null;default:1"`
 	Nama     string
 	Email    string
 	Alamat   string
 	HP       string
 	Password string
+	Role     string `gorm:"size:20;default:user"`
 	Book     []data.Books
+	// PurgedAt diisi saat akun nonaktif dihapus permanen dan dianonimkan.
+	PurgedAt *time.Time `gorm:"index"`
 }
 
 func ToCore(data User) user.Core {
-	return user.Core{
+	res := user.Core{
 		ID:       data.ID,
 		Nama:     data.Nama,
 		Email:    data.Email,
 		Alamat:   data.Alamat,
 		HP:       data.HP,
 		Password: data.Password,
+		Version:  data.Version,
+		Role:     data.Role,
 	}
+	if data.DeletedAt.Valid {
+		res.DeletedAt = data.DeletedAt.Time
+	}
+	return res
 }
 
 func CoreToData(data user.Core) User {
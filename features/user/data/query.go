@@ -1,11 +1,21 @@
 package data
 
 import (
+	book "api/features/book/data"
+	shelf "api/features/shelf/data"
 	"api/features/user"
+	"api/features/webhook"
+	hook "api/features/webhook/data"
+	"api/logger"
+	"api/outbox"
+	"api/uow"
+	"context"
 	"errors"
-	"log"
+	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type userQuery struct {
@@ -18,70 +28,242 @@ func New(db *gorm.DB) user.UserData {
 	}
 }
 
-func (uq *userQuery) Login(email string) (user.Core, error) {
+func (uq *userQuery) Login(ctx context.Context, email string) (user.Core, error) {
 	res := User{}
 
-	if err := uq.db.Where("email = ?", email).First(&res).Error; err != nil {
-		log.Println("login query error", err.Error())
+	if err := uow.DB(ctx, uq.db).Where("email = ?", email).First(&res).Error; err != nil {
+		logger.Error(ctx, "login query error", logger.Fields{"error": err})
 		return user.Core{}, errors.New("data not found")
 	}
 
 	return ToCore(res), nil
 }
-func (uq *userQuery) Register(newUser user.Core) (user.Core, error) {
+func (uq *userQuery) Register(ctx context.Context, newUser user.Core) (user.Core, error) {
 	cnv := CoreToData(newUser)
-	err := uq.db.Create(&cnv).Error
+	cnv.Version = 1
+	err := uow.DB(ctx, uq.db).Create(&cnv).Error
 	if err != nil {
+		logger.Error(ctx, "register query error", logger.Fields{"error": err})
 		return user.Core{}, err
 	}
 
 	newUser.ID = cnv.ID
+	newUser.Version = cnv.Version
 
 	return newUser, nil
 }
-func (uq *userQuery) Profile(id uint) (user.Core, error) {
+func (uq *userQuery) Profile(ctx context.Context, id uint) (user.Core, error) {
 	res := User{}
-	if err := uq.db.Where("id = ?", id).First(&res).Error; err != nil {
-		log.Println("Get By ID query error", err.Error())
+	if err := uow.DB(ctx, uq.db).Where("id = ?", id).First(&res).Error; err != nil {
+		logger.Error(ctx, "get by id query error", logger.Fields{"error": err})
 		return user.Core{}, err
 	}
 
 	return ToCore(res), nil
 }
 
-func (uq *userQuery) Update(UserID uint, updateData user.Core) (user.Core, error) {
+func (uq *userQuery) Update(ctx context.Context, UserID uint, updateData user.Core, fields []string) (user.Core, error) {
 	cnv := CoreToData(updateData)
-	qry := uq.db.Model(&User{}).Where("id = ?", UserID).Updates(&cnv)
+	cnv.ID = 0
+	err := uow.DB(ctx, uq.db).Transaction(func(tx *gorm.DB) error {
+		old, err := lockUser(tx, UserID, updateData.Version)
+		if err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		// Select membuat nilai kosong ikut tersimpan
+		cnv.Version = old.Version + 1
+		columns := append([]string{"Version", "UpdatedAt"}, fields...)
+		if err := tx.Model(&User{}).Where("id = ?", UserID).Select(columns).Updates(&cnv).Error; err != nil {
+			return err
+		}
 
-	affrows := qry.RowsAffected
-	if affrows == 0 {
-		log.Println("no rows affected")
-		return user.Core{}, errors.New("tidak ada data user yang diubah")
+		row := User{}
+		if err := tx.First(&row, UserID).Error; err != nil {
+			return err
+		}
+		return publish(tx, webhook.EventUserUpdated, userEvent{ID: row.ID, Nama: row.Nama, Email: row.Email, Alamat: row.Alamat, HP: row.HP, Version: row.Version})
+	})
+	if err != nil {
+		logger.Error(ctx, "update data by id query error", logger.Fields{"error": err})
+		return user.Core{}, err
 	}
-	err := qry.Error
 
+	return uq.Profile(ctx, UserID)
+}
+
+func (uq *userQuery) Deactive(ctx context.Context, id uint, version uint) error {
+	err := uow.DB(ctx, uq.db).Transaction(func(tx *gorm.DB) error {
+		row, err := lockUser(tx, id, version)
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&User{}, id).Error; err != nil {
+			return err
+		}
+		return publish(tx, webhook.EventUserDeactivated, userEvent{ID: id, Version: row.Version})
+	})
+	if err != nil {
+		logger.Error(ctx, "delete user query error", logger.Fields{"error": err})
+		return err
+	}
+
+	return nil
+}
+
+// inactive memilih akun nonaktif yang belum dihapus permanen.
+const inactive = "deleted_at IS NOT NULL AND purged_at IS NULL"
+
+func (uq *userQuery) Deactivated(ctx context.Context, email string) (user.Core, error) {
+	res := User{}
+	err := uow.DB(ctx, uq.db).Unscoped().Where("email = ? AND "+inactive, email).Order("deleted_at DESC").First(&res).Error
 	if err != nil {
-		log.Println("update data by id query error", err.Error())
+		logger.Error(ctx, "get deactivated user error", logger.Fields{"error": err})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return user.Core{}, errors.New("data not found")
+		}
 		return user.Core{}, err
 	}
-	return ToCore(cnv), nil
+
+	return ToCore(res), nil
 }
 
-func (uq *userQuery) Deactive(id uint) error {
-	qry := uq.db.Delete(&User{}, id)
-	affRow := qry.RowsAffected
+func (uq *userQuery) Restore(ctx context.Context, id uint) (user.Core, error) {
+	err := uow.DB(ctx, uq.db).Transaction(func(tx *gorm.DB) error {
+		row := User{}
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND "+inactive, id).First(&row).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("data not found")
+		}
+		if err != nil {
+			return err
+		}
 
-	if affRow <= 0 {
-		log.Println("no data processed")
-		return errors.New("tidak ada data yang dihapus")
+		// email bisa sudah didaftarkan ulang selama akun nonaktif
+		var active int64
+		if err := tx.Model(&User{}).Where("email = ?", row.Email).Count(&active).Error; err != nil {
+			return err
+		}
+		if active > 0 {
+			return errors.New("duplicated: email sudah dipakai akun lain")
+		}
+
+		err = tx.Unscoped().Model(&User{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		}).Error
+		if err != nil {
+			return err
+		}
+		return publish(tx, webhook.EventUserRestored, userEvent{ID: id, Version: row.Version + 1})
+	})
+	if err != nil {
+		logger.Error(ctx, "restore user query error", logger.Fields{"error": err, "user_id": id})
+		return user.Core{}, err
 	}
 
-	err := qry.Error
+	return uq.Profile(ctx, id)
+}
 
+func (uq *userQuery) Expired(ctx context.Context, before time.Time) ([]uint, error) {
+	res := []uint{}
+	err := uow.DB(ctx, uq.db).Unscoped().Model(&User{}).Where(inactive+" AND deleted_at < ?", before).Pluck("id", &res).Error
 	if err != nil {
-		log.Println("delete user query error", err.Error())
-		return errors.New("tidak dapat menghapus data")
+		logger.Error(ctx, "list expired user error", logger.Fields{"error": err})
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (uq *userQuery) Purge(ctx context.Context, id uint) error {
+	err := uow.DB(ctx, uq.db).Transaction(func(tx *gorm.DB) error {
+		// akun yang dipulihkan setelah Expired dibaca tidak ikut terhapus
+		row := User{}
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ? AND "+inactive, id).First(&row).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("data not found")
+		}
+		if err != nil {
+			return err
+		}
+
+		books := []uint{}
+		if err := tx.Unscoped().Model(&book.Books{}).Where("user_id = ?", id).Pluck("id", &books).Error; err != nil {
+			return err
+		}
+		// ulasan user di buku orang lain tetap ada, namanya ikut dianonimkan
+		if err := book.HardDelete(tx, books); err != nil {
+			return err
+		}
+		deletes := []struct {
+			model interface{}
+			query string
+			args  []interface{}
+		}{
+			{&shelf.Reading{}, "user_id = ?", []interface{}{id}},
+			{&shelf.ShelfBook{}, "shelf_id IN (?)", []interface{}{tx.Model(&shelf.Shelf{}).Select("id").Where("user_id = ?", id)}},
+			{&shelf.Shelf{}, "user_id = ?", []interface{}{id}},
+			{&book.ImportJob{}, "user_id = ?", []interface{}{id}},
+			{&hook.Delivery{}, "subscription_id IN (?)", []interface{}{tx.Model(&hook.Subscription{}).Select("id").Where("user_id = ?", id)}},
+			{&hook.Subscription{}, "user_id = ?", []interface{}{id}},
+			{&outbox.Message{}, "user_id = ?", []interface{}{id}},
+		}
+		for _, d := range deletes {
+			if err := tx.Unscoped().Where(d.query, d.args...).Delete(d.model).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Unscoped().Model(&User{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"nama":      "Pengguna dihapus",
+			"email":     fmt.Sprintf("deleted-%d@invalid", id),
+			"alamat":    "",
+			"hp":        "",
+			"password":  "",
+			"purged_at": time.Now(),
+		}).Error
+	})
+	if err != nil {
+		logger.Error(ctx, "purge user query error", logger.Fields{"error": err, "user_id": id})
+		return err
 	}
 
 	return nil
 }
+
+// userEvent adalah data event akun yang ditulis ke outbox. Profil hanya
+// diisi untuk event user.updated.
+type userEvent struct {
+	ID      uint   `json:"id"`
+	Nama    string `json:"nama,omitempty"`
+	Email   string `json:"email,omitempty"`
+	Alamat  string `json:"alamat,omitempty"`
+	HP      string `json:"hp,omitempty"`
+	Version uint   `json:"version"`
+}
+
+// publish menulis event akun ke outbox di transaksi tx sehingga event hanya
+// diteruskan ke webhook bila perubahannya ikut di-commit.
+func publish(tx *gorm.DB, event string, data userEvent) error {
+	return outbox.Add(tx, event, data.ID, data)
+}
+
+// lockUser mengunci baris user sampai transaksi selesai lalu memastikan
+// versinya masih sama dengan version, 0 berarti tanpa pengecekan.
+func lockUser(tx *gorm.DB, id uint, version uint) (User, error) {
+	row := User{}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return User{}, errors.New("data not found")
+	}
+	if err != nil {
+		return User{}, err
+	}
+	if version != 0 && row.Version != version {
+		return User{}, fmt.Errorf("precondition failed: profil sudah diubah (versi %d)", row.Version)
+	}
+	return row, nil
+}
@@ -0,0 +1,219 @@
+package data_test
+
+import (
+	"api/cache"
+	"api/dbtest"
+	author "api/features/author/data"
+	"api/features/book"
+	bd "api/features/book/data"
+	review "api/features/review/data"
+	shelf "api/features/shelf/data"
+	"api/features/user"
+	"api/features/user/data"
+	hook "api/features/webhook/data"
+	"api/outbox"
+	"api/uow"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newUserData(t *testing.T) (*gorm.DB, user.UserData, book.BookData) {
+	db := dbtest.Open(t, data.User{}, bd.Genre{}, bd.Books{}, author.Author{}, bd.BookAuthor{}, bd.BookRevision{}, bd.ImportJob{},
+		review.Review{}, shelf.Reading{}, shelf.Shelf{}, shelf.ShelfBook{}, outbox.Message{}, hook.Subscription{}, hook.Delivery{})
+	return db, data.New(db), bd.New(db)
+}
+
+// TestLifecycle memastikan buku disembunyikan selama akun nonaktif, muncul
+// lagi saat akun dipulihkan, dan dihapus permanen saat purge.
+func TestLifecycle(t *testing.T) {
+	ctx := context.Background()
+	db, users, books := newUserData(t)
+
+	alif, err := users.Register(ctx, user.Core{Nama: "alif", Email: "alif@be14.com", Password: "rahasia"})
+	require.NoError(t, err)
+	budi, err := users.Register(ctx, user.Core{Nama: "budi", Email: "budi@be14.com"})
+	require.NoError(t, err)
+	b, err := books.Add(ctx, alif.ID, book.Core{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eiichiro Oda", Genre: []string{"manga"}})
+	require.NoError(t, err)
+	require.NoError(t, db.Create(&review.Review{BooksID: b.ID, UserID: budi.ID, Rating: 5}).Error)
+	require.NoError(t, db.Create(&shelf.Reading{UserID: alif.ID, BooksID: b.ID, Status: "reading"}).Error)
+
+	require.NoError(t, users.Deactive(ctx, alif.ID, 0))
+
+	t.Run("buku disembunyikan", func(t *testing.T) {
+		_, err := books.Detail(ctx, b.ID)
+		assert.ErrorContains(t, err, "not found")
+		_, total, err := books.List(ctx, book.Filter{Page: 1, Limit: 10})
+		require.NoError(t, err)
+		assert.Zero(t, total)
+	})
+
+	t.Run("akun dipulihkan", func(t *testing.T) {
+		res, err := users.Deactivated(ctx, "alif@be14.com")
+		require.NoError(t, err)
+		assert.False(t, res.DeletedAt.IsZero())
+
+		res, err = users.Restore(ctx, alif.ID)
+		require.NoError(t, err)
+		assert.Equal(t, alif.Version+1, res.Version)
+		_, err = books.Detail(ctx, b.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("email sudah dipakai akun lain", func(t *testing.T) {
+		require.NoError(t, users.Deactive(ctx, alif.ID, 0))
+		baru, err := users.Register(ctx, user.Core{Nama: "alif baru", Email: "alif@be14.com"})
+		require.NoError(t, err)
+		_, err = users.Restore(ctx, alif.ID)
+		assert.ErrorContains(t, err, "duplicated")
+		require.NoError(t, users.Deactive(ctx, baru.ID, 0))
+	})
+
+	t.Run("purge setelah masa pemulihan", func(t *testing.T) {
+		ids, err := users.Expired(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Contains(t, ids, alif.ID)
+		require.NoError(t, users.Purge(ctx, alif.ID))
+
+		row := data.User{}
+		require.NoError(t, db.Unscoped().First(&row, alif.ID).Error)
+		assert.Equal(t, "Pengguna dihapus", row.Nama)
+		assert.Empty(t, row.Password)
+		assert.NotNil(t, row.PurgedAt)
+
+		var count int64
+		db.Unscoped().Model(&bd.Books{}).Where("user_id = ?", alif.ID).Count(&count)
+		assert.Zero(t, count)
+		db.Model(&review.Review{}).Where("books_id = ?", b.ID).Count(&count)
+		assert.Zero(t, count)
+		db.Model(&shelf.Reading{}).Where("user_id = ?", alif.ID).Count(&count)
+		assert.Zero(t, count)
+		db.Table("book_genres").Where("books_id = ?", b.ID).Count(&count)
+		assert.Zero(t, count)
+
+		_, err = users.Deactivated(ctx, "alif@be14.com")
+		assert.NoError(t, err, "akun pengganti masih bisa dipulihkan")
+		ids, err = users.Expired(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.NotContains(t, ids, alif.ID)
+	})
+}
+
+func TestTransfer(t *testing.T) {
+	ctx := context.Background()
+	_, users, books := newUserData(t)
+
+	alif, err := users.Register(ctx, user.Core{Nama: "alif", Email: "alif@be14.com"})
+	require.NoError(t, err)
+	budi, err := users.Register(ctx, user.Core{Nama: "budi", Email: "budi@be14.com"})
+	require.NoError(t, err)
+	b, err := books.Add(ctx, alif.ID, book.Core{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eiichiro Oda"})
+	require.NoError(t, err)
+	job, err := books.CreateImportJob(ctx, book.ImportJob{UserID: alif.ID, Status: book.ImportRunning})
+	require.NoError(t, err)
+
+	ids, err := books.Transfer(ctx, alif.ID, budi.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{b.ID}, ids)
+	require.NoError(t, books.CancelImports(ctx, alif.ID))
+	require.NoError(t, users.Deactive(ctx, alif.ID, 0))
+
+	res, err := books.Detail(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, budi.ID, res.UserID)
+	assert.Equal(t, b.Version+1, res.Version)
+
+	res2, err := books.ImportJob(ctx, alif.ID, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, book.ImportCancelled, res2.Status)
+	assert.ErrorContains(t, books.UpdateImportJob(ctx, book.ImportJob{ID: job.ID, Status: book.ImportDone}), "cancelled")
+}
+
+// TestCached memastikan profil dibaca dari cache sampai akun diubah lewat
+// UserData yang sama, dan perubahan di dalam unit of work baru menghapus
+// cache setelah di-commit.
+func TestCached(t *testing.T) {
+	ctx := context.Background()
+	db, next, _ := newUserData(t)
+	c := cache.NewMemory(10)
+	users := data.NewCached(next, c, time.Minute)
+	u := uow.New(db, func(tx *gorm.DB) uow.Repos {
+		return uow.Repos{Users: data.NewInvalidating(data.New(tx), c)}
+	})
+
+	alif, err := users.Register(ctx, user.Core{Nama: "alif", Email: "alif@be14.com"})
+	require.NoError(t, err)
+	budi, err := users.Register(ctx, user.Core{Nama: "budi", Email: "budi@be14.com"})
+	require.NoError(t, err)
+
+	t.Run("tanpa unit of work", func(t *testing.T) {
+		_, err = users.Profile(ctx, alif.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, db.Model(&data.User{}).Where("id = ?", alif.ID).Update("nama", "diubah langsung").Error)
+		res, err := users.Profile(ctx, alif.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "alif", res.Nama)
+
+		_, err = users.Update(ctx, alif.ID, user.Core{HP: "0812"}, []string{"HP"})
+		require.NoError(t, err)
+		res, err = users.Profile(ctx, alif.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "diubah langsung", res.Nama)
+		assert.Equal(t, "0812", res.HP)
+
+		require.NoError(t, users.Deactive(ctx, alif.ID, 0))
+		_, err = users.Profile(ctx, alif.ID)
+		assert.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("unit of work di-rollback", func(t *testing.T) {
+		err := u.Do(ctx, func(txCtx context.Context, r uow.Repos) error {
+			if _, err := r.Users.Update(txCtx, budi.ID, user.Core{Nama: "batal"}, []string{"Nama"}); err != nil {
+				return err
+			}
+			// dibaca di dalam transaksi tanpa mengisi cache
+			res, err := users.Profile(txCtx, budi.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "batal", res.Nama)
+
+			// pembacaan bersamaan dari luar transaksi mengisi cache
+			res, err = users.Profile(ctx, budi.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "budi", res.Nama)
+			return errors.New("batal")
+		})
+		assert.ErrorContains(t, err, "batal")
+
+		res, err := users.Profile(ctx, budi.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "budi", res.Nama)
+		assert.Equal(t, budi.Version, res.Version)
+	})
+
+	t.Run("unit of work di-commit", func(t *testing.T) {
+		err := u.Do(ctx, func(txCtx context.Context, r uow.Repos) error {
+			if _, err := r.Users.Update(txCtx, budi.ID, user.Core{Nama: "budi baru"}, []string{"Nama"}); err != nil {
+				return err
+			}
+			// data lama yang masuk cache selama transaksi berjalan dihapus
+			// setelah commit
+			res, err := users.Profile(ctx, budi.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "budi", res.Nama)
+			return nil
+		})
+		require.NoError(t, err)
+
+		res, err := users.Profile(ctx, budi.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "budi baru", res.Nama)
+		assert.Equal(t, budi.Version+1, res.Version)
+	})
+}
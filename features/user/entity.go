@@ -1,14 +1,46 @@
 package user
 
-import "github.com/labstack/echo/v4"
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
 
 type Core struct {
 	ID       uint
-	Nama     string
-	Email    string
+	Nama     string `validate:"required"`
+	Email    string `validate:"required,email"`
 	Alamat   string
 	HP       string
 	Password string
+	// Version naik setiap kali profil berubah. Saat update, Version berisi
+	// versi yang diharapkan client (0 berarti tanpa pengecekan).
+	Version uint
+	// DeletedAt adalah waktu akun dinonaktifkan, kosong bila akun aktif.
+	DeletedAt time.Time
+	// Role bernilai "user" atau "admin". Belum ada endpoint untuk mengubah
+	// role, admin diatur langsung di database.
+	Role string
+}
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+const (
+	BooksHide     = "hide"
+	BooksTransfer = "transfer"
+)
+
+// Deactivation menentukan nasib buku saat akun dinonaktifkan. Books bernilai
+// "hide" (default) agar buku disembunyikan sampai akun dipulihkan, atau
+// "transfer" untuk memindahkan buku ke user dengan email TransferTo.
+type Deactivation struct {
+	Books      string
+	TransferTo string
 }
 
 type UserHandler interface {
@@ -17,20 +49,47 @@ type UserHandler interface {
 	Profile() echo.HandlerFunc
 	Update() echo.HandlerFunc
 	Deactive() echo.HandlerFunc
+	Restore() echo.HandlerFunc
+	Export() echo.HandlerFunc
 }
 
 type UserService interface {
-	Login(email, password string) (string, Core, error)
-	Register(newUser Core) (Core, error)
-	Profile(token interface{}) (Core, error)
-	Update(token interface{}, updateData Core) (Core, error)
-	Deactive(token interface{}) error
+	Login(ctx context.Context, email, password string) (string, Core, error)
+	Register(ctx context.Context, newUser Core) (Core, error)
+	Profile(ctx context.Context, token interface{}) (Core, error)
+	// Update hanya mengubah field Core yang disebut di fields, termasuk yang
+	// dikosongkan, lalu mengembalikan profil utuh hasil baca ulang.
+	Update(ctx context.Context, token interface{}, updateData Core, fields []string) (Core, error)
+	// Deactive menonaktifkan akun bila versinya masih sama dengan version,
+	// 0 berarti tanpa pengecekan versi. Buku diperlakukan sesuai opt dan
+	// import yang belum selesai dibatalkan dalam transaksi yang sama.
+	Deactive(ctx context.Context, token interface{}, version uint, opt Deactivation) error
+	// Restore mengaktifkan kembali akun yang masih dalam masa pemulihan
+	// lalu login seperti Login.
+	Restore(ctx context.Context, email, password string) (string, Core, error)
+	// Purge menghapus permanen akun yang masa pemulihannya sudah lewat dan
+	// mengembalikan jumlah akun yang dihapus.
+	Purge(ctx context.Context) (int, error)
+	// Export memeriksa user lalu mengembalikan fungsi yang menulis profil dan
+	// seluruh buku user ke w sesuai format (json, csv atau zip) secara
+	// bertahap, tanpa menampung semua data di memori.
+	Export(ctx context.Context, token interface{}, format string) (func(w io.Writer) error, error)
 }
 
 type UserData interface {
-	Login(email string) (Core, error)
-	Register(newUser Core) (Core, error)
-	Profile(id uint) (Core, error)
-	Update(id uint, updateData Core) (Core, error)
-	Deactive(id uint) error
+	Login(ctx context.Context, email string) (Core, error)
+	Register(ctx context.Context, newUser Core) (Core, error)
+	Profile(ctx context.Context, id uint) (Core, error)
+	Update(ctx context.Context, id uint, updateData Core, fields []string) (Core, error)
+	Deactive(ctx context.Context, id uint, version uint) error
+	// Deactivated mengembalikan akun nonaktif terakhir dengan email
+	// tersebut yang belum dihapus permanen.
+	Deactivated(ctx context.Context, email string) (Core, error)
+	Restore(ctx context.Context, id uint) (Core, error)
+	// Expired mengembalikan id akun yang dinonaktifkan sebelum before dan
+	// belum dihapus permanen.
+	Expired(ctx context.Context, before time.Time) ([]uint, error)
+	// Purge menghapus buku, rak, webhook dan aktivitas user secara permanen
+	// lalu menganonimkan baris user.
+	Purge(ctx context.Context, id uint) error
 }
@@ -2,7 +2,18 @@ package handler
 
 import (
 	"api/features/user"
+	"api/helper"
+	"api/logger"
+	"api/patch"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
@@ -24,7 +35,7 @@ func (uc *userControll) Login() echo.HandlerFunc {
 			return c.JSON(http.StatusBadRequest, "format inputan salah")
 		}
 
-		token, res, err := uc.srv.Login(input.Email, input.Password)
+		token, res, err := uc.srv.Login(c.Request().Context(), input.Email, input.Password)
 		if err != nil {
 			return c.JSON(PrintErrorResponse(err.Error()))
 		}
@@ -39,7 +50,7 @@ func (uc *userControll) Register() echo.HandlerFunc {
 			return c.JSON(http.StatusBadRequest, "format inputan salah")
 		}
 
-		res, err := uc.srv.Register(*ReqToCore(input))
+		res, err := uc.srv.Register(c.Request().Context(), *ReqToCore(input))
 		if err != nil {
 			return c.JSON(PrintErrorResponse(err.Error()))
 		}
@@ -51,11 +62,15 @@ func (uc *userControll) Profile() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		token := c.Get("user")
 
-		res, err := uc.srv.Profile(token)
+		res, err := uc.srv.Profile(c.Request().Context(), token)
 		if err != nil {
 			return c.JSON(PrintErrorResponse(err.Error()))
 		}
 
+		setETag(c, res)
+		if helper.ETagMatch(c.Request().Header.Get("If-None-Match"), helper.ETag(res.ID, res.Version)) {
+			return c.NoContent(http.StatusNotModified)
+		}
 		return c.JSON(PrintSuccessReponse(http.StatusOK, "berhasil lihat profil", res))
 	}
 }
@@ -63,31 +78,183 @@ func (uc *userControll) Profile() echo.HandlerFunc {
 func (uc *userControll) Update() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		token := c.Get("user")
-		body := UpdateRequest{}
+		version, ok := helper.IfMatchVersion(c.Request().Header.Get("If-Match"), uint(helper.ExtractToken(token)))
+		if !ok {
+			return c.JSON(PrintErrorResponse(errIfMatch))
+		}
 
-		if err := c.Bind(&body); err != nil {
+		ctype, body, err := patchBody(c)
+		if err != nil {
 			return c.JSON(http.StatusBadRequest, "format inputan salah")
 		}
 
-		res, err := uc.srv.Update(token, *ReqToCore(body))
+		// tanpa If-Match, patch diterapkan ulang pada versi terbaru bila
+		// profil berubah di antara baca dan tulis
+		var res user.Core
+		for attempt := 1; ; attempt++ {
+			res, err = uc.patch(c.Request().Context(), token, version, ctype, body)
+			if err == nil || version != 0 || attempt == maxPatchAttempts || !strings.Contains(err.Error(), "precondition") {
+				break
+			}
+		}
 
 		if err != nil {
 			return c.JSON(PrintErrorResponse(err.Error()))
 		}
 
+		setETag(c, res)
 		return c.JSON(PrintSuccessReponse(http.StatusOK, "berhasil update profil", res))
 
 	}
 }
 
+// maxPatchAttempts membatasi percobaan ulang patch tanpa If-Match.
+const maxPatchAttempts = 3
+
+// patchBody membaca body PATCH beserta content type-nya. Form diubah
+// menjadi merge patch, field yang dikirim kosong berarti dikosongkan.
+func patchBody(c echo.Context) (string, []byte, error) {
+	ctype, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if ctype != echo.MIMEApplicationForm {
+		body, err := io.ReadAll(c.Request().Body)
+		return ctype, body, err
+	}
+
+	form, err := c.FormParams()
+	if err != nil {
+		return "", nil, err
+	}
+	doc := map[string]string{}
+	for k := range updateFields {
+		if v, ok := form[k]; ok && len(v) > 0 {
+			doc[k] = v[0]
+		}
+	}
+	body, err := json.Marshal(doc)
+	return patch.MIMEMergePatch, body, err
+}
+
+// patch menerapkan merge patch atau JSON Patch pada profil saat ini lalu
+// menyimpan field yang berubah saja. version 0 berarti patch berlaku untuk
+// versi yang baru dibaca.
+func (uc *userControll) patch(ctx context.Context, token interface{}, version uint, ctype string, body []byte) (user.Core, error) {
+	cur, err := uc.srv.Profile(ctx, token)
+	if err != nil {
+		return user.Core{}, err
+	}
+	if version == 0 {
+		version = cur.Version
+	}
+
+	doc, err := json.Marshal(ToDocument(cur))
+	if err != nil {
+		return user.Core{}, err
+	}
+	patched, err := patch.Apply(ctype, doc, body)
+	if err != nil {
+		return user.Core{}, err
+	}
+	keys, err := patch.Changed(doc, patched)
+	if err != nil {
+		return user.Core{}, err
+	}
+
+	input := UpdateRequest{}
+	dec := json.NewDecoder(bytes.NewReader(patched))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&input); err != nil {
+		return user.Core{}, fmt.Errorf("format patch tidak sesuai: %w", err)
+	}
+
+	fields := make([]string, 0, len(keys))
+	for _, k := range keys {
+		fields = append(fields, updateFields[k])
+	}
+	cnv := ReqToCore(input)
+	cnv.Version = version
+	return uc.srv.Update(ctx, token, *cnv, fields)
+}
+
 func (uc *userControll) Deactive() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		token := c.Get("user")
+		version, ok := helper.IfMatchVersion(c.Request().Header.Get("If-Match"), uint(helper.ExtractToken(token)))
+		if !ok {
+			return c.JSON(PrintErrorResponse(errIfMatch))
+		}
 
-		if err := uc.srv.Deactive(token); err != nil {
+		input := DeactiveRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		opt := user.Deactivation{Books: input.Books, TransferTo: input.TransferTo}
+		if err := uc.srv.Deactive(c.Request().Context(), token, version, opt); err != nil {
 			return c.JSON(PrintErrorResponse(err.Error()))
 		}
 
 		return c.JSON(http.StatusAccepted, "berhasil hapus profil")
 	}
 }
+
+func (uc *userControll) Restore() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := LoginRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		token, res, err := uc.srv.Restore(c.Request().Context(), input.Email, input.Password)
+		if err != nil {
+			return c.JSON(PrintErrorResponse(err.Error()))
+		}
+
+		setETag(c, res)
+		return c.JSON(PrintSuccessReponse(http.StatusOK, "berhasil memulihkan akun", res, token))
+	}
+}
+
+// errIfMatch dikembalikan bila header If-Match bukan etag profil user.
+const errIfMatch = "precondition failed: If-Match tidak sesuai dengan profil"
+
+// setETag menulis versi profil ke header ETag agar client bisa mengirimnya
+// kembali lewat If-Match atau If-None-Match.
+func setETag(c echo.Context, res user.Core) {
+	c.Response().Header().Set("ETag", helper.ETag(res.ID, res.Version))
+}
+
+// exportTypes berisi content type dan ekstensi file untuk setiap format export.
+var exportTypes = map[string][2]string{
+	"json": {echo.MIMEApplicationJSONCharsetUTF8, "json"},
+	"csv":  {"text/csv; charset=utf-8", "csv"},
+	"zip":  {"application/zip", "zip"},
+}
+
+func (uc *userControll) Export() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := ExportRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+		if input.Format == "" {
+			input.Format = "json"
+		}
+
+		write, err := uc.srv.Export(c.Request().Context(), c.Get("user"), input.Format)
+		if err != nil {
+			return c.JSON(PrintErrorResponse(err.Error()))
+		}
+
+		typ := exportTypes[input.Format]
+		filename := fmt.Sprintf("library-export-%s.%s", time.Now().Format("20060102"), typ[1])
+		c.Response().Header().Set(echo.HeaderContentType, typ[0])
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+		c.Response().WriteHeader(http.StatusOK)
+
+		// status sudah terkirim, kegagalan di tengah stream hanya bisa dicatat
+		if err := write(c.Response()); err != nil {
+			logger.Error(c.Request().Context(), "stream export error", logger.Fields{"error": err})
+		}
+		return nil
+	}
+}
@@ -0,0 +1,50 @@
+package handler_test
+
+import (
+	"api/features/user"
+	"api/features/user/handler"
+	"api/mocks"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func restore(srv user.UserService) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/users/restore", strings.NewReader(`{"email":"alif@be14.com","password":"be1422"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	handler.New(srv).Restore()(echo.New().NewContext(req, rec))
+	return rec
+}
+
+func TestRestore(t *testing.T) {
+	t.Run("sukses", func(t *testing.T) {
+		srv := mocks.NewUserService(t)
+		srv.On("Restore", mock.Anything, "alif@be14.com", "be1422").Return("token", user.Core{ID: 1, Version: 3}, nil).Once()
+
+		rec := restore(srv)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"1-3"`, rec.Header().Get("ETag"))
+	})
+
+	t.Run("masa pemulihan berakhir dibedakan dari akun yang tidak ada", func(t *testing.T) {
+		srv := mocks.NewUserService(t)
+		srv.On("Restore", mock.Anything, "alif@be14.com", "be1422").Return("", user.Core{}, errors.New("forbidden: masa pemulihan akun sudah berakhir")).Once()
+		assert.Equal(t, http.StatusForbidden, restore(srv).Code)
+
+		srv.On("Restore", mock.Anything, "alif@be14.com", "be1422").Return("", user.Core{}, errors.New("data tidak ditemukan")).Once()
+		assert.Equal(t, http.StatusNotFound, restore(srv).Code)
+	})
+
+	t.Run("email dipakai akun lain", func(t *testing.T) {
+		srv := mocks.NewUserService(t)
+		srv.On("Restore", mock.Anything, "alif@be14.com", "be1422").Return("", user.Core{}, errors.New("conflict: email sudah dipakai akun lain")).Once()
+		assert.Equal(t, http.StatusConflict, restore(srv).Code)
+	})
+}
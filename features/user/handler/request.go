@@ -3,23 +3,33 @@ package handler
 import "api/features/user"
 
 type LoginRequest struct {
-	Email    string `json:"email" form:"email"`
-	Password string `json:"password" form:"password"`
+	Email    string `json:"email" form:"email" validate:"required"`
+	Password string `json:"password" form:"password" validate:"required"`
 }
 
 type RegisterRequest struct {
-	Nama     string `json:"nama" form:"nama"`
-	Email    string `json:"email" form:"email"`
+	Nama     string `json:"nama" form:"nama" validate:"required"`
+	Email    string `json:"email" form:"email" validate:"required,email"`
 	Alamat   string `json:"alamat" form:"alamat"`
 	HP       string `json:"hp" form:"hp"`
-	Password string `json:"password" form:"password"`
+	Password string `json:"password" form:"password" validate:"required"`
 }
 
+// UpdateRequest adalah dokumen profil yang bisa diubah lewat PATCH. Body
+// merge patch berisi sebagian field, null berarti field dikosongkan.
 type UpdateRequest struct {
-	Nama   string `json:"nama" form:"nama"`
-	Email  string `json:"email" form:"email"`
-	Alamat string `json:"alamat" form:"alamat"`
-	HP     string `json:"hp" form:"hp"`
+	Nama   *string `json:"nama" form:"nama"`
+	Email  *string `json:"email" form:"email" validate:"omitempty,email"`
+	Alamat *string `json:"alamat" form:"alamat"`
+	HP     *string `json:"hp" form:"hp"`
+}
+
+// updateFields memetakan field dokumen UpdateRequest ke field user.Core.
+var updateFields = map[string]string{
+	"nama":   "Nama",
+	"email":  "Email",
+	"alamat": "Alamat",
+	"hp":     "HP",
 }
 
 func ReqToCore(data interface{}) *user.Core {
@@ -39,13 +49,43 @@ func ReqToCore(data interface{}) *user.Core {
 		res.Password = cnv.Password
 	case UpdateRequest:
 		cnv := data.(UpdateRequest)
-		res.Email = cnv.Email
-		res.Nama = cnv.Nama
-		res.Alamat = cnv.Alamat
-		res.HP = cnv.HP
+		res.Email = value(cnv.Email)
+		res.Nama = value(cnv.Nama)
+		res.Alamat = value(cnv.Alamat)
+		res.HP = value(cnv.HP)
 	default:
 		return nil
 	}
 
 	return &res
 }
+
+// ToDocument membuat dokumen UpdateRequest dari profil saat ini, tempat
+// patch diterapkan.
+func ToDocument(data user.Core) UpdateRequest {
+	return UpdateRequest{
+		Nama:   &data.Nama,
+		Email:  &data.Email,
+		Alamat: &data.Alamat,
+		HP:     &data.HP,
+	}
+}
+
+// value mengembalikan nilai pointer, atau "" bila nil.
+func value(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
+
+// DeactiveRequest menentukan nasib buku saat akun dinonaktifkan, buku
+// disembunyikan bila books kosong.
+type DeactiveRequest struct {
+	Books      string `query:"books" validate:"omitempty,oneof=hide transfer"`
+	TransferTo string `query:"transfer_to" validate:"omitempty,email"`
+}
+
+type ExportRequest struct {
+	Format string `query:"format" validate:"omitempty,oneof=json csv zip"`
+}
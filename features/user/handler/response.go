@@ -4,6 +4,7 @@ import (
 	"api/features/user"
 	"net/http"
 	"strings"
+	"time"
 )
 
 type UserReponse struct {
@@ -14,6 +15,36 @@ type UserReponse struct {
 	HP     string `json:"hp"`
 }
 
+// ExportResponse mendokumentasikan isi export format json yang ditulis oleh
+// service. Format csv hanya berisi buku, format zip berisi profile.json,
+// books.csv dan books.jsonl.
+type ExportResponse struct {
+	ExportedAt time.Time            `json:"exported_at"`
+	Profile    UserReponse          `json:"profile"`
+	Books      []ExportBookResponse `json:"books"`
+}
+
+type ExportBookResponse struct {
+	ID            uint                   `json:"id"`
+	Judul         string                 `json:"judul"`
+	TahunTerbit   int                    `json:"tahun_terbit"`
+	Penulis       string                 `json:"penulis"`
+	PenulisID     []uint                 `json:"penulis_id,omitempty"`
+	Authors       []ExportAuthorResponse `json:"authors"`
+	ISBN          string                 `json:"isbn,omitempty"`
+	Penerbit      string                 `json:"penerbit,omitempty"`
+	Bahasa        string                 `json:"bahasa,omitempty"`
+	JumlahHalaman int                    `json:"jumlah_halaman,omitempty"`
+	Deskripsi     string                 `json:"deskripsi,omitempty"`
+	Genre         []string               `json:"genre"`
+	CoverKey      string                 `json:"cover_key,omitempty"`
+}
+
+type ExportAuthorResponse struct {
+	ID   uint   `json:"id"`
+	Nama string `json:"nama"`
+}
+
 func ToResponse(data user.Core) UserReponse {
 	return UserReponse{
 		ID:     data.ID,
@@ -51,6 +82,12 @@ func PrintErrorResponse(msg string) (int, interface{}) {
 		code = http.StatusInternalServerError
 	} else if strings.Contains(msg, "format") {
 		code = http.StatusBadRequest
+	} else if strings.Contains(msg, "precondition") {
+		code = http.StatusPreconditionFailed
+	} else if strings.Contains(msg, "conflict") {
+		code = http.StatusConflict
+	} else if strings.Contains(msg, "forbidden") {
+		code = http.StatusForbidden
 	} else {
 		strings.Contains(msg, "not found")
 		code = http.StatusNotFound
@@ -0,0 +1,252 @@
+package services
+
+import (
+	"api/features/book"
+	"api/features/user"
+	"api/logger"
+	"api/tracing"
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exportPageSize adalah jumlah buku yang dibaca per query saat export.
+const exportPageSize = 100
+
+// bookColumns sama dengan kolom yang diterima import CSV sehingga hasil
+// export bisa diimport kembali.
+var bookColumns = []string{"id", "judul", "tahun_terbit", "penulis", "isbn", "penerbit", "bahasa", "jumlah_halaman", "deskripsi", "genre"}
+
+type exportProfile struct {
+	ID     uint   `json:"id"`
+	Nama   string `json:"nama"`
+	Email  string `json:"email"`
+	Alamat string `json:"alamat"`
+	HP     string `json:"hp"`
+}
+
+type exportAuthor struct {
+	ID   uint   `json:"id"`
+	Nama string `json:"nama"`
+}
+
+type exportBook struct {
+	ID            uint           `json:"id"`
+	Judul         string         `json:"judul"`
+	TahunTerbit   int            `json:"tahun_terbit"`
+	Penulis       string         `json:"penulis"`
+	PenulisID     []uint         `json:"penulis_id,omitempty"`
+	Authors       []exportAuthor `json:"authors"`
+	ISBN          string         `json:"isbn,omitempty"`
+	Penerbit      string         `json:"penerbit,omitempty"`
+	Bahasa        string         `json:"bahasa,omitempty"`
+	JumlahHalaman int            `json:"jumlah_halaman,omitempty"`
+	Deskripsi     string         `json:"deskripsi,omitempty"`
+	Genre         []string       `json:"genre"`
+	CoverKey      string         `json:"cover_key,omitempty"`
+}
+
+func toExportBook(b book.Core) exportBook {
+	res := exportBook{
+		ID:            b.ID,
+		Judul:         b.Judul,
+		TahunTerbit:   b.TahunTerbit,
+		Penulis:       b.Penulis,
+		Authors:       []exportAuthor{},
+		ISBN:          b.ISBN,
+		Penerbit:      b.Penerbit,
+		Bahasa:        b.Bahasa,
+		JumlahHalaman: b.JumlahHalaman,
+		Deskripsi:     b.Deskripsi,
+		Genre:         b.Genre,
+		CoverKey:      b.CoverKey,
+	}
+	for _, a := range b.Authors {
+		res.PenulisID = append(res.PenulisID, a.ID)
+		res.Authors = append(res.Authors, exportAuthor{ID: a.ID, Nama: a.Nama})
+	}
+	if res.Genre == nil {
+		res.Genre = []string{}
+	}
+	return res
+}
+
+func (uuc *userUseCase) Export(ctx context.Context, token interface{}, format string) (func(w io.Writer) error, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Export")
+	defer span.End()
+
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" && format != "zip" {
+		return nil, errors.New("format export tidak sesuai, gunakan json, csv atau zip")
+	}
+
+	// profil dibaca sebelum response dikirim agar error masih bisa
+	// dikembalikan dengan status yang sesuai
+	profile, err := uuc.Profile(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w io.Writer) error {
+		var err error
+		switch format {
+		case "json":
+			err = uuc.exportJSON(ctx, profile, w)
+		case "csv":
+			err = uuc.exportCSV(ctx, profile.ID, w)
+		case "zip":
+			err = uuc.exportZip(ctx, profile, w)
+		}
+		if err != nil {
+			logger.Error(ctx, "export error", logger.Fields{"error": err, "format": format})
+		}
+		return err
+	}, nil
+}
+
+// eachBook membaca buku user per halaman dan memanggil fn untuk setiap buku.
+func (uuc *userUseCase) eachBook(ctx context.Context, userID uint, fn func(book.Core) error) error {
+	for page := 1; ; page++ {
+		rows, _, err := uuc.books.List(ctx, book.Filter{UserID: userID, Page: page, Limit: exportPageSize})
+		if err != nil {
+			return err
+		}
+		for _, r := range rows {
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+		if len(rows) < exportPageSize {
+			return nil
+		}
+	}
+}
+
+func (uuc *userUseCase) exportJSON(ctx context.Context, profile user.Core, w io.Writer) error {
+	head, err := json.Marshal(toExportProfile(profile))
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, `{"exported_at":"`+time.Now().UTC().Format(time.RFC3339)+`","profile":`+string(head)+`,"books":[`); err != nil {
+		return err
+	}
+
+	first := true
+	err = uuc.eachBook(ctx, profile.ID, func(b book.Core) error {
+		line, err := json.Marshal(toExportBook(b))
+		if err != nil {
+			return err
+		}
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		_, err = w.Write(line)
+		flush(w)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "]}\n")
+	return err
+}
+
+func (uuc *userUseCase) exportJSONL(ctx context.Context, userID uint, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	return uuc.eachBook(ctx, userID, func(b book.Core) error {
+		return enc.Encode(toExportBook(b))
+	})
+}
+
+func (uuc *userUseCase) exportCSV(ctx context.Context, userID uint, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(bookColumns); err != nil {
+		return err
+	}
+
+	err := uuc.eachBook(ctx, userID, func(b book.Core) error {
+		err := cw.Write([]string{
+			strconv.FormatUint(uint64(b.ID), 10),
+			b.Judul,
+			strconv.Itoa(b.TahunTerbit),
+			b.Penulis,
+			b.ISBN,
+			b.Penerbit,
+			b.Bahasa,
+			strconv.Itoa(b.JumlahHalaman),
+			b.Deskripsi,
+			strings.Join(b.Genre, "|"),
+		})
+		cw.Flush()
+		flush(w)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportZip menulis profile.json, books.csv dan books.jsonl ke dalam satu
+// arsip zip.
+func (uuc *userUseCase) exportZip(ctx context.Context, profile user.Core, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	f, err := zw.Create("profile.json")
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(toExportProfile(profile)); err != nil {
+		return err
+	}
+
+	if f, err = zw.Create("books.csv"); err != nil {
+		return err
+	}
+	if err := uuc.exportCSV(ctx, profile.ID, f); err != nil {
+		return err
+	}
+
+	if f, err = zw.Create("books.jsonl"); err != nil {
+		return err
+	}
+	if err := uuc.exportJSONL(ctx, profile.ID, f); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+func toExportProfile(data user.Core) exportProfile {
+	return exportProfile{
+		ID:     data.ID,
+		Nama:   data.Nama,
+		Email:  data.Email,
+		Alamat: data.Alamat,
+		HP:     data.HP,
+	}
+}
+
+// flush mengirim data yang sudah ditulis ke client bila w mendukungnya.
+func flush(w io.Writer) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
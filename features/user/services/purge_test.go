@@ -0,0 +1,55 @@
+package services
+
+import (
+	"api/dbtest"
+	"api/features/audit"
+	adata "api/features/audit/data"
+	asrv "api/features/audit/services"
+	author "api/features/author/data"
+	bd "api/features/book/data"
+	review "api/features/review/data"
+	shelf "api/features/shelf/data"
+	"api/features/user"
+	ud "api/features/user/data"
+	hook "api/features/webhook/data"
+	"api/outbox"
+	"api/uow"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// TestPurgeAuditLog memastikan data pribadi user yang sudah dihapus
+// permanen tidak tersisa di audit log, yang tidak bisa diubah setelah
+// dicatat.
+func TestPurgeAuditLog(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t, ud.User{}, bd.Genre{}, bd.Books{}, author.Author{}, bd.BookAuthor{}, bd.BookRevision{}, bd.ImportJob{},
+		review.Review{}, shelf.Reading{}, shelf.Shelf{}, shelf.ShelfBook{}, outbox.Message{}, hook.Subscription{}, hook.Delivery{}, adata.AuditLog{})
+	users, books := ud.New(db), bd.New(db)
+	uw := uow.New(db, func(tx *gorm.DB) uow.Repos {
+		return uow.Repos{Users: ud.New(tx), Books: bd.New(tx)}
+	})
+	srv := New(users, books, uw, 0, asrv.NewRecorder(adata.New(db)))
+
+	alif, err := srv.Register(ctx, user.Core{Nama: "alif", Email: "alif@be14.com", Password: "rahasia123"})
+	require.NoError(t, err)
+	_, err = srv.Update(ctx, validToken(int(alif.ID)), user.Core{Alamat: "Jl. Merdeka 17", HP: "081234567890"}, []string{"Alamat", "HP"})
+	require.NoError(t, err)
+	require.NoError(t, srv.Deactive(ctx, validToken(int(alif.ID)), 0, user.Deactivation{}))
+	n, err := srv.Purge(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	logs := []adata.AuditLog{}
+	require.NoError(t, db.Where("entity = ? AND entity_id = ?", audit.EntityUser, alif.ID).Find(&logs).Error)
+	assert.Len(t, logs, 4)
+	for _, l := range logs {
+		for _, pii := range []string{"alif", "Merdeka", "081234567890"} {
+			assert.NotContains(t, l.Changes, pii, "audit log %s", l.Action)
+		}
+	}
+}
@@ -2,31 +2,66 @@ package services
 
 import (
 	"api/config"
+	"api/features/audit"
+	"api/features/book"
 	"api/features/user"
 	"api/helper"
+	"api/logger"
+	"api/metrics"
+	"api/tracing"
+	"api/uow"
+	"context"
 	"errors"
-	"log"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/golang-jwt/jwt"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// errPrecondition dikembalikan bila If-Match tidak sesuai versi profil.
+const errPrecondition = "precondition failed: profil sudah diubah, muat ulang lalu coba lagi"
+
 type userUseCase struct {
-	qry user.UserData
-	vld *validator.Validate
+	qry   user.UserData
+	books book.BookData
+	// uow dipakai untuk perubahan user dan buku yang harus berhasil atau
+	// gagal bersama.
+	uow uow.UnitOfWork
+	// grace adalah masa akun nonaktif masih bisa dipulihkan sebelum
+	// dihapus permanen oleh Purge.
+	grace time.Duration
+	vld   *validator.Validate
+	audit audit.Recorder
 }
 
-func New(ud user.UserData) user.UserService {
+// New membuat UserService, rec boleh nil, berarti perubahan akun tidak
+// dicatat di audit log.
+func New(ud user.UserData, bd book.BookData, uw uow.UnitOfWork, grace time.Duration, rec audit.Recorder) user.UserService {
 	return &userUseCase{
-		qry: ud,
-		vld: validator.New(),
+		qry:   ud,
+		books: bd,
+		uow:   uw,
+		grace: grace,
+		vld:   validator.New(),
+		audit: rec,
 	}
 }
 
-func (uuc *userUseCase) Login(email, password string) (string, user.Core, error) {
-	res, err := uuc.qry.Login(email)
+// record mencatat perubahan akun ke audit log bila recorder dipasang.
+func (uuc *userUseCase) record(ctx context.Context, action string, id uint, before, after interface{}) {
+	if uuc.audit == nil {
+		return
+	}
+	uuc.audit.Record(ctx, action, audit.EntityUser, id, before, after)
+}
+
+func (uuc *userUseCase) Login(ctx context.Context, email, password string) (string, user.Core, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Login")
+	defer span.End()
+
+	res, err := uuc.qry.Login(ctx, email)
 	if err != nil {
 		msg := ""
 		if strings.Contains(err.Error(), "not found") {
@@ -34,34 +69,46 @@ func (uuc *userUseCase) Login(email, password string) (string, user.Core, error)
 		} else {
 			msg = "terdapat masalah pada server"
 		}
+		metrics.UserLogins.WithLabelValues(metrics.LoginFailed).Inc()
 		return "", user.Core{}, errors.New(msg)
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(res.Password), []byte(password)); err != nil {
-		log.Println("login compare", err.Error())
+		logger.Warn(ctx, "login compare", logger.Fields{"error": err})
+		metrics.UserLogins.WithLabelValues(metrics.LoginFailed).Inc()
 		return "", user.Core{}, errors.New("password tidak sesuai")
 	}
 
+	useToken := signToken(res.ID)
+	metrics.UserLogins.WithLabelValues(metrics.LoginSucceeded).Inc()
+
+	return useToken, res, nil
+
+}
+
+// signToken membuat token JWT untuk user id.
+func signToken(id uint) string {
 	claims := jwt.MapClaims{}
 	claims["authorized"] = true
-	claims["userID"] = res.ID
+	claims["userID"] = id
 	// claims["exp"] = time.Now().Add(time.Hour * 1).Unix() //Token expires after 1 hour
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	useToken, _ := token.SignedString([]byte(config.JWT_KEY))
-
-	return useToken, res, nil
-
+	return useToken
 }
-func (uuc *userUseCase) Register(newUser user.Core) (user.Core, error) {
+func (uuc *userUseCase) Register(ctx context.Context, newUser user.Core) (user.Core, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Register")
+	defer span.End()
+
 	hashed, err := helper.GeneratePassword(newUser.Password)
 
 	if err != nil {
-		log.Println("bcrypt error ", err.Error())
+		logger.Error(ctx, "bcrypt error", logger.Fields{"error": err})
 		return user.Core{}, errors.New("password process error")
 	}
 	newUser.Password = string(hashed)
 	// log.Panic(string(hashed))
-	res, err := uuc.qry.Register(newUser)
+	res, err := uuc.qry.Register(ctx, newUser)
 	if err != nil {
 		msg := ""
 		if strings.Contains(err.Error(), "duplicated") {
@@ -71,15 +118,21 @@ func (uuc *userUseCase) Register(newUser user.Core) (user.Core, error) {
 		}
 		return user.Core{}, errors.New(msg)
 	}
+	metrics.UserRegistrations.Inc()
+	// belum ada token saat mendaftar, pelakunya adalah akun baru itu sendiri
+	uuc.record(logger.WithUserID(ctx, res.ID), audit.ActionCreate, res.ID, nil, res)
 
 	return res, nil
 }
-func (uuc *userUseCase) Profile(token interface{}) (user.Core, error) {
+func (uuc *userUseCase) Profile(ctx context.Context, token interface{}) (user.Core, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Profile")
+	defer span.End()
+
 	id := helper.ExtractToken(token)
 	if id <= 0 {
 		return user.Core{}, errors.New("data tidak ditemukan")
 	}
-	res, err := uuc.qry.Profile(uint(id))
+	res, err := uuc.qry.Profile(ctx, uint(id))
 	if err != nil {
 		msg := ""
 		if strings.Contains(err.Error(), "not found") {
@@ -92,41 +145,96 @@ func (uuc *userUseCase) Profile(token interface{}) (user.Core, error) {
 	return res, nil
 }
 
-func (uuc *userUseCase) Update(token interface{}, updateData user.Core) (user.Core, error) {
+func (uuc *userUseCase) Update(ctx context.Context, token interface{}, updateData user.Core, fields []string) (user.Core, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Update")
+	defer span.End()
+
 	id := helper.ExtractToken(token)
 
 	if id <= 0 {
 		return user.Core{}, errors.New("data not found")
 	}
 
-	res, err := uuc.qry.Update(uint(id), updateData)
+	// hanya field yang diubah yang divalidasi, nama dan email tetap tidak
+	// boleh dikosongkan
+	if len(fields) > 0 {
+		if err := uuc.vld.StructPartial(updateData, fields...); err != nil {
+			return user.Core{}, errors.New("format nama atau email tidak sesuai")
+		}
+	}
+
+	var before interface{}
+	if uuc.audit != nil {
+		if old, err := uuc.qry.Profile(ctx, uint(id)); err == nil {
+			before = old
+		}
+	}
+	res, err := uuc.qry.Update(ctx, uint(id), updateData, fields)
 
 	if err != nil {
 		msg := ""
 		if strings.Contains(err.Error(), "not found") {
 			msg = "data tidak ditemukan"
+		} else if strings.Contains(err.Error(), "precondition") {
+			msg = errPrecondition
 		} else {
 			msg = "terdapat masalah pada server"
 		}
 		return user.Core{}, errors.New(msg)
 
 	}
+	uuc.record(ctx, audit.ActionUpdate, uint(id), before, res)
 
 	return res, nil
 }
 
-func (uuc *userUseCase) Deactive(token interface{}) error {
+func (uuc *userUseCase) Deactive(ctx context.Context, token interface{}, version uint, opt user.Deactivation) error {
+	ctx, span := tracing.Start(ctx, "UserService.Deactive")
+	defer span.End()
+
 	id := helper.ExtractToken(token)
 
 	if id <= 0 {
 		return errors.New("data not found")
 	}
-	err := uuc.qry.Deactive(uint(id))
+	if opt.Books == user.BooksTransfer && opt.TransferTo == "" {
+		return errors.New("format email penerima buku wajib diisi")
+	}
+
+	err := uuc.uow.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+		if opt.Books == user.BooksTransfer {
+			to, err := r.Users.Login(ctx, opt.TransferTo)
+			if err != nil {
+				return errors.New("penerima buku tidak ditemukan")
+			}
+			if to.ID == uint(id) {
+				return errors.New("format penerima buku harus user lain")
+			}
+			ids, err := r.Books.Transfer(ctx, uint(id), to.ID)
+			if err != nil {
+				return err
+			}
+			logger.Info(ctx, "buku dipindahkan", logger.Fields{"to": to.ID, "count": len(ids)})
+		}
+		if err := r.Books.CancelImports(ctx, uint(id)); err != nil {
+			return err
+		}
+		if err := r.Users.Deactive(ctx, uint(id), version); err != nil {
+			return err
+		}
+		// dicatat di dalam transaksi agar ikut batal bila penonaktifan gagal
+		uuc.record(ctx, audit.ActionDeactivate, uint(id), nil, opt)
+		return nil
+	})
 
 	if err != nil {
 		msg := ""
-		if strings.Contains(err.Error(), "not found") {
+		if strings.Contains(err.Error(), "penerima") {
+			msg = err.Error()
+		} else if strings.Contains(err.Error(), "not found") {
 			msg = "data tidak ditemukan"
+		} else if strings.Contains(err.Error(), "precondition") {
+			msg = errPrecondition
 		} else {
 			msg = "terdapat masalah pada server"
 		}
@@ -136,3 +244,68 @@ func (uuc *userUseCase) Deactive(token interface{}) error {
 
 	return nil
 }
+
+func (uuc *userUseCase) Restore(ctx context.Context, email, password string) (string, user.Core, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Restore")
+	defer span.End()
+
+	res, err := uuc.qry.Deactivated(ctx, email)
+	if err != nil {
+		msg := ""
+		if strings.Contains(err.Error(), "not found") {
+			msg = "data tidak ditemukan"
+		} else {
+			msg = "terdapat masalah pada server"
+		}
+		return "", user.Core{}, errors.New(msg)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(res.Password), []byte(password)); err != nil {
+		logger.Warn(ctx, "restore compare", logger.Fields{"error": err})
+		return "", user.Core{}, errors.New("password tidak sesuai")
+	}
+	if time.Since(res.DeletedAt) > uuc.grace {
+		return "", user.Core{}, errors.New("forbidden: masa pemulihan akun sudah berakhir")
+	}
+
+	res, err = uuc.qry.Restore(ctx, res.ID)
+	if err != nil {
+		msg := ""
+		if strings.Contains(err.Error(), "duplicated") {
+			msg = "conflict: email sudah dipakai akun lain"
+		} else if strings.Contains(err.Error(), "not found") {
+			msg = "data tidak ditemukan"
+		} else {
+			msg = "terdapat masalah pada server"
+		}
+		return "", user.Core{}, errors.New(msg)
+	}
+	logger.Info(ctx, "akun dipulihkan", logger.Fields{"user_id": res.ID})
+	uuc.record(logger.WithUserID(ctx, res.ID), audit.ActionRestore, res.ID, nil, res)
+
+	return signToken(res.ID), res, nil
+}
+
+func (uuc *userUseCase) Purge(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Purge")
+	defer span.End()
+
+	ids, err := uuc.qry.Expired(ctx, time.Now().Add(-uuc.grace))
+	if err != nil {
+		return 0, errors.New("terdapat masalah pada server")
+	}
+
+	// satu akun gagal tidak menghentikan akun lain, sisanya dicoba lagi
+	// pada jadwal berikutnya
+	n := 0
+	for _, id := range ids {
+		if err := uuc.qry.Purge(ctx, id); err != nil {
+			logger.Warn(ctx, "purge user gagal", logger.Fields{"error": err, "user_id": id})
+			continue
+		}
+		uuc.record(ctx, audit.ActionPurge, id, nil, nil)
+		n++
+	}
+
+	return n, nil
+}
@@ -1,11 +1,20 @@
 package services
 
 import (
+	"api/features/book"
 	"api/features/user"
 	"api/helper"
 	"api/mocks"
+	"api/uow"
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/stretchr/testify/assert"
@@ -18,9 +27,9 @@ func TestRegister(t *testing.T) {
 	t.Run("Berhasil Register", func(t *testing.T) {
 		inputData := user.Core{Nama: "alif", Email: "alif@be14.com", Alamat: "bangka", HP: "088", Password: "alif123"}
 		resData := user.Core{ID: uint(1), Nama: "alif", Email: "alif@be14.com", Alamat: "bangka", HP: "088"}
-		repo.On("Register", mock.Anything).Return(resData, nil).Once()
-		srv := New(repo)
-		res, err := srv.Register(inputData)
+		repo.On("Register", mock.Anything, mock.Anything).Return(resData, nil).Once()
+		srv := New(repo, nil, nil, 0, nil)
+		res, err := srv.Register(context.Background(), inputData)
 		assert.Nil(t, err)
 		assert.Equal(t, resData.ID, res.ID)
 		assert.Equal(t, resData.Nama, res.Nama)
@@ -32,9 +41,9 @@ func TestRegister(t *testing.T) {
 	t.Run("masalah di server", func(t *testing.T) {
 		inputData := user.Core{Nama: "alif", Email: "alif@be14.com", Alamat: "bangka", HP: "088", Password: "alif123"}
 		resData := user.Core{ID: uint(1), Nama: "alif", Email: "alif@be14.com", Alamat: "bangka", HP: "088"}
-		repo.On("Register", mock.Anything).Return(resData, errors.New("terdapat masalah pada server")).Once()
-		srv := New(repo)
-		res, err := srv.Register(inputData)
+		repo.On("Register", mock.Anything, mock.Anything).Return(resData, errors.New("terdapat masalah pada server")).Once()
+		srv := New(repo, nil, nil, 0, nil)
+		res, err := srv.Register(context.Background(), inputData)
 		assert.NotNil(t, err)
 		assert.Equal(t, uint(0), res.ID)
 		assert.ErrorContains(t, err, "server")
@@ -44,9 +53,9 @@ func TestRegister(t *testing.T) {
 	t.Run("data sudah terdaftar", func(t *testing.T) {
 		inputData := user.Core{Nama: "alif", Email: "alif@be14.com", Alamat: "bangka", HP: "088", Password: "alif123"}
 		// resData := user.Core{ID: uint(1), Nama: "alif", Email: "alif@be14.com", Alamat: "bangka", HP: "088"}
-		repo.On("Register", mock.Anything).Return(user.Core{}, errors.New("duplicated")).Once()
-		srv := New(repo)
-		res, err := srv.Register(inputData)
+		repo.On("Register", mock.Anything, mock.Anything).Return(user.Core{}, errors.New("duplicated")).Once()
+		srv := New(repo, nil, nil, 0, nil)
+		res, err := srv.Register(context.Background(), inputData)
 		assert.NotNil(t, err)
 		assert.Equal(t, uint(0), res.ID)
 		assert.ErrorContains(t, err, "sudah terdaftar")
@@ -64,10 +73,10 @@ func TestLogin(t *testing.T) {
 		hashed, _ := helper.GeneratePassword("be1422")
 		resData := user.Core{ID: uint(1), Nama: "alif", Email: "alif@be14.com", HP: "088888", Password: hashed}
 
-		repo.On("Login", inputEmail).Return(resData, nil) // simulasi method login pada layer data
+		repo.On("Login", mock.Anything, inputEmail).Return(resData, nil).Once() // simulasi method login pada layer data
 
-		srv := New(repo)
-		token, res, err := srv.Login(inputEmail, "be1422")
+		srv := New(repo, nil, nil, 0, nil)
+		token, res, err := srv.Login(context.Background(), inputEmail, "be1422")
 		assert.Nil(t, err)
 		assert.NotEmpty(t, token)
 		assert.Equal(t, resData.ID, res.ID)
@@ -76,10 +85,10 @@ func TestLogin(t *testing.T) {
 
 	t.Run("Tidak ditemukan", func(t *testing.T) {
 		inputEmail := "alif@be14.com"
-		repo.On("Login", inputEmail).Return(user.Core{}, errors.New("data not found"))
+		repo.On("Login", mock.Anything, inputEmail).Return(user.Core{}, errors.New("data not found")).Once()
 
-		srv := New(repo)
-		token, res, err := srv.Login(inputEmail, "be1422")
+		srv := New(repo, nil, nil, 0, nil)
+		token, res, err := srv.Login(context.Background(), inputEmail, "be1422")
 		assert.NotNil(t, err)
 		assert.ErrorContains(t, err, "tidak ditemukan")
 		assert.Empty(t, token)
@@ -91,10 +100,10 @@ func TestLogin(t *testing.T) {
 		inputEmail := "alif@be14.com"
 		hashed, _ := helper.GeneratePassword("be1422")
 		resData := user.Core{ID: uint(1), Nama: "alif", Email: "alif@be14.com", HP: "088888", Password: hashed}
-		repo.On("Login", inputEmail).Return(resData, nil)
+		repo.On("Login", mock.Anything, inputEmail).Return(resData, nil).Once()
 
-		srv := New(repo)
-		token, res, err := srv.Login(inputEmail, "be1423")
+		srv := New(repo, nil, nil, 0, nil)
+		token, res, err := srv.Login(context.Background(), inputEmail, "be1423")
 		assert.NotNil(t, err)
 		assert.ErrorContains(t, err, "password tidak sesuai")
 		assert.Empty(t, token)
@@ -106,10 +115,10 @@ func TestLogin(t *testing.T) {
 		inputEmail := "alif@be14.com"
 		hashed, _ := helper.GeneratePassword("be1422")
 		resData := user.Core{ID: uint(1), Nama: "alif", Email: "alif@be14.com", HP: "088888", Password: hashed}
-		repo.On("Login", inputEmail).Return(resData, errors.New("terdapat masalah pada server")).Once()
+		repo.On("Login", mock.Anything, inputEmail).Return(resData, errors.New("terdapat masalah pada server")).Once()
 
-		srv := New(repo)
-		token, res, err := srv.Login(inputEmail, "be1423")
+		srv := New(repo, nil, nil, 0, nil)
+		token, res, err := srv.Login(context.Background(), inputEmail, "be1423")
 		assert.NotNil(t, err)
 		assert.ErrorContains(t, err, "server")
 		assert.Empty(t, token)
@@ -125,41 +134,41 @@ func TestProfile(t *testing.T) {
 	t.Run("Sukses lihat profile", func(t *testing.T) {
 		resData := user.Core{ID: uint(1), Nama: "alif", Email: "alif@be14.com", HP: "088888"}
 
-		repo.On("Profile", uint(1)).Return(resData, nil).Once()
+		repo.On("Profile", mock.Anything, uint(1)).Return(resData, nil).Once()
 
-		srv := New(repo)
+		srv := New(repo, nil, nil, 0, nil)
 
 		_, token := helper.GenerateJWT(1)
 
 		pToken := token.(*jwt.Token)
 		pToken.Valid = true
 
-		res, err := srv.Profile(pToken)
+		res, err := srv.Profile(context.Background(), pToken)
 		assert.Nil(t, err)
 		assert.Equal(t, resData.ID, res.ID)
 		repo.AssertExpectations(t)
 	})
 
 	t.Run("jwt tidak valid", func(t *testing.T) {
-		srv := New(repo)
+		srv := New(repo, nil, nil, 0, nil)
 
 		_, token := helper.GenerateJWT(1)
 
-		res, err := srv.Profile(token)
+		res, err := srv.Profile(context.Background(), token)
 		assert.NotNil(t, err)
 		assert.ErrorContains(t, err, "tidak ditemukan")
 		assert.Equal(t, uint(0), res.ID)
 	})
 
 	t.Run("data tidak ditemukan", func(t *testing.T) {
-		repo.On("Profile", uint(4)).Return(user.Core{}, errors.New("data not found")).Once()
+		repo.On("Profile", mock.Anything, uint(4)).Return(user.Core{}, errors.New("data not found")).Once()
 
-		srv := New(repo)
+		srv := New(repo, nil, nil, 0, nil)
 
 		_, token := helper.GenerateJWT(4)
 		pToken := token.(*jwt.Token)
 		pToken.Valid = true
-		res, err := srv.Profile(pToken)
+		res, err := srv.Profile(context.Background(), pToken)
 		assert.NotNil(t, err)
 		assert.ErrorContains(t, err, "tidak ditemukan")
 		assert.Equal(t, uint(0), res.ID)
@@ -167,13 +176,13 @@ func TestProfile(t *testing.T) {
 	})
 
 	t.Run("masalah di server", func(t *testing.T) {
-		repo.On("Profile", mock.Anything).Return(user.Core{}, errors.New("terdapat masalah pada server")).Once()
-		srv := New(repo)
+		repo.On("Profile", mock.Anything, mock.Anything).Return(user.Core{}, errors.New("terdapat masalah pada server")).Once()
+		srv := New(repo, nil, nil, 0, nil)
 
 		_, token := helper.GenerateJWT(1)
 		pToken := token.(*jwt.Token)
 		pToken.Valid = true
-		res, err := srv.Profile(pToken)
+		res, err := srv.Profile(context.Background(), pToken)
 		assert.NotNil(t, err)
 		assert.ErrorContains(t, err, "server")
 		assert.Equal(t, uint(0), res.ID)
@@ -188,13 +197,13 @@ func TestUpdate(t *testing.T) {
 		input := user.Core{Nama: "alip", Email: "alip@be14.com", HP: "08888"}
 		hashed, _ := helper.GeneratePassword("be1422")
 		resData := user.Core{ID: uint(1), Nama: "alip", Email: "alip@be14.com", HP: "08888", Password: hashed}
-		repo.On("Update", uint(1), input).Return(resData, nil).Once()
+		repo.On("Update", mock.Anything, uint(1), input, []string{"Nama"}).Return(resData, nil).Once()
 
-		srv := New(repo)
+		srv := New(repo, nil, nil, 0, nil)
 		_, token := helper.GenerateJWT(1)
 		pToken := token.(*jwt.Token)
 		pToken.Valid = true
-		res, err := srv.Update(pToken, input)
+		res, err := srv.Update(context.Background(), pToken, input, []string{"Nama"})
 		assert.Nil(t, err)
 		assert.Equal(t, resData.ID, res.ID)
 		assert.Equal(t, input.Nama, res.Nama)
@@ -206,26 +215,39 @@ func TestUpdate(t *testing.T) {
 
 	t.Run("jwt tidak valid", func(t *testing.T) {
 		input := user.Core{Nama: "alif", Email: "alif@be14.com", HP: "088"}
-		srv := New(repo)
+		srv := New(repo, nil, nil, 0, nil)
 
 		_, token := helper.GenerateJWT(0)
 		pToken := token.(*jwt.Token)
 		pToken.Valid = true
-		res, err := srv.Update(pToken, input)
+		res, err := srv.Update(context.Background(), pToken, input, []string{"Nama"})
 		assert.NotNil(t, err)
 		assert.ErrorContains(t, err, "not found")
 		assert.Equal(t, uint(0), res.ID)
 	})
 
+	t.Run("versi profil sudah berubah", func(t *testing.T) {
+		input := user.Core{Nama: "alif", Version: 1}
+		repo.On("Update", mock.Anything, uint(1), input, []string{"Nama"}).Return(user.Core{}, errors.New("precondition failed: profil sudah diubah (versi 2)")).Once()
+
+		srv := New(repo, nil, nil, 0, nil)
+		_, token := helper.GenerateJWT(1)
+		pToken := token.(*jwt.Token)
+		pToken.Valid = true
+		_, err := srv.Update(context.Background(), pToken, input, []string{"Nama"})
+		assert.ErrorContains(t, err, "precondition")
+		repo.AssertExpectations(t)
+	})
+
 	t.Run("data tidak ditemukan", func(t *testing.T) {
 		input := user.Core{Nama: "alif", Email: "alif@be14.com", HP: "088"}
-		repo.On("Update", uint(2), input).Return(user.Core{}, errors.New("data not found")).Once()
+		repo.On("Update", mock.Anything, uint(2), input, []string{"Nama"}).Return(user.Core{}, errors.New("data not found")).Once()
 
-		srv := New(repo)
+		srv := New(repo, nil, nil, 0, nil)
 		_, token := helper.GenerateJWT(2)
 		pToken := token.(*jwt.Token)
 		pToken.Valid = true
-		res, err := srv.Update(pToken, input)
+		res, err := srv.Update(context.Background(), pToken, input, []string{"Nama"})
 		assert.NotNil(t, err)
 		assert.ErrorContains(t, err, "tidak ditemukan")
 		assert.Equal(t, uint(0), res.ID)
@@ -234,13 +256,13 @@ func TestUpdate(t *testing.T) {
 
 	t.Run("masalah di server", func(t *testing.T) {
 		input := user.Core{Nama: "alif", Email: "alif@be14.com", HP: "088"}
-		repo.On("Update", uint(1), input).Return(user.Core{}, errors.New("terdapat masalah pada server")).Once()
+		repo.On("Update", mock.Anything, uint(1), input, []string{"Nama"}).Return(user.Core{}, errors.New("terdapat masalah pada server")).Once()
 
-		srv := New(repo)
+		srv := New(repo, nil, nil, 0, nil)
 		_, token := helper.GenerateJWT(1)
 		pToken := token.(*jwt.Token)
 		pToken.Valid = true
-		res, err := srv.Update(pToken, input)
+		res, err := srv.Update(context.Background(), pToken, input, []string{"Nama"})
 		assert.NotNil(t, err)
 		assert.ErrorContains(t, err, "server")
 		assert.Equal(t, uint(0), res.ID)
@@ -249,54 +271,266 @@ func TestUpdate(t *testing.T) {
 
 }
 
+// inTx membuat UnitOfWork palsu yang langsung menjalankan fn dengan
+// repository mock.
+func inTx(t *testing.T, users user.UserData, books book.BookData) *mocks.UnitOfWork {
+	uw := mocks.NewUnitOfWork(t)
+	uw.On("Do", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context, uow.Repos) error) error {
+		return fn(ctx, uow.Repos{Users: users, Books: books})
+	}).Maybe()
+	return uw
+}
+
+func validToken(id int) interface{} {
+	_, token := helper.GenerateJWT(id)
+	pToken := token.(*jwt.Token)
+	pToken.Valid = true
+	return pToken
+}
+
 func TestDeactive(t *testing.T) {
-	repo := mocks.NewUserData(t)
+	t.Run("suskes hapus profile, buku disembunyikan", func(t *testing.T) {
+		repo := mocks.NewUserData(t)
+		bookRepo := mocks.NewBookData(t)
+		bookRepo.On("CancelImports", mock.Anything, uint(1)).Return(nil).Once()
+		repo.On("Deactive", mock.Anything, uint(1), uint(0)).Return(nil).Once()
+
+		srv := New(repo, bookRepo, inTx(t, repo, bookRepo), 0, nil)
+		err := srv.Deactive(context.Background(), validToken(1), 0, user.Deactivation{})
+		assert.Nil(t, err)
+		bookRepo.AssertNotCalled(t, "Transfer", mock.Anything, mock.Anything, mock.Anything)
+	})
 
-	t.Run("suskes hapus profile", func(t *testing.T) {
-		repo.On("Deactive", uint(1)).Return(nil).Once()
+	t.Run("buku dipindahkan ke user lain", func(t *testing.T) {
+		repo := mocks.NewUserData(t)
+		bookRepo := mocks.NewBookData(t)
+		repo.On("Login", mock.Anything, "budi@be14.com").Return(user.Core{ID: 2}, nil).Once()
+		bookRepo.On("Transfer", mock.Anything, uint(1), uint(2)).Return([]uint{4, 5, 6}, nil).Once()
+		bookRepo.On("CancelImports", mock.Anything, uint(1)).Return(nil).Once()
+		repo.On("Deactive", mock.Anything, uint(1), uint(0)).Return(nil).Once()
 
-		srv := New(repo)
-		_, token := helper.GenerateJWT(1)
-		pToken := token.(*jwt.Token)
-		pToken.Valid = true
-		err := srv.Deactive(pToken)
+		srv := New(repo, bookRepo, inTx(t, repo, bookRepo), 0, nil)
+		err := srv.Deactive(context.Background(), validToken(1), 0, user.Deactivation{Books: user.BooksTransfer, TransferTo: "budi@be14.com"})
 		assert.Nil(t, err)
-		repo.AssertExpectations(t)
+	})
 
+	t.Run("penerima buku tidak ada", func(t *testing.T) {
+		repo := mocks.NewUserData(t)
+		bookRepo := mocks.NewBookData(t)
+		repo.On("Login", mock.Anything, "budi@be14.com").Return(user.Core{}, errors.New("data not found")).Once()
+
+		srv := New(repo, bookRepo, inTx(t, repo, bookRepo), 0, nil)
+		err := srv.Deactive(context.Background(), validToken(1), 0, user.Deactivation{Books: user.BooksTransfer, TransferTo: "budi@be14.com"})
+		assert.ErrorContains(t, err, "penerima buku tidak ditemukan")
+		repo.AssertNotCalled(t, "Deactive", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("penerima buku diri sendiri", func(t *testing.T) {
+		repo := mocks.NewUserData(t)
+		bookRepo := mocks.NewBookData(t)
+		repo.On("Login", mock.Anything, "alif@be14.com").Return(user.Core{ID: 1}, nil).Once()
+
+		srv := New(repo, bookRepo, inTx(t, repo, bookRepo), 0, nil)
+		err := srv.Deactive(context.Background(), validToken(1), 0, user.Deactivation{Books: user.BooksTransfer, TransferTo: "alif@be14.com"})
+		assert.ErrorContains(t, err, "format")
+	})
+
+	t.Run("transfer tanpa email penerima", func(t *testing.T) {
+		repo := mocks.NewUserData(t)
+		srv := New(repo, nil, inTx(t, repo, nil), 0, nil)
+		err := srv.Deactive(context.Background(), validToken(1), 0, user.Deactivation{Books: user.BooksTransfer})
+		assert.ErrorContains(t, err, "format")
 	})
 
 	t.Run("jwt tidak valid", func(t *testing.T) {
-		srv := New(repo)
+		repo := mocks.NewUserData(t)
+		srv := New(repo, nil, inTx(t, repo, nil), 0, nil)
 
 		_, token := helper.GenerateJWT(1)
-		err := srv.Deactive(token)
+		err := srv.Deactive(context.Background(), token, 0, user.Deactivation{})
 		assert.NotNil(t, err)
 		assert.ErrorContains(t, err, "not found")
 	})
 
 	t.Run("data tidak ditemukan", func(t *testing.T) {
-		repo.On("Deactive", uint(2)).Return(errors.New("data not found")).Once()
+		repo := mocks.NewUserData(t)
+		bookRepo := mocks.NewBookData(t)
+		bookRepo.On("CancelImports", mock.Anything, uint(2)).Return(nil).Once()
+		repo.On("Deactive", mock.Anything, uint(2), uint(0)).Return(errors.New("data not found")).Once()
 
-		srv := New(repo)
-		_, token := helper.GenerateJWT(2)
-		pToken := token.(*jwt.Token)
-		pToken.Valid = true
-		err := srv.Deactive(pToken)
+		srv := New(repo, bookRepo, inTx(t, repo, bookRepo), 0, nil)
+		err := srv.Deactive(context.Background(), validToken(2), 0, user.Deactivation{})
 		assert.NotNil(t, err)
 		assert.ErrorContains(t, err, "tidak ditemukan")
-		repo.AssertExpectations(t)
 	})
 
 	t.Run("masalah di server", func(t *testing.T) {
-		repo.On("Deactive", mock.Anything).Return(errors.New("terdapat masalah pada server")).Once()
+		repo := mocks.NewUserData(t)
+		bookRepo := mocks.NewBookData(t)
+		bookRepo.On("CancelImports", mock.Anything, uint(1)).Return(errors.New("database is locked")).Once()
 
-		srv := New(repo)
-		_, token := helper.GenerateJWT(1)
-		pToken := token.(*jwt.Token)
-		pToken.Valid = true
-		err := srv.Deactive(pToken)
+		srv := New(repo, bookRepo, inTx(t, repo, bookRepo), 0, nil)
+		err := srv.Deactive(context.Background(), validToken(1), 0, user.Deactivation{})
 		assert.NotNil(t, err)
 		assert.ErrorContains(t, err, "server")
-		repo.AssertExpectations(t)
+	})
+}
+
+func TestRestore(t *testing.T) {
+	hashed, _ := helper.GeneratePassword("be1422")
+	grace := 24 * time.Hour
+
+	t.Run("sukses pulihkan akun", func(t *testing.T) {
+		repo := mocks.NewUserData(t)
+		repo.On("Deactivated", mock.Anything, "alif@be14.com").Return(user.Core{ID: 1, Password: hashed, DeletedAt: time.Now().Add(-time.Hour)}, nil).Once()
+		repo.On("Restore", mock.Anything, uint(1)).Return(user.Core{ID: 1, Nama: "alif", Version: 3}, nil).Once()
+
+		srv := New(repo, nil, nil, grace, nil)
+		token, res, err := srv.Restore(context.Background(), "alif@be14.com", "be1422")
+		assert.Nil(t, err)
+		assert.NotEmpty(t, token)
+		assert.Equal(t, uint(3), res.Version)
+	})
+
+	t.Run("password salah", func(t *testing.T) {
+		repo := mocks.NewUserData(t)
+		repo.On("Deactivated", mock.Anything, "alif@be14.com").Return(user.Core{ID: 1, Password: hashed, DeletedAt: time.Now()}, nil).Once()
+
+		srv := New(repo, nil, nil, grace, nil)
+		_, _, err := srv.Restore(context.Background(), "alif@be14.com", "salah")
+		assert.ErrorContains(t, err, "password tidak sesuai")
+		repo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+
+	t.Run("masa pemulihan sudah lewat", func(t *testing.T) {
+		repo := mocks.NewUserData(t)
+		repo.On("Deactivated", mock.Anything, "alif@be14.com").Return(user.Core{ID: 1, Password: hashed, DeletedAt: time.Now().Add(-2 * grace)}, nil).Once()
+
+		srv := New(repo, nil, nil, grace, nil)
+		_, _, err := srv.Restore(context.Background(), "alif@be14.com", "be1422")
+		assert.ErrorContains(t, err, "masa pemulihan")
+		repo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+
+	t.Run("email dipakai akun lain", func(t *testing.T) {
+		repo := mocks.NewUserData(t)
+		repo.On("Deactivated", mock.Anything, "alif@be14.com").Return(user.Core{ID: 1, Password: hashed, DeletedAt: time.Now()}, nil).Once()
+		repo.On("Restore", mock.Anything, uint(1)).Return(user.Core{}, errors.New("duplicated: email sudah dipakai akun lain")).Once()
+
+		srv := New(repo, nil, nil, grace, nil)
+		_, _, err := srv.Restore(context.Background(), "alif@be14.com", "be1422")
+		assert.ErrorContains(t, err, "conflict")
+	})
+
+	t.Run("akun tidak ditemukan", func(t *testing.T) {
+		repo := mocks.NewUserData(t)
+		repo.On("Deactivated", mock.Anything, "alif@be14.com").Return(user.Core{}, errors.New("data not found")).Once()
+
+		srv := New(repo, nil, nil, grace, nil)
+		_, _, err := srv.Restore(context.Background(), "alif@be14.com", "be1422")
+		assert.ErrorContains(t, err, "tidak ditemukan")
+	})
+}
+
+func TestPurge(t *testing.T) {
+	repo := mocks.NewUserData(t)
+	repo.On("Expired", mock.Anything, mock.Anything).Return([]uint{1, 2, 3}, nil).Once()
+	repo.On("Purge", mock.Anything, uint(1)).Return(nil).Once()
+	repo.On("Purge", mock.Anything, uint(2)).Return(errors.New("data not found")).Once()
+	repo.On("Purge", mock.Anything, uint(3)).Return(nil).Once()
+
+	srv := New(repo, nil, nil, time.Hour, nil)
+	n, err := srv.Purge(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func TestExport(t *testing.T) {
+	profile := user.Core{ID: 1, Nama: "alif", Email: "alif@be14.com", Password: "rahasia"}
+	books := []book.Core{
+		{ID: 2, Judul: "Good Omens", TahunTerbit: 1990, Penulis: "Neil Gaiman, Terry Pratchett",
+			Authors: []book.Author{{ID: 3, Nama: "Neil Gaiman"}, {ID: 4, Nama: "Terry Pratchett"}}, Genre: []string{"fantasi", "komedi"}},
+		{ID: 1, Judul: "Laskar Pelangi", TahunTerbit: 2005, Penulis: "Andrea Hirata", ISBN: "9789793062792"},
+	}
+	_, token := helper.GenerateJWT(1)
+	pToken := token.(*jwt.Token)
+	pToken.Valid = true
+
+	newSrv := func(t *testing.T) user.UserService {
+		repo := mocks.NewUserData(t)
+		bookRepo := mocks.NewBookData(t)
+		repo.On("Profile", mock.Anything, uint(1)).Return(profile, nil).Once()
+		bookRepo.On("List", mock.Anything, book.Filter{UserID: 1, Page: 1, Limit: exportPageSize}).Return(books, int64(2), nil)
+		return New(repo, bookRepo, nil, 0, nil)
+	}
+
+	t.Run("format json", func(t *testing.T) {
+		write, err := newSrv(t).Export(context.Background(), pToken, "")
+		assert.Nil(t, err)
+
+		out := bytes.Buffer{}
+		assert.Nil(t, write(&out))
+		res := map[string]interface{}{}
+		assert.Nil(t, json.Unmarshal(out.Bytes(), &res))
+		assert.Equal(t, "alif@be14.com", res["profile"].(map[string]interface{})["email"])
+		assert.NotContains(t, out.String(), "rahasia")
+		assert.Len(t, res["books"], 2)
+		assert.Equal(t, []interface{}{3.0, 4.0}, res["books"].([]interface{})[0].(map[string]interface{})["penulis_id"])
+	})
+
+	t.Run("format csv", func(t *testing.T) {
+		write, err := newSrv(t).Export(context.Background(), pToken, "csv")
+		assert.Nil(t, err)
+
+		out := bytes.Buffer{}
+		assert.Nil(t, write(&out))
+		records, err := csv.NewReader(&out).ReadAll()
+		assert.Nil(t, err)
+		assert.Equal(t, bookColumns, records[0])
+		assert.Equal(t, []string{"2", "Good Omens", "1990", "Neil Gaiman, Terry Pratchett", "", "", "", "0", "", "fantasi|komedi"}, records[1])
+		assert.Len(t, records, 3)
+	})
+
+	t.Run("format zip", func(t *testing.T) {
+		write, err := newSrv(t).Export(context.Background(), pToken, "zip")
+		assert.Nil(t, err)
+
+		out := bytes.Buffer{}
+		assert.Nil(t, write(&out))
+		zr, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+		assert.Nil(t, err)
+		names := []string{}
+		for _, f := range zr.File {
+			names = append(names, f.Name)
+		}
+		assert.Equal(t, []string{"profile.json", "books.csv", "books.jsonl"}, names)
+	})
+
+	t.Run("buku dibaca per halaman", func(t *testing.T) {
+		repo := mocks.NewUserData(t)
+		bookRepo := mocks.NewBookData(t)
+		page := make([]book.Core, exportPageSize)
+		repo.On("Profile", mock.Anything, uint(1)).Return(profile, nil).Once()
+		bookRepo.On("List", mock.Anything, book.Filter{UserID: 1, Page: 1, Limit: exportPageSize}).Return(page, int64(101), nil).Once()
+		bookRepo.On("List", mock.Anything, book.Filter{UserID: 1, Page: 2, Limit: exportPageSize}).Return(books[:1], int64(101), nil).Once()
+
+		write, err := New(repo, bookRepo, nil, 0, nil).Export(context.Background(), pToken, "csv")
+		assert.Nil(t, err)
+		out := bytes.Buffer{}
+		assert.Nil(t, write(&out))
+		assert.Equal(t, exportPageSize+2, strings.Count(out.String(), "\n"))
+	})
+
+	t.Run("format tidak dikenal", func(t *testing.T) {
+		_, err := New(mocks.NewUserData(t), nil, nil, 0, nil).Export(context.Background(), pToken, "xml")
+		assert.ErrorContains(t, err, "format")
+	})
+
+	t.Run("user tidak ditemukan", func(t *testing.T) {
+		repo := mocks.NewUserData(t)
+		repo.On("Profile", mock.Anything, uint(1)).Return(user.Core{}, errors.New("data not found")).Once()
+
+		_, err := New(repo, nil, nil, 0, nil).Export(context.Background(), pToken, "json")
+		assert.ErrorContains(t, err, "tidak ditemukan")
 	})
 }
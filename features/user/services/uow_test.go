@@ -0,0 +1,46 @@
+package services
+
+import (
+	"api/dbtest"
+	author "api/features/author/data"
+	"api/features/book"
+	bd "api/features/book/data"
+	"api/features/user"
+	ud "api/features/user/data"
+	"api/outbox"
+	"api/uow"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// TestDeactiveUnitOfWork memakai database sungguhan untuk memastikan buku
+// yang sudah dipindahkan ikut batal bila penonaktifan akun gagal.
+func TestDeactiveUnitOfWork(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t, ud.User{}, bd.Genre{}, bd.Books{}, author.Author{}, bd.BookAuthor{}, bd.BookRevision{}, bd.ImportJob{}, outbox.Message{})
+	users, books := ud.New(db), bd.New(db)
+	uw := uow.New(db, func(tx *gorm.DB) uow.Repos {
+		return uow.Repos{Users: ud.New(tx), Books: bd.New(tx)}
+	})
+
+	alif, err := users.Register(ctx, user.Core{Nama: "alif", Email: "alif@be14.com"})
+	require.NoError(t, err)
+	_, err = users.Register(ctx, user.Core{Nama: "budi", Email: "budi@be14.com"})
+	require.NoError(t, err)
+	added, err := books.Add(ctx, alif.ID, book.Core{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eiichiro Oda"})
+	require.NoError(t, err)
+
+	srv := New(users, books, uw, 0, nil)
+	err = srv.Deactive(ctx, validToken(int(alif.ID)), 99, user.Deactivation{Books: user.BooksTransfer, TransferTo: "budi@be14.com"})
+	assert.ErrorContains(t, err, "precondition")
+
+	res, err := books.Detail(ctx, added.ID)
+	require.NoError(t, err)
+	assert.Equal(t, alif.ID, res.UserID)
+	_, err = users.Profile(ctx, alif.ID)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,106 @@
+package data
+
+import (
+	"api/features/webhook"
+	"strings"
+	"time"
+)
+
+// Subscription menyimpan event yang dilanggan sebagai daftar dipisah koma.
+type Subscription struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"index"`
+	URL       string `gorm:"size:500"`
+	Events    string `gorm:"size:500"`
+	Secret    string `gorm:"size:64"`
+	CreatedAt time.Time
+}
+
+func (Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// subscribed memeriksa apakah event termasuk event yang dilanggan.
+func (s Subscription) subscribed(event string) bool {
+	for _, e := range strings.Split(s.Events, ",") {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+type Delivery struct {
+	ID             uint `gorm:"primaryKey"`
+	SubscriptionID uint `gorm:"index"`
+	Subscription   Subscription
+	Event          string     `gorm:"size:50"`
+	Payload        string     `gorm:"type:text"`
+	Status         string     `gorm:"size:20;index:idx_delivery_due"`
+	Attempts       int        `gorm:"default:0"`
+	ResponseCode   int        `gorm:"default:0"`
+	LastError      string     `gorm:"size:500"`
+	NextAttempt    time.Time  `gorm:"index:idx_delivery_due"`
+	DeliveredAt    *time.Time `gorm:"default:null"`
+	CreatedAt      time.Time
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func ToCore(data Subscription) webhook.Subscription {
+	return webhook.Subscription{
+		ID:        data.ID,
+		UserID:    data.UserID,
+		URL:       data.URL,
+		Events:    strings.Split(data.Events, ","),
+		Secret:    data.Secret,
+		CreatedAt: data.CreatedAt,
+	}
+}
+
+func CoreToData(data webhook.Subscription) Subscription {
+	return Subscription{
+		ID:     data.ID,
+		UserID: data.UserID,
+		URL:    data.URL,
+		Events: strings.Join(data.Events, ","),
+		Secret: data.Secret,
+	}
+}
+
+func DeliveryToCore(data Delivery) webhook.Delivery {
+	res := webhook.Delivery{
+		ID:             data.ID,
+		SubscriptionID: data.SubscriptionID,
+		Event:          data.Event,
+		Payload:        data.Payload,
+		Status:         data.Status,
+		Attempts:       data.Attempts,
+		ResponseCode:   data.ResponseCode,
+		LastError:      data.LastError,
+		NextAttempt:    data.NextAttempt,
+		CreatedAt:      data.CreatedAt,
+		DeliveredAt:    data.DeliveredAt,
+	}
+	if data.Subscription.ID != 0 {
+		res.Subscription = ToCore(data.Subscription)
+	}
+	return res
+}
+
+func CoreToDelivery(data webhook.Delivery) Delivery {
+	return Delivery{
+		ID:             data.ID,
+		SubscriptionID: data.SubscriptionID,
+		Event:          data.Event,
+		Payload:        data.Payload,
+		Status:         data.Status,
+		Attempts:       data.Attempts,
+		ResponseCode:   data.ResponseCode,
+		LastError:      data.LastError,
+		NextAttempt:    data.NextAttempt,
+		DeliveredAt:    data.DeliveredAt,
+	}
+}
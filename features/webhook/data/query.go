@@ -0,0 +1,240 @@
+package data
+
+import (
+	"api/features/webhook"
+	"api/logger"
+	"api/outbox"
+	"api/uow"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type webhookData struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) webhook.WebhookData {
+	return &webhookData{
+		db: db,
+	}
+}
+
+func (wd *webhookData) Add(ctx context.Context, userID uint, newSub webhook.Subscription) (webhook.Subscription, error) {
+	row := CoreToData(newSub)
+	row.ID = 0
+	row.UserID = userID
+	if err := uow.DB(ctx, wd.db).Create(&row).Error; err != nil {
+		logger.Error(ctx, "add webhook query error", logger.Fields{"error": err})
+		return webhook.Subscription{}, err
+	}
+
+	return ToCore(row), nil
+}
+
+func (wd *webhookData) List(ctx context.Context, userID uint) ([]webhook.Subscription, error) {
+	rows := []Subscription{}
+	if err := uow.DB(ctx, wd.db).Where("user_id = ?", userID).Order("id").Find(&rows).Error; err != nil {
+		logger.Error(ctx, "list webhook query error", logger.Fields{"error": err})
+		return nil, err
+	}
+
+	res := []webhook.Subscription{}
+	for _, r := range rows {
+		res = append(res, ToCore(r))
+	}
+	return res, nil
+}
+
+func (wd *webhookData) Subscription(ctx context.Context, userID, subID uint) (webhook.Subscription, error) {
+	row, err := ownSubscription(uow.DB(ctx, wd.db), userID, subID)
+	if err != nil {
+		logger.Error(ctx, "get webhook error", logger.Fields{"error": err, "webhook_id": subID})
+		return webhook.Subscription{}, err
+	}
+
+	return ToCore(row), nil
+}
+
+func (wd *webhookData) Delete(ctx context.Context, userID, subID uint) error {
+	err := uow.DB(ctx, wd.db).Transaction(func(tx *gorm.DB) error {
+		if _, err := ownSubscription(tx, userID, subID); err != nil {
+			return err
+		}
+		if err := tx.Where("subscription_id = ?", subID).Delete(&Delivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&Subscription{}, subID).Error
+	})
+	if err != nil {
+		logger.Error(ctx, "delete webhook query error", logger.Fields{"error": err, "webhook_id": subID})
+		return err
+	}
+
+	return nil
+}
+
+func (wd *webhookData) Deliveries(ctx context.Context, userID, subID uint, status string, page, limit int) ([]webhook.Delivery, int64, error) {
+	db := uow.DB(ctx, wd.db)
+	if _, err := ownSubscription(db, userID, subID); err != nil {
+		return nil, 0, err
+	}
+
+	qry := db.Model(&Delivery{}).Where("subscription_id = ?", subID)
+	if status != "" {
+		qry = qry.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := qry.Count(&total).Error; err != nil {
+		logger.Error(ctx, "count delivery query error", logger.Fields{"error": err, "webhook_id": subID})
+		return nil, 0, err
+	}
+
+	rows := []Delivery{}
+	if err := qry.Order("id DESC").Offset((page - 1) * limit).Limit(limit).Find(&rows).Error; err != nil {
+		logger.Error(ctx, "list delivery query error", logger.Fields{"error": err, "webhook_id": subID})
+		return nil, 0, err
+	}
+
+	res := []webhook.Delivery{}
+	for _, r := range rows {
+		res = append(res, DeliveryToCore(r))
+	}
+	return res, total, nil
+}
+
+func (wd *webhookData) AddDelivery(ctx context.Context, delivery webhook.Delivery) (webhook.Delivery, error) {
+	row := CoreToDelivery(delivery)
+	row.ID = 0
+	if err := uow.DB(ctx, wd.db).Omit(clause.Associations).Create(&row).Error; err != nil {
+		logger.Error(ctx, "add delivery query error", logger.Fields{"error": err})
+		return webhook.Delivery{}, err
+	}
+
+	return DeliveryToCore(row), nil
+}
+
+func (wd *webhookData) UpdateDelivery(ctx context.Context, delivery webhook.Delivery) error {
+	row := CoreToDelivery(delivery)
+	err := uow.DB(ctx, wd.db).Model(&Delivery{}).Where("id = ?", delivery.ID).
+		Select("status", "attempts", "response_code", "last_error", "next_attempt", "delivered_at").
+		Updates(&row).Error
+	if err != nil {
+		logger.Error(ctx, "update delivery query error", logger.Fields{"error": err, "delivery_id": delivery.ID})
+		return err
+	}
+
+	return nil
+}
+
+func (wd *webhookData) FanOut(ctx context.Context, limit int) (int, error) {
+	n := 0
+	err := uow.DB(ctx, wd.db).Transaction(func(tx *gorm.DB) error {
+		msgs := []outbox.Message{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Order("id").Limit(limit).Find(&msgs).Error
+		if err != nil || len(msgs) == 0 {
+			return err
+		}
+
+		users := make([]uint, 0, len(msgs))
+		ids := make([]uint, 0, len(msgs))
+		for _, m := range msgs {
+			users = append(users, m.UserID)
+			ids = append(ids, m.ID)
+		}
+		subs := []Subscription{}
+		if err := tx.Where("user_id IN ?", users).Find(&subs).Error; err != nil {
+			return err
+		}
+
+		rows := []Delivery{}
+		for _, m := range msgs {
+			body, err := json.Marshal(webhook.Body{ID: m.ID, Event: m.Event, CreatedAt: m.CreatedAt, Data: json.RawMessage(m.Payload)})
+			if err != nil {
+				return err
+			}
+			for _, s := range subs {
+				if s.UserID == m.UserID && s.subscribed(m.Event) {
+					rows = append(rows, Delivery{
+						SubscriptionID: s.ID,
+						Event:          m.Event,
+						Payload:        string(body),
+						Status:         webhook.DeliveryPending,
+						NextAttempt:    m.CreatedAt,
+					})
+				}
+			}
+		}
+		if len(rows) > 0 {
+			if err := tx.Omit(clause.Associations).CreateInBatches(&rows, 100).Error; err != nil {
+				return err
+			}
+		}
+
+		n = len(msgs)
+		return tx.Where("id IN ?", ids).Delete(&outbox.Message{}).Error
+	})
+	if err != nil {
+		logger.Error(ctx, "fan out outbox query error", logger.Fields{"error": err})
+		return 0, err
+	}
+
+	return n, nil
+}
+
+func (wd *webhookData) Due(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]webhook.Delivery, error) {
+	rows := []Delivery{}
+	err := uow.DB(ctx, wd.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt <= ?", webhook.DeliveryPending, now).
+			Order("next_attempt").Limit(limit).Find(&rows).Error
+		if err != nil || len(rows) == 0 {
+			return err
+		}
+
+		ids := make([]uint, 0, len(rows))
+		for _, r := range rows {
+			ids = append(ids, r.ID)
+		}
+		err = tx.Model(&Delivery{}).Where("id IN ?", ids).Update("next_attempt", now.Add(lease)).Error
+		if err != nil {
+			return err
+		}
+		rows = []Delivery{}
+		return tx.Preload("Subscription").Where("id IN ?", ids).Find(&rows).Error
+	})
+	if err != nil {
+		logger.Error(ctx, "claim delivery query error", logger.Fields{"error": err})
+		return nil, err
+	}
+
+	res := []webhook.Delivery{}
+	for _, r := range rows {
+		// subscription bisa terhapus di antara fan out dan klaim
+		if r.Subscription.ID == 0 {
+			continue
+		}
+		res = append(res, DeliveryToCore(r))
+	}
+	return res, nil
+}
+
+// ownSubscription memastikan subscription milik userID. Subscription user
+// lain dianggap tidak ada.
+func ownSubscription(db *gorm.DB, userID, subID uint) (Subscription, error) {
+	row := Subscription{}
+	err := db.Where("id = ? AND user_id = ?", subID, userID).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Subscription{}, errors.New("webhook not found")
+	}
+	if err != nil {
+		return Subscription{}, err
+	}
+	return row, nil
+}
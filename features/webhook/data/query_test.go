@@ -0,0 +1,100 @@
+package data_test
+
+import (
+	"api/dbtest"
+	"api/features/webhook"
+	"api/features/webhook/data"
+	"api/outbox"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.Open(t, outbox.Message{}, data.Subscription{}, data.Delivery{})
+	repo := data.New(db)
+
+	sub, err := repo.Add(ctx, 1, webhook.Subscription{URL: "https://example.com/hook", Events: []string{webhook.EventBookCreated, webhook.EventBookDeleted}, Secret: "rahasia"})
+	require.NoError(t, err)
+	other, err := repo.Add(ctx, 2, webhook.Subscription{URL: "https://example.org/hook", Events: []string{webhook.EventBookCreated}, Secret: "lain"})
+	require.NoError(t, err)
+
+	require.NoError(t, outbox.Add(db, webhook.EventBookCreated, 1, map[string]uint{"id": 10}))
+	require.NoError(t, outbox.Add(db, webhook.EventBookUpdated, 1, map[string]uint{"id": 10}))
+	require.NoError(t, outbox.Add(db, webhook.EventBookCreated, 3, map[string]uint{"id": 11}))
+
+	t.Run("fan out hanya ke subscription pemilik yang melanggan event", func(t *testing.T) {
+		n, err := repo.FanOut(ctx, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 3, n)
+
+		var left int64
+		require.NoError(t, db.Model(&outbox.Message{}).Count(&left).Error)
+		assert.Zero(t, left)
+
+		res, total, err := repo.Deliveries(ctx, 1, sub.ID, "", 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, webhook.EventBookCreated, res[0].Event)
+		assert.Equal(t, webhook.DeliveryPending, res[0].Status)
+
+		body := webhook.Body{}
+		require.NoError(t, json.Unmarshal([]byte(res[0].Payload), &body))
+		assert.Equal(t, webhook.EventBookCreated, body.Event)
+		assert.JSONEq(t, `{"id":10}`, string(body.Data))
+
+		_, total, err = repo.Deliveries(ctx, 2, other.ID, "", 1, 10)
+		require.NoError(t, err)
+		assert.Zero(t, total)
+	})
+
+	t.Run("delivery yang diklaim tidak diambil lagi sampai lease habis", func(t *testing.T) {
+		now := time.Now()
+		due, err := repo.Due(ctx, now, time.Minute, 10)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		assert.Equal(t, "rahasia", due[0].Subscription.Secret)
+
+		again, err := repo.Due(ctx, now, time.Minute, 10)
+		require.NoError(t, err)
+		assert.Empty(t, again)
+
+		delivered := now
+		due[0].Status, due[0].Attempts, due[0].ResponseCode, due[0].DeliveredAt = webhook.DeliverySuccess, 1, 200, &delivered
+		require.NoError(t, repo.UpdateDelivery(ctx, due[0]))
+
+		again, err = repo.Due(ctx, now.Add(2*time.Minute), time.Minute, 10)
+		require.NoError(t, err)
+		assert.Empty(t, again)
+
+		res, _, err := repo.Deliveries(ctx, 1, sub.ID, webhook.DeliverySuccess, 1, 10)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, 200, res[0].ResponseCode)
+	})
+
+	t.Run("subscription user lain dianggap tidak ada", func(t *testing.T) {
+		_, err := repo.Subscription(ctx, 2, sub.ID)
+		assert.ErrorContains(t, err, "not found")
+		_, _, err = repo.Deliveries(ctx, 2, sub.ID, "", 1, 10)
+		assert.ErrorContains(t, err, "not found")
+		assert.ErrorContains(t, repo.Delete(ctx, 2, sub.ID), "not found")
+	})
+
+	t.Run("delete ikut menghapus log delivery", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, 1, sub.ID))
+
+		var left int64
+		require.NoError(t, db.Model(&data.Delivery{}).Where("subscription_id = ?", sub.ID).Count(&left).Error)
+		assert.Zero(t, left)
+
+		res, err := repo.List(ctx, 1)
+		require.NoError(t, err)
+		assert.Empty(t, res)
+	})
+}
@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Event yang bisa dilanggan. Event buku dan akun hanya dikirim ke
+// subscription milik pemilik buku atau akun tersebut. Belum ada event
+// peminjaman buku (book.lent) karena fitur peminjaman belum ada, event itu
+// ditambahkan bersama fiturnya.
+const (
+	EventBookCreated     = "book.created"
+	EventBookUpdated     = "book.updated"
+	EventBookDeleted     = "book.deleted"
+	EventBookRestored    = "book.restored"
+	EventBookPurged      = "book.purged"
+	EventUserUpdated     = "user.updated"
+	EventUserDeactivated = "user.deactivated"
+	EventUserRestored    = "user.restored"
+	// EventPing hanya dikirim lewat endpoint test dan tidak bisa dilanggan.
+	EventPing = "ping"
+)
+
+const (
+	// DeliveryPending menunggu dikirim atau dicoba ulang pada NextAttempt.
+	DeliveryPending = "pending"
+	DeliverySuccess = "success"
+	// DeliveryDead dipakai setelah semua percobaan gagal, delivery tidak
+	// dicoba lagi.
+	DeliveryDead = "dead"
+)
+
+// Subscription adalah URL milik user yang menerima event sesuai Events.
+type Subscription struct {
+	ID     uint
+	UserID uint
+	URL    string   `validate:"required,url,max=500"`
+	Events []string `validate:"required,min=1,max=20,dive,oneof=book.created book.updated book.deleted book.restored book.purged user.updated user.deactivated user.restored"`
+	// Secret dipakai untuk menandatangani payload dengan HMAC-SHA256, hanya
+	// ditampilkan saat subscription dibuat.
+	Secret    string
+	CreatedAt time.Time
+}
+
+// Delivery adalah pengiriman satu event ke satu subscription beserta hasil
+// percobaan terakhirnya. Subscription hanya diisi saat delivery akan
+// dikirim.
+type Delivery struct {
+	ID             uint
+	SubscriptionID uint
+	Subscription   Subscription
+	Event          string
+	// Payload adalah Body dalam bentuk JSON, dikirim apa adanya di setiap
+	// percobaan.
+	Payload      string
+	Status       string
+	Attempts     int
+	ResponseCode int
+	LastError    string
+	NextAttempt  time.Time
+	CreatedAt    time.Time
+	DeliveredAt  *time.Time
+}
+
+// Body adalah isi request yang dikirim ke URL subscription. ID sama di
+// setiap percobaan ulang sehingga penerima bisa mengabaikan event yang
+// sudah pernah diterima, ping selalu ber-ID 0.
+type Body struct {
+	ID        uint            `json:"id"`
+	Event     string          `json:"event"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type WebhookHandler interface {
+	Add() echo.HandlerFunc
+	List() echo.HandlerFunc
+	Delete() echo.HandlerFunc
+	Deliveries() echo.HandlerFunc
+	Test() echo.HandlerFunc
+}
+
+type WebhookService interface {
+	Add(ctx context.Context, token interface{}, newSub Subscription) (Subscription, error)
+	List(ctx context.Context, token interface{}) ([]Subscription, error)
+	Delete(ctx context.Context, token interface{}, subID uint) error
+	// Deliveries mengembalikan satu halaman log delivery subscription,
+	// status kosong berarti semua status.
+	Deliveries(ctx context.Context, token interface{}, subID uint, status string, page, limit int) ([]Delivery, int64, error)
+	// Test langsung mengirim event ping ke subscription sekali tanpa
+	// dicoba ulang dan mengembalikan hasilnya.
+	Test(ctx context.Context, token interface{}, subID uint) (Delivery, error)
+	// Dispatch meneruskan event outbox ke subscription yang cocok lalu
+	// mengirim delivery yang sudah jatuh tempo, mengembalikan jumlah
+	// delivery yang dikirim.
+	Dispatch(ctx context.Context) (int, error)
+}
+
+// WebhookData membatasi subscription dan delivery pada userID, milik user
+// lain dianggap tidak ada.
+type WebhookData interface {
+	Add(ctx context.Context, userID uint, newSub Subscription) (Subscription, error)
+	List(ctx context.Context, userID uint) ([]Subscription, error)
+	Subscription(ctx context.Context, userID, subID uint) (Subscription, error)
+	// Delete menghapus subscription beserta log delivery-nya.
+	Delete(ctx context.Context, userID, subID uint) error
+	Deliveries(ctx context.Context, userID, subID uint, status string, page, limit int) ([]Delivery, int64, error)
+	AddDelivery(ctx context.Context, delivery Delivery) (Delivery, error)
+	UpdateDelivery(ctx context.Context, delivery Delivery) error
+	// FanOut membuat delivery dari maksimal limit event outbox tertua untuk
+	// setiap subscription yang cocok lalu menghapus event tersebut dari
+	// outbox dalam satu transaksi. Mengembalikan jumlah event yang diproses.
+	FanOut(ctx context.Context, limit int) (int, error)
+	// Due mengambil maksimal limit delivery pending yang jatuh tempo pada
+	// now lalu menggeser NextAttempt-nya sejauh lease, sehingga delivery
+	// yang sama tidak diambil dua kali selama sedang dikirim.
+	Due(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Delivery, error)
+}
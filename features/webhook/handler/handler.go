@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"api/features/webhook"
+	"api/helper"
+	"api/logger"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type webhookHandle struct {
+	srv webhook.WebhookService
+}
+
+func New(ws webhook.WebhookService) webhook.WebhookHandler {
+	return &webhookHandle{
+		srv: ws,
+	}
+}
+
+// pathID membaca path parameter id.
+func pathID(c echo.Context) (uint, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		logger.Warn(c.Request().Context(), "convert id error", logger.Fields{"error": err})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (wh *webhookHandle) Add() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		input := WebhookRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, err := wh.srv.Add(c.Request().Context(), c.Get("user"), input.ToCore())
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintSuccessReponse(http.StatusCreated, "sukses menambahkan webhook, simpan secret karena tidak akan ditampilkan lagi", res))
+	}
+}
+
+func (wh *webhookHandle) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		res, err := wh.srv.List(c.Request().Context(), c.Get("user"))
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintSuccessReponse(http.StatusOK, "sukses menampilkan webhook", res))
+	}
+}
+
+func (wh *webhookHandle) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, ok := pathID(c)
+		if !ok {
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		if err := wh.srv.Delete(c.Request().Context(), c.Get("user"), id); err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(http.StatusAccepted, "berhasil delete webhook")
+	}
+}
+
+func (wh *webhookHandle) Deliveries() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, ok := pathID(c)
+		if !ok {
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		input := ListDeliveryRequest{}
+		if err := c.Bind(&input); err != nil {
+			return c.JSON(http.StatusBadRequest, "format inputan salah")
+		}
+
+		res, total, err := wh.srv.Deliveries(c.Request().Context(), c.Get("user"), id, input.Status, input.Page, input.Limit)
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		return c.JSON(PrintListResponse(http.StatusOK, "sukses menampilkan log delivery", res, helper.NewPagination(input.Page, input.Limit, total)))
+	}
+}
+
+func (wh *webhookHandle) Test() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, ok := pathID(c)
+		if !ok {
+			return c.JSON(http.StatusBadRequest, "masukan input sesuai pola")
+		}
+
+		res, err := wh.srv.Test(c.Request().Context(), c.Get("user"), id)
+		if err != nil {
+			return c.JSON(helper.PrintErrorResponse(err.Error()))
+		}
+
+		// ping yang gagal tetap 200, hasilnya dibaca dari status delivery
+		return c.JSON(PrintSuccessReponse(http.StatusOK, "hasil ping webhook", res))
+	}
+}
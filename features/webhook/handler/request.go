@@ -0,0 +1,18 @@
+package handler
+
+import "api/features/webhook"
+
+type WebhookRequest struct {
+	URL    string   `json:"url" validate:"required,url,max=500"`
+	Events []string `json:"events" validate:"required,min=1,max=20,dive,oneof=book.created book.updated book.deleted book.restored book.purged user.updated user.deactivated user.restored"`
+}
+
+type ListDeliveryRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=pending success dead"`
+	Page   int    `query:"page" validate:"gte=1"`
+	Limit  int    `query:"limit" validate:"gte=1,lte=100"`
+}
+
+func (r WebhookRequest) ToCore() webhook.Subscription {
+	return webhook.Subscription{URL: r.URL, Events: r.Events}
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"api/features/webhook"
+	"api/helper"
+	"encoding/json"
+	"time"
+)
+
+type WebhookResponse struct {
+	ID     uint     `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	// Secret hanya terisi pada response pembuatan webhook.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type DeliveryResponse struct {
+	ID           uint        `json:"id"`
+	WebhookID    uint        `json:"webhook_id"`
+	Event        string      `json:"event"`
+	Status       string      `json:"status"`
+	Attempts     int         `json:"attempts"`
+	ResponseCode int         `json:"response_code,omitempty"`
+	LastError    string      `json:"last_error,omitempty"`
+	NextAttempt  *time.Time  `json:"next_attempt,omitempty"`
+	Payload      interface{} `json:"payload"`
+	CreatedAt    time.Time   `json:"created_at"`
+	DeliveredAt  *time.Time  `json:"delivered_at,omitempty"`
+}
+
+func ToResponse(data webhook.Subscription) WebhookResponse {
+	return WebhookResponse{
+		ID:        data.ID,
+		URL:       data.URL,
+		Events:    data.Events,
+		Secret:    data.Secret,
+		CreatedAt: data.CreatedAt,
+	}
+}
+
+func ToDeliveryResponse(data webhook.Delivery) DeliveryResponse {
+	res := DeliveryResponse{
+		ID:           data.ID,
+		WebhookID:    data.SubscriptionID,
+		Event:        data.Event,
+		Status:       data.Status,
+		Attempts:     data.Attempts,
+		ResponseCode: data.ResponseCode,
+		LastError:    data.LastError,
+		Payload:      json.RawMessage(data.Payload),
+		CreatedAt:    data.CreatedAt,
+		DeliveredAt:  data.DeliveredAt,
+	}
+	// jadwal percobaan berikutnya hanya berarti bagi delivery yang belum
+	// selesai
+	if data.Status == webhook.DeliveryPending {
+		next := data.NextAttempt
+		res.NextAttempt = &next
+	}
+	return res
+}
+
+// PrintSuccessReponse menerima webhook.Subscription, []webhook.Subscription
+// atau webhook.Delivery.
+func PrintSuccessReponse(code int, message string, data interface{}) (int, interface{}) {
+	resp := map[string]interface{}{}
+	switch v := data.(type) {
+	case webhook.Subscription:
+		resp["data"] = ToResponse(v)
+	case []webhook.Subscription:
+		res := []WebhookResponse{}
+		for _, s := range v {
+			res = append(res, ToResponse(s))
+		}
+		resp["data"] = res
+	case webhook.Delivery:
+		resp["data"] = ToDeliveryResponse(v)
+	}
+
+	if message != "" {
+		resp["message"] = message
+	}
+
+	return code, resp
+}
+
+func PrintListResponse(code int, message string, data []webhook.Delivery, pagination helper.Pagination) (int, interface{}) {
+	res := []DeliveryResponse{}
+	for _, d := range data {
+		res = append(res, ToDeliveryResponse(d))
+	}
+
+	resp := map[string]interface{}{}
+	resp["data"] = res
+	resp["pagination"] = pagination
+
+	if message != "" {
+		resp["message"] = message
+	}
+
+	return code, resp
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"api/features/webhook"
+	"api/metrics"
+	"api/netguard"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxResponseSize = 64 << 10
+	maxErrorLen     = 500
+)
+
+// Header yang dikirim bersama setiap delivery. Penerima memverifikasi
+// payload dengan menghitung Sign(secret, timestamp, body) lalu
+// membandingkannya dengan HeaderSignature.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Sign menghitung tanda tangan HMAC-SHA256 dari "timestamp.body" dengan
+// secret subscription. Timestamp ikut ditandatangani agar request lama
+// tidak bisa dikirim ulang oleh pihak lain.
+func Sign(secret, timestamp, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver mengirim d sekali lalu mengisi hasilnya. Bila gagal dan retry
+// masih diizinkan, delivery dijadwalkan ulang dengan backoff, selain itu
+// delivery dianggap mati.
+func (ws *webhookSrv) deliver(ctx context.Context, d webhook.Delivery, retry bool) webhook.Delivery {
+	code, err := ws.send(ctx, d)
+	now := ws.now()
+
+	d.Attempts++
+	d.ResponseCode = code
+	d.LastError = ""
+	switch {
+	case err == nil:
+		d.Status = webhook.DeliverySuccess
+		d.DeliveredAt = &now
+	case retry && d.Attempts < maxAttempts:
+		d.Status = webhook.DeliveryPending
+		d.NextAttempt = now.Add(backoff(d.Attempts))
+	default:
+		d.Status = webhook.DeliveryDead
+	}
+	if err != nil {
+		d.LastError = err.Error()
+		// alamat hasil resolve tidak ditampilkan ke pemilik webhook
+		if errors.Is(err, netguard.ErrBlocked) {
+			d.LastError = netguard.ErrBlocked.Error()
+		}
+		if len(d.LastError) > maxErrorLen {
+			d.LastError = d.LastError[:maxErrorLen]
+		}
+	}
+
+	metrics.WebhookDeliveries.WithLabelValues(d.Event, d.Status).Inc()
+	return d
+}
+
+// send mengirim payload ke URL subscription, status selain 2xx dianggap
+// gagal.
+func (ws *webhookSrv) send(ctx context.Context, d webhook.Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.Subscription.URL, strings.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	ts := strconv.FormatInt(ws.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "books-api-webhook")
+	req.Header.Set(HeaderEvent, d.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(d.ID), 10))
+	req.Header.Set(HeaderTimestamp, ts)
+	req.Header.Set(HeaderSignature, Sign(d.Subscription.Secret, ts, d.Payload))
+
+	resp, err := ws.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// body dibaca agar koneksi bisa dipakai ulang
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff adalah jeda sebelum percobaan berikutnya setelah attempts kali
+// gagal, berlipat dua dari baseBackoff dan dibatasi maxBackoff.
+func backoff(attempts int) time.Duration {
+	d := baseBackoff
+	for i := 1; i < attempts && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		return maxBackoff
+	}
+	return d
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"api/features/webhook"
+	"api/helper"
+	"api/logger"
+	"api/netguard"
+	"api/tracing"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// maxSubscriptions membatasi jumlah webhook per user.
+	maxSubscriptions = 10
+	// maxAttempts adalah jumlah percobaan sebelum delivery dianggap mati.
+	// Dengan backoff 30 detik yang berlipat dua, percobaan terakhir
+	// dilakukan sekitar satu jam setelah event terjadi.
+	maxAttempts = 8
+	baseBackoff = 30 * time.Second
+	maxBackoff  = 6 * time.Hour
+	// dispatchBatch adalah jumlah event outbox dan delivery yang diproses
+	// setiap kali Dispatch dijalankan.
+	dispatchBatch = 100
+	// deliveryLease harus lebih lama dari timeout client agar delivery
+	// yang sedang dikirim tidak diambil lagi.
+	deliveryLease   = time.Minute
+	deliveryWorkers = 4
+)
+
+type webhookSrv struct {
+	data     webhook.WebhookData
+	client   *http.Client
+	resolver netguard.Resolver
+	vld      *validator.Validate
+	now      func() time.Time
+}
+
+// New membuat WebhookService, client dipakai untuk mengirim delivery dan
+// sebaiknya memiliki timeout yang lebih pendek dari deliveryLease serta
+// menolak koneksi ke alamat internal (lihat netguard.Transport).
+func New(wd webhook.WebhookData, client *http.Client) webhook.WebhookService {
+	return &webhookSrv{
+		data:     wd,
+		client:   client,
+		resolver: net.DefaultResolver,
+		vld:      validator.New(),
+		now:      time.Now,
+	}
+}
+
+func (ws *webhookSrv) Add(ctx context.Context, token interface{}, newSub webhook.Subscription) (webhook.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Add")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return webhook.Subscription{}, errors.New("user not found")
+	}
+	newSub.Events = uniqueEvents(newSub.Events)
+	if err := ws.vld.Struct(newSub); err != nil || !httpURL(newSub.URL) {
+		return webhook.Subscription{}, errors.New("format input webhook tidak sesuai, url harus http atau https dan event wajib diisi dengan event yang dikenal")
+	}
+	// alamat diperiksa lagi oleh client setiap kali dikirim karena hasil
+	// DNS bisa berubah
+	u, _ := url.Parse(newSub.URL)
+	if err := netguard.CheckHost(ctx, ws.resolver, u.Hostname()); err != nil {
+		logger.Warn(ctx, "webhook url ditolak", logger.Fields{"error": err, "url": newSub.URL})
+		return webhook.Subscription{}, errors.New("format url webhook tidak sesuai, host harus bisa di-resolve ke alamat publik")
+	}
+
+	subs, err := ws.data.List(ctx, uint(userID))
+	if err != nil {
+		return webhook.Subscription{}, errors.New("terjadi kesalahan pada server")
+	}
+	if len(subs) >= maxSubscriptions {
+		return webhook.Subscription{}, errors.New("conflict: maksimal 10 webhook per user")
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		logger.Error(ctx, "generate webhook secret error", logger.Fields{"error": err})
+		return webhook.Subscription{}, errors.New("terjadi kesalahan pada server")
+	}
+	newSub.Secret = hex.EncodeToString(secret)
+
+	res, err := ws.data.Add(ctx, uint(userID), newSub)
+	if err != nil {
+		return webhook.Subscription{}, errors.New("terjadi kesalahan pada server")
+	}
+	return res, nil
+}
+
+func (ws *webhookSrv) List(ctx context.Context, token interface{}) ([]webhook.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.List")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return nil, errors.New("user not found")
+	}
+
+	res, err := ws.data.List(ctx, uint(userID))
+	if err != nil {
+		return nil, errors.New("terjadi kesalahan pada server")
+	}
+	// secret hanya ditampilkan sekali saat webhook dibuat
+	for i := range res {
+		res[i].Secret = ""
+	}
+	return res, nil
+}
+
+func (ws *webhookSrv) Delete(ctx context.Context, token interface{}, subID uint) error {
+	ctx, span := tracing.Start(ctx, "WebhookService.Delete")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return errors.New("user not found")
+	}
+
+	if err := ws.data.Delete(ctx, uint(userID), subID); err != nil {
+		return errorMsg(err)
+	}
+	return nil
+}
+
+func (ws *webhookSrv) Deliveries(ctx context.Context, token interface{}, subID uint, status string, page, limit int) ([]webhook.Delivery, int64, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Deliveries")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return nil, 0, errors.New("user not found")
+	}
+	if err := ws.vld.Var(status, "omitempty,oneof=pending success dead"); err != nil {
+		return nil, 0, errors.New("format status tidak sesuai")
+	}
+
+	page, limit = helper.PageLimit(page, limit)
+	res, total, err := ws.data.Deliveries(ctx, uint(userID), subID, status, page, limit)
+	if err != nil {
+		return nil, 0, errorMsg(err)
+	}
+	return res, total, nil
+}
+
+func (ws *webhookSrv) Test(ctx context.Context, token interface{}, subID uint) (webhook.Delivery, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Test")
+	defer span.End()
+
+	userID := helper.ExtractToken(token)
+	if userID <= 0 {
+		return webhook.Delivery{}, errors.New("user not found")
+	}
+
+	sub, err := ws.data.Subscription(ctx, uint(userID), subID)
+	if err != nil {
+		return webhook.Delivery{}, errorMsg(err)
+	}
+
+	now := ws.now()
+	data, _ := json.Marshal(map[string]uint{"webhook_id": sub.ID})
+	body, _ := json.Marshal(webhook.Body{Event: webhook.EventPing, CreatedAt: now, Data: data})
+	// ping dicatat di log delivery seperti event lain, NextAttempt digeser
+	// agar tidak ikut diambil Dispatch selama sedang dikirim
+	d, err := ws.data.AddDelivery(ctx, webhook.Delivery{
+		SubscriptionID: sub.ID,
+		Event:          webhook.EventPing,
+		Payload:        string(body),
+		Status:         webhook.DeliveryPending,
+		NextAttempt:    now.Add(deliveryLease),
+	})
+	if err != nil {
+		return webhook.Delivery{}, errors.New("terjadi kesalahan pada server")
+	}
+
+	d.Subscription = sub
+	d = ws.deliver(ctx, d, false)
+	if err := ws.data.UpdateDelivery(ctx, d); err != nil {
+		return webhook.Delivery{}, errors.New("terjadi kesalahan pada server")
+	}
+	d.Subscription.Secret = ""
+	return d, nil
+}
+
+func (ws *webhookSrv) Dispatch(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Dispatch")
+	defer span.End()
+
+	if _, err := ws.data.FanOut(ctx, dispatchBatch); err != nil {
+		return 0, err
+	}
+	due, err := ws.data.Due(ctx, ws.now(), deliveryLease, dispatchBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	sem := make(chan struct{}, deliveryWorkers)
+	wg := sync.WaitGroup{}
+	for _, d := range due {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(d webhook.Delivery) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			d = ws.deliver(ctx, d, true)
+			// error sudah dicatat data, delivery akan diambil lagi setelah
+			// lease habis
+			_ = ws.data.UpdateDelivery(ctx, d)
+			if d.Status == webhook.DeliveryDead {
+				logger.Warn(ctx, "webhook delivery gagal permanen", logger.Fields{"delivery_id": d.ID, "webhook_id": d.SubscriptionID, "error": d.LastError})
+			}
+		}(d)
+	}
+	wg.Wait()
+
+	return len(due), nil
+}
+
+// uniqueEvents membuang event kosong dan event yang disebut lebih dari
+// sekali dengan urutan tetap.
+func uniqueEvents(events []string) []string {
+	res := []string{}
+	seen := map[string]bool{}
+	for _, e := range events {
+		e = strings.TrimSpace(e)
+		if e == "" || seen[e] {
+			continue
+		}
+		seen[e] = true
+		res = append(res, e)
+	}
+	return res
+}
+
+// httpURL memastikan URL memakai http atau https dan memiliki host.
+func httpURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// errorMsg meneruskan pesan "not found" dari data apa adanya, selain itu
+// dianggap kesalahan server.
+func errorMsg(err error) error {
+	if strings.Contains(err.Error(), "not found") {
+		return err
+	}
+	return errors.New("terjadi kesalahan pada server")
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"api/features/webhook"
+	"api/helper"
+	"api/mocks"
+	"api/netguard"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func validToken() interface{} {
+	_, token := helper.GenerateJWT(1)
+	pToken := token.(*jwt.Token)
+	pToken.Valid = true
+	return pToken
+}
+
+type resolver map[string]string
+
+func (r resolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	ip, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return []net.IPAddr{{IP: net.ParseIP(ip)}}, nil
+}
+
+func TestAdd(t *testing.T) {
+	data := mocks.NewWebhookData(t)
+	srv := New(data, http.DefaultClient).(*webhookSrv)
+	srv.resolver = resolver{"example.com": "93.184.216.34", "internal.example.com": "10.0.0.5"}
+
+	t.Run("url bukan http", func(t *testing.T) {
+		_, err := srv.Add(context.Background(), validToken(), webhook.Subscription{URL: "ftp://example.com", Events: []string{webhook.EventBookCreated}})
+		assert.ErrorContains(t, err, "format")
+	})
+
+	t.Run("event tidak dikenal", func(t *testing.T) {
+		_, err := srv.Add(context.Background(), validToken(), webhook.Subscription{URL: "https://example.com", Events: []string{webhook.EventPing}})
+		assert.ErrorContains(t, err, "format")
+		_, err = srv.Add(context.Background(), validToken(), webhook.Subscription{URL: "https://example.com", Events: []string{" "}})
+		assert.ErrorContains(t, err, "format")
+	})
+
+	t.Run("alamat internal ditolak", func(t *testing.T) {
+		urls := []string{"http://127.0.0.1:8000/hook", "http://[::1]/hook", "http://169.254.169.254/latest/meta-data",
+			"https://internal.example.com/hook", "https://tidak-ada.example.com/hook"}
+		for _, u := range urls {
+			_, err := srv.Add(context.Background(), validToken(), webhook.Subscription{URL: u, Events: []string{webhook.EventBookCreated}})
+			assert.ErrorContains(t, err, "format url", u)
+		}
+	})
+
+	t.Run("batas jumlah webhook", func(t *testing.T) {
+		data.On("List", mock.Anything, uint(1)).Return(make([]webhook.Subscription, maxSubscriptions), nil).Once()
+		_, err := srv.Add(context.Background(), validToken(), webhook.Subscription{URL: "https://example.com", Events: []string{webhook.EventBookCreated}})
+		assert.ErrorContains(t, err, "conflict")
+	})
+
+	t.Run("sukses dengan secret baru", func(t *testing.T) {
+		data.On("List", mock.Anything, uint(1)).Return([]webhook.Subscription{}, nil).Once()
+		data.On("Add", mock.Anything, uint(1), mock.MatchedBy(func(s webhook.Subscription) bool {
+			return len(s.Secret) == 64 && len(s.Events) == 1
+		})).Return(webhook.Subscription{ID: 1, Secret: "s"}, nil).Once()
+
+		res, err := srv.Add(context.Background(), validToken(), webhook.Subscription{URL: "https://example.com", Events: []string{webhook.EventBookCreated, webhook.EventBookCreated}})
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), res.ID)
+	})
+}
+
+func TestDispatch(t *testing.T) {
+	now := time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC)
+	payload := `{"id":1,"event":"book.created","created_at":"2024-03-10T08:00:00Z","data":{"id":10}}`
+
+	var got *http.Request
+	var gotBody string
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		got, gotBody = r, string(b)
+	}))
+	defer ok.Close()
+	fail := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer fail.Close()
+
+	data := mocks.NewWebhookData(t)
+	srv := &webhookSrv{data: data, client: http.DefaultClient, now: func() time.Time { return now }}
+
+	due := []webhook.Delivery{
+		{ID: 1, Event: webhook.EventBookCreated, Payload: payload, Status: webhook.DeliveryPending, Subscription: webhook.Subscription{URL: ok.URL, Secret: "rahasia"}},
+		{ID: 2, Event: webhook.EventBookCreated, Payload: payload, Status: webhook.DeliveryPending, Subscription: webhook.Subscription{URL: fail.URL}},
+		{ID: 3, Event: webhook.EventBookCreated, Payload: payload, Status: webhook.DeliveryPending, Attempts: maxAttempts - 1, Subscription: webhook.Subscription{URL: fail.URL}},
+	}
+	data.On("FanOut", mock.Anything, dispatchBatch).Return(1, nil).Once()
+	data.On("Due", mock.Anything, now, deliveryLease, dispatchBatch).Return(due, nil).Once()
+
+	mu := sync.Mutex{}
+	updated := map[uint]webhook.Delivery{}
+	data.On("UpdateDelivery", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		d := args.Get(1).(webhook.Delivery)
+		mu.Lock()
+		updated[d.ID] = d
+		mu.Unlock()
+	}).Return(nil).Times(3)
+
+	n, err := srv.Dispatch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	t.Run("payload ditandatangani", func(t *testing.T) {
+		require.NotNil(t, got)
+		assert.Equal(t, payload, gotBody)
+		assert.Equal(t, webhook.EventBookCreated, got.Header.Get(HeaderEvent))
+		assert.Equal(t, "1", got.Header.Get(HeaderDelivery))
+		assert.Equal(t, "1710057600", got.Header.Get(HeaderTimestamp))
+		assert.Equal(t, Sign("rahasia", "1710057600", payload), got.Header.Get(HeaderSignature))
+	})
+
+	t.Run("status delivery", func(t *testing.T) {
+		assert.Equal(t, webhook.DeliverySuccess, updated[1].Status)
+		assert.Equal(t, &now, updated[1].DeliveredAt)
+
+		assert.Equal(t, webhook.DeliveryPending, updated[2].Status)
+		assert.Equal(t, 1, updated[2].Attempts)
+		assert.Equal(t, http.StatusInternalServerError, updated[2].ResponseCode)
+		assert.Equal(t, now.Add(baseBackoff), updated[2].NextAttempt)
+
+		assert.Equal(t, webhook.DeliveryDead, updated[3].Status)
+		assert.Equal(t, maxAttempts, updated[3].Attempts)
+		assert.Equal(t, "status 500", updated[3].LastError)
+	})
+}
+
+func TestTest(t *testing.T) {
+	fail := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer fail.Close()
+
+	data := mocks.NewWebhookData(t)
+	srv := New(data, http.DefaultClient)
+
+	t.Run("webhook tidak ditemukan", func(t *testing.T) {
+		data.On("Subscription", mock.Anything, uint(1), uint(9)).Return(webhook.Subscription{}, assert.AnError).Once()
+		_, err := srv.Test(context.Background(), validToken(), 9)
+		assert.ErrorContains(t, err, "server")
+	})
+
+	t.Run("ping gagal tidak dicoba ulang", func(t *testing.T) {
+		data.On("Subscription", mock.Anything, uint(1), uint(2)).Return(webhook.Subscription{ID: 2, URL: fail.URL, Secret: "rahasia"}, nil).Once()
+		data.On("AddDelivery", mock.Anything, mock.MatchedBy(func(d webhook.Delivery) bool {
+			return d.Event == webhook.EventPing && d.SubscriptionID == 2
+		})).Return(webhook.Delivery{ID: 5, SubscriptionID: 2, Event: webhook.EventPing, Status: webhook.DeliveryPending}, nil).Once()
+		data.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(d webhook.Delivery) bool {
+			return d.ID == 5 && d.Status == webhook.DeliveryDead
+		})).Return(nil).Once()
+
+		res, err := srv.Test(context.Background(), validToken(), 2)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.DeliveryDead, res.Status)
+		assert.Equal(t, http.StatusNotFound, res.ResponseCode)
+		assert.Empty(t, res.Subscription.Secret)
+	})
+}
+
+func TestBlockedDelivery(t *testing.T) {
+	srv := &webhookSrv{client: &http.Client{Transport: netguard.Transport(time.Second)}, now: time.Now}
+	d := srv.deliver(context.Background(), webhook.Delivery{ID: 1, Event: webhook.EventPing, Subscription: webhook.Subscription{URL: "http://127.0.0.1:1/hook"}}, false)
+
+	assert.Equal(t, webhook.DeliveryDead, d.Status)
+	assert.Zero(t, d.ResponseCode)
+	// alamat tujuan tidak dibocorkan ke pemilik webhook
+	assert.Equal(t, netguard.ErrBlocked.Error(), d.LastError)
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, backoff(1))
+	assert.Equal(t, time.Minute, backoff(2))
+	assert.Equal(t, 32*time.Minute, backoff(7))
+	assert.Equal(t, maxBackoff, backoff(20))
+}
@@ -0,0 +1,105 @@
+package health
+
+import (
+	"api/logger"
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultTimeout = 2 * time.Second
+
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+)
+
+// Check adalah satu dependensi yang diperiksa oleh readiness probe.
+type Check struct {
+	Name    string
+	Timeout time.Duration
+	Fn      func(ctx context.Context) error
+}
+
+type Handler struct {
+	checks       []Check
+	shuttingDown atomic.Bool
+}
+
+func New(checks ...Check) *Handler {
+	return &Handler{
+		checks: checks,
+	}
+}
+
+// SetShuttingDown membuat readiness probe gagal sehingga orchestrator
+// berhenti mengirim trafik sebelum server dimatikan.
+func (h *Handler) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+func (h *Handler) Liveness() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]interface{}{"status": statusOK})
+	}
+}
+
+func (h *Handler) Readiness() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if h.shuttingDown.Load() {
+			return c.JSON(http.StatusServiceUnavailable, map[string]interface{}{"status": "shutting down"})
+		}
+
+		results := h.run(c.Request().Context())
+		code, status := http.StatusOK, "ready"
+		for _, res := range results {
+			if res != statusOK {
+				code, status = http.StatusServiceUnavailable, "not ready"
+				break
+			}
+		}
+
+		return c.JSON(code, map[string]interface{}{
+			"status": status,
+			"checks": results,
+		})
+	}
+}
+
+// run menjalankan semua check secara paralel. Probe bisa dipanggil siapa
+// saja, jadi error check hanya dicatat di log dan hasilnya cukup
+// "unavailable" agar detail driver dan host database tidak terbuka.
+func (h *Handler) run(ctx context.Context) map[string]string {
+	results := make(map[string]string, len(h.checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, chk := range h.checks {
+		wg.Add(1)
+		go func(chk Check) {
+			defer wg.Done()
+			timeout := chk.Timeout
+			if timeout <= 0 {
+				timeout = defaultTimeout
+			}
+			cctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			res := statusOK
+			if err := chk.Fn(cctx); err != nil {
+				logger.Warn(ctx, "readiness check gagal", logger.Fields{"check": chk.Name, "error": err})
+				res = statusUnavailable
+			}
+			mu.Lock()
+			results[chk.Name] = res
+			mu.Unlock()
+		}(chk)
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,81 @@
+package health_test
+
+import (
+	"api/health"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type readiness struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+func ready(t *testing.T, h *health.Handler) (int, readiness) {
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/readyz", nil), rec)
+	require.NoError(t, h.Readiness()(c))
+
+	res := readiness{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	return rec.Code, res
+}
+
+func ok(ctx context.Context) error { return nil }
+
+func TestReadiness(t *testing.T) {
+	t.Run("semua check berhasil", func(t *testing.T) {
+		code, res := ready(t, health.New(health.Check{Name: "database", Fn: ok}))
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "ready", res.Status)
+		assert.Equal(t, map[string]string{"database": "ok"}, res.Checks)
+	})
+
+	t.Run("check gagal tanpa membuka detail error", func(t *testing.T) {
+		fail := func(ctx context.Context) error {
+			return errors.New("dial tcp 10.0.0.5:3306: connect: connection refused")
+		}
+		code, res := ready(t, health.New(
+			health.Check{Name: "database", Fn: fail},
+			health.Check{Name: "migration", Fn: ok},
+		))
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "not ready", res.Status)
+		assert.Equal(t, map[string]string{"database": "unavailable", "migration": "ok"}, res.Checks)
+	})
+
+	t.Run("check melewati timeout", func(t *testing.T) {
+		slow := func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		start := time.Now()
+		code, res := ready(t, health.New(health.Check{Name: "database", Timeout: 20 * time.Millisecond, Fn: slow}))
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "unavailable", res.Checks["database"])
+	})
+
+	t.Run("sedang shutdown", func(t *testing.T) {
+		called := false
+		h := health.New(health.Check{Name: "database", Fn: func(ctx context.Context) error {
+			called = true
+			return nil
+		}})
+		h.SetShuttingDown()
+
+		code, res := ready(t, h)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "shutting down", res.Status)
+		assert.False(t, called)
+	})
+}
@@ -0,0 +1,26 @@
+package health
+
+import (
+	"net/http"
+	"runtime"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Diisi saat build, contoh:
+//
+//	go build -ldflags "-X api/health.Commit=$(git rev-parse HEAD) -X api/health.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+func (h *Handler) Version() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"commit":     Commit,
+			"build_time": BuildTime,
+			"go_version": runtime.Version(),
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package helper
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ETag membuat entity tag dari ID dan versi data, misalnya "12-3".
+func ETag(id, version uint) string {
+	return fmt.Sprintf(`"%d-%d"`, id, version)
+}
+
+// IfMatchVersion membaca versi yang diharapkan dari header If-Match untuk
+// data dengan ID id. Header kosong atau "*" berarti tanpa syarat (versi 0),
+// ok bernilai false bila tidak ada etag yang cocok dengan ID tersebut.
+func IfMatchVersion(header string, id uint) (version uint, ok bool) {
+	if strings.TrimSpace(header) == "" {
+		return 0, true
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return 0, true
+		}
+		var tagID, tagVersion uint
+		if _, err := fmt.Sscanf(tag, `"%d-%d"`, &tagID, &tagVersion); err == nil && tagID == id && tagVersion > 0 {
+			return tagVersion, true
+		}
+	}
+	return 0, false
+}
+
+// ETagMatch memeriksa header If-None-Match memuat etag. Perbandingan
+// dilakukan secara weak, sehingga W/"12-3" sama dengan "12-3".
+func ETagMatch(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
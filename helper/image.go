@@ -0,0 +1,35 @@
+package helper
+
+import (
+	"image"
+
+	"golang.org/x/image/draw"
+)
+
+// Thumbnail memperkecil gambar agar sisi terpanjangnya tidak lebih dari max
+// piksel dengan tetap menjaga rasio. Gambar yang sudah kecil dikembalikan apa adanya.
+func Thumbnail(src image.Image, max int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= max && h <= max {
+		return src
+	}
+
+	if w >= h {
+		h = h * max / w
+		w = max
+	} else {
+		w = w * max / h
+		h = max
+	}
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Over, nil)
+	return dst
+}
@@ -0,0 +1,38 @@
+package helper
+
+const (
+	DefaultLimit = 10
+	MaxLimit     = 100
+)
+
+type Pagination struct {
+	Page      int   `json:"page"`
+	Limit     int   `json:"limit"`
+	TotalData int64 `json:"total_data"`
+	TotalPage int   `json:"total_page"`
+}
+
+// PageLimit mengisi nilai default page dan limit serta membatasi limit
+// maksimal agar satu request tidak mengambil terlalu banyak data.
+func PageLimit(page, limit int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	return page, limit
+}
+
+func NewPagination(page, limit int, total int64) Pagination {
+	page, limit = PageLimit(page, limit)
+	return Pagination{
+		Page:      page,
+		Limit:     limit,
+		TotalData: total,
+		TotalPage: int((total + int64(limit) - 1) / int64(limit)),
+	}
+}
@@ -34,6 +34,12 @@ func PrintErrorResponse(msg string) (int, interface{}) {
 		code = http.StatusBadRequest
 	} else if strings.Contains(msg, "not found") {
 		code = http.StatusNotFound
+	} else if strings.Contains(msg, "conflict") {
+		code = http.StatusConflict
+	} else if strings.Contains(msg, "forbidden") {
+		code = http.StatusForbidden
+	} else if strings.Contains(msg, "precondition") {
+		code = http.StatusPreconditionFailed
 	}
 
 	return code, resp
@@ -1,8 +1,9 @@
 package helper
 
 import (
+	"api/logger"
+	"context"
 	"errors"
-	"log"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -10,7 +11,7 @@ import (
 func GeneratePassword(password string) (string, error) {
 	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		log.Println("bcrypt error ", err.Error())
+		logger.Error(context.Background(), "bcrypt error", logger.Fields{"error": err})
 		return "", errors.New("password process error")
 	}
 
@@ -19,7 +20,7 @@ func GeneratePassword(password string) (string, error) {
 
 func CheckPassword(hashed, password string) error {
 	if err := bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password)); err != nil {
-		log.Println("login compare", err.Error())
+		logger.Warn(context.Background(), "login compare", logger.Fields{"error": err})
 		return errors.New("password tidak sesuai ")
 	}
 	return nil
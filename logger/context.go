@@ -0,0 +1,51 @@
+package logger
+
+import "context"
+
+type ctxKey int
+
+const (
+	requestIDKey ctxKey = iota
+	userIDKey
+	ipKey
+)
+
+// WithRequestID menyimpan request ID ke dalam context agar ikut tercatat
+// pada setiap log yang ditulis selama request berjalan.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// WithUserID menyimpan ID user yang sudah terautentikasi ke dalam context.
+func WithUserID(ctx context.Context, id uint) context.Context {
+	return context.WithValue(ctx, userIDKey, id)
+}
+
+func UserID(ctx context.Context) uint {
+	if ctx == nil {
+		return 0
+	}
+	id, _ := ctx.Value(userIDKey).(uint)
+	return id
+}
+
+// WithIP menyimpan IP client ke dalam context untuk audit log.
+func WithIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, ipKey, ip)
+}
+
+func IP(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	ip, _ := ctx.Value(ipKey).(string)
+	return ip
+}
@@ -0,0 +1,153 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = map[Level]string{
+	LevelDebug: "debug",
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+}
+
+// Fields berisi data tambahan yang ikut ditulis pada satu baris log.
+type Fields map[string]interface{}
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys adalah potongan nama field yang nilainya tidak boleh masuk log.
+var sensitiveKeys = []string{"password", "token", "authorization", "secret", "cookie"}
+
+var (
+	mu       sync.Mutex
+	minLevel           = LevelInfo
+	out      io.Writer = os.Stdout
+)
+
+// SetLevel mengatur level minimum log, nilai yang tidak dikenal diabaikan.
+func SetLevel(level string) {
+	for lvl, name := range levelNames {
+		if strings.EqualFold(level, name) {
+			mu.Lock()
+			minLevel = lvl
+			mu.Unlock()
+			return
+		}
+	}
+}
+
+func SetOutput(w io.Writer) {
+	mu.Lock()
+	out = w
+	mu.Unlock()
+}
+
+func Debug(ctx context.Context, msg string, fields ...Fields) {
+	write(ctx, LevelDebug, msg, fields)
+}
+
+func Info(ctx context.Context, msg string, fields ...Fields) {
+	write(ctx, LevelInfo, msg, fields)
+}
+
+func Warn(ctx context.Context, msg string, fields ...Fields) {
+	write(ctx, LevelWarn, msg, fields)
+}
+
+func Error(ctx context.Context, msg string, fields ...Fields) {
+	write(ctx, LevelError, msg, fields)
+}
+
+func write(ctx context.Context, lvl Level, msg string, fields []Fields) {
+	mu.Lock()
+	defer mu.Unlock()
+	if lvl < minLevel {
+		return
+	}
+
+	entry := map[string]interface{}{
+		"time":  time.Now().UTC().Format(time.RFC3339Nano),
+		"level": levelNames[lvl],
+		"msg":   msg,
+	}
+	if id := RequestID(ctx); id != "" {
+		entry["request_id"] = id
+	}
+	if id := UserID(ctx); id > 0 {
+		entry["user_id"] = id
+	}
+	if ctx != nil {
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			entry["trace_id"] = sc.TraceID().String()
+			entry["span_id"] = sc.SpanID().String()
+		}
+	}
+	for _, f := range fields {
+		for k, v := range f {
+			entry[k] = redact(k, v)
+		}
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		b, _ = json.Marshal(map[string]interface{}{"level": "error", "msg": "log marshal error", "error": err.Error()})
+	}
+	out.Write(append(b, '\n'))
+}
+
+func redact(key string, val interface{}) interface{} {
+	lower := strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(lower, s) {
+			return redacted
+		}
+	}
+
+	switch v := val.(type) {
+	case error:
+		return v.Error()
+	case Fields:
+		res := Fields{}
+		for k, fv := range v {
+			res[k] = redact(k, fv)
+		}
+		return res
+	}
+	return val
+}
+
+// RedactURI menyamarkan nilai query parameter yang sensitif, misalnya
+// ?token=... sehingga URI aman untuk dicatat.
+func RedactURI(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.RawQuery == "" {
+		return uri
+	}
+	q := u.Query()
+	for k := range q {
+		if redact(k, "") == redacted {
+			q.Set(k, redacted)
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
@@ -2,16 +2,54 @@ package main
 
 import (
 	"api/config"
+	aud "api/features/audit/data"
+	audhl "api/features/audit/handler"
+	audsrv "api/features/audit/services"
+	ad "api/features/author/data"
+	ahl "api/features/author/handler"
+	asrv "api/features/author/services"
 	bd "api/features/book/data"
 	bhl "api/features/book/handler"
 	bsrv "api/features/book/services"
+	rd "api/features/review/data"
+	rhl "api/features/review/handler"
+	rsrv "api/features/review/services"
+	sd "api/features/shelf/data"
+	shl "api/features/shelf/handler"
+	ssrv "api/features/shelf/services"
 	"api/features/user/data"
 	"api/features/user/handler"
 	"api/features/user/services"
-	"log"
+	whd "api/features/webhook/data"
+	whhl "api/features/webhook/handler"
+	whsrv "api/features/webhook/services"
+	"api/health"
+	"api/logger"
+	"api/metrics"
+	"api/middlewares"
+	"api/openapi"
+	"api/routes"
+	"api/scheduler"
+	"api/tracing"
+	"api/uow"
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"gorm.io/gorm"
+)
+
+const (
+	// readinessDrain memberi waktu orchestrator melihat readiness gagal
+	// sebelum server berhenti menerima koneksi.
+	readinessDrain  = 5 * time.Second
+	shutdownTimeout = 10 * time.Second
 )
 
 func main() {
@@ -19,33 +57,141 @@ func main() {
 	cfg := config.InitConfig()
 	db := config.InitDB(*cfg)
 	config.Migrate(db)
+	if err := metrics.RegisterDB(db, cfg.DBName); err != nil {
+		logger.Error(context.Background(), "register db metrics error", logger.Fields{"error": err})
+	}
+
+	shutdownTracer, err := tracing.Init(context.Background(), cfg.TraceExporter, cfg.OTLPEndpoint)
+	if err != nil {
+		logger.Error(context.Background(), "init tracing error", logger.Fields{"error": err})
+	} else {
+		defer shutdownTracer(context.Background())
+	}
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		logger.Error(context.Background(), "register gorm tracing error", logger.Fields{"error": err})
+	}
+
+	auditData := aud.New(db)
+	recorder := audsrv.NewRecorder(auditData)
 
-	userData := data.New(db)
-	userSrv := services.New(userData)
+	appCache := config.InitCache(*cfg)
+	cacheTTL := config.CacheTTL(*cfg)
+	userData, bookData := data.New(db), bd.New(db)
+	txRepos := func(tx *gorm.DB) uow.Repos {
+		return uow.Repos{Users: data.New(tx), Books: bd.New(tx)}
+	}
+	if appCache != nil {
+		userData, bookData = data.NewCached(userData, appCache, cacheTTL), bd.NewCached(bookData, appCache, cacheTTL)
+		// repo di dalam transaksi tidak membaca cache, perubahannya
+		// menghapus cache setelah transaksi di-commit
+		txRepos = func(tx *gorm.DB) uow.Repos {
+			return uow.Repos{Users: data.NewInvalidating(data.New(tx), appCache), Books: bd.NewInvalidating(bd.New(tx), appCache)}
+		}
+	}
+	unitOfWork := uow.New(db, txRepos)
+	userSrv := services.New(userData, bookData, unitOfWork, config.DeactivationGrace(*cfg), recorder)
 	userHdl := handler.New(userSrv)
 
-	bookData := bd.New(db)
-	bookSrv := bsrv.New(bookData)
+	blobStore := config.InitBlobStore(*cfg)
+
+	bookSrv := bsrv.New(bookData, unitOfWork, blobStore, config.InitMetadata(*cfg), config.TrashRetention(*cfg), recorder)
 	bookHdl := bhl.New(bookSrv)
 
+	authorSrv := asrv.New(ad.New(db, appCache), userData)
+	authorHdl := ahl.New(authorSrv)
+
+	reviewSrv := rsrv.New(rd.New(db))
+	reviewHdl := rhl.New(reviewSrv)
+
+	shelfSrv := ssrv.New(sd.New(db))
+	shelfHdl := shl.New(shelfSrv)
+
+	auditSrv := audsrv.New(auditData, userData)
+	auditHdl := audhl.New(auditSrv)
+
+	webhookSrv := whsrv.New(whd.New(db), config.WebhookClient(*cfg))
+	webhookHdl := whhl.New(webhookSrv)
+
+	healthHdl := health.New(
+		health.Check{Name: "database", Fn: func(ctx context.Context) error { return config.Ping(ctx, db) }},
+		health.Check{Name: "migration", Fn: func(ctx context.Context) error { return config.CheckMigration(ctx, db) }},
+	)
+
+	apiDoc := openapi.NewDocument(e)
+
 	e.Pre(middleware.RemoveTrailingSlash())
-	e.Use(middleware.CORS())
-	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
-		Format: "method=${method}, uri=${uri}, status=${status}, error=${error}\n",
-	}))
-	// users
-	e.POST("/register", userHdl.Register())
-	e.POST("/login", userHdl.Login())
-	e.GET("/users", userHdl.Profile(), middleware.JWT([]byte(config.JWT_KEY)))
-	e.PATCH("/users", userHdl.Update(), middleware.JWT([]byte(config.JWT_KEY)))
-	e.DELETE("/users", userHdl.Deactive(), middleware.JWT([]byte(config.JWT_KEY)))
-
-	// books
-	e.POST("/books", bookHdl.Add(), middleware.JWT([]byte(config.JWT_KEY)))
-	e.PATCH("/books/:id", bookHdl.Update(), middleware.JWT([]byte(config.JWT_KEY)))
-	e.DELETE("/books/:id", bookHdl.Delete(), middleware.JWT([]byte(config.JWT_KEY)))
-
-	if err := e.Start(":8000"); err != nil {
-		log.Println(err.Error())
+	// ETag perlu diekspos agar client browser bisa membaca versi data
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{ExposeHeaders: []string{"ETag"}}))
+	e.Use(middlewares.RequestID())
+	e.Use(middlewares.ReadYourWrites())
+	e.Use(middlewares.Tracing())
+	e.Use(middlewares.Logger())
+	e.Use(middlewares.Metrics())
+	e.Use(middlewares.Validate(apiDoc))
+
+	jwtMdw := middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey:     []byte(config.JWT_KEY),
+		SuccessHandler: middlewares.JWTUser,
+	})
+
+	routes.Register(e, routes.Handlers{
+		JWT:     jwtMdw,
+		User:    userHdl,
+		Book:    bookHdl,
+		Author:  authorHdl,
+		Review:  reviewHdl,
+		Shelf:   shelfHdl,
+		Audit:   auditHdl,
+		Webhook: webhookHdl,
+		Health:  healthHdl,
+		Files:   config.LocalBlobDir(*cfg),
+	})
+	openapi.Register(e, apiDoc)
+
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go scheduler.Every(jobCtx, "purgeUsers", config.PurgeInterval(*cfg), func(ctx context.Context) error {
+		n, err := userSrv.Purge(ctx)
+		if n > 0 {
+			logger.Info(ctx, "akun nonaktif dihapus permanen", logger.Fields{"count": n})
+		}
+		return err
+	})
+	go scheduler.Every(jobCtx, "purgeTrash", config.PurgeInterval(*cfg), func(ctx context.Context) error {
+		n, err := bookSrv.PurgeTrash(ctx)
+		if n > 0 {
+			logger.Info(ctx, "buku di sampah dihapus permanen", logger.Fields{"count": n})
+		}
+		return err
+	})
+	go scheduler.Every(jobCtx, "dispatchWebhooks", config.WebhookInterval(*cfg), func(ctx context.Context) error {
+		_, err := webhookSrv.Dispatch(ctx)
+		return err
+	})
+
+	go func() {
+		if err := e.Start(":8000"); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error(context.Background(), "server stopped", logger.Fields{"error": err})
+			os.Exit(1)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+
+	logger.Info(context.Background(), "shutting down server")
+	healthHdl.SetShuttingDown()
+	stopJobs()
+	time.Sleep(readinessDrain)
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := e.Shutdown(ctx); err != nil {
+		logger.Error(ctx, "server shutdown error", logger.Fields{"error": err})
+	}
+	// import yang belum selesai saat timeout tetap berstatus running
+	if err := bookSrv.WaitImports(ctx); err != nil {
+		logger.Error(ctx, "import buku belum selesai saat shutdown", logger.Fields{"error": err})
 	}
 }
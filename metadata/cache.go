@@ -0,0 +1,82 @@
+package metadata
+
+import (
+	"api/features/book"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+type cacheItem struct {
+	book    book.Core
+	err     error
+	expires time.Time
+}
+
+// cache menyimpan hasil lookup di memori. ISBN yang tidak ditemukan juga
+// disimpan agar tidak terus ditanyakan ke provider, sedangkan error lain
+// (timeout, provider mati) tidak disimpan supaya lookup berikutnya dicoba
+// lagi.
+type cache struct {
+	next  book.MetadataProvider
+	ttl   time.Duration
+	max   int
+	now   func() time.Time
+	mu    sync.Mutex
+	items map[string]cacheItem
+}
+
+// NewCache membungkus provider dengan cache berumur ttl dan maksimal max
+// ISBN.
+func NewCache(next book.MetadataProvider, ttl time.Duration, max int) book.MetadataProvider {
+	return &cache{
+		next:  next,
+		ttl:   ttl,
+		max:   max,
+		now:   time.Now,
+		items: map[string]cacheItem{},
+	}
+}
+
+func (c *cache) Lookup(ctx context.Context, isbn string) (book.Core, error) {
+	c.mu.Lock()
+	item, ok := c.items[isbn]
+	c.mu.Unlock()
+	if ok && c.now().Before(item.expires) {
+		return item.book, item.err
+	}
+
+	res, err := c.next.Lookup(ctx, isbn)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return book.Core{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.items) >= c.max {
+		c.evict()
+	}
+	c.items[isbn] = cacheItem{book: res, err: err, expires: c.now().Add(c.ttl)}
+
+	return res, err
+}
+
+// evict membuang item yang sudah kedaluwarsa, bila tidak ada maka item
+// yang paling cepat kedaluwarsa dibuang.
+func (c *cache) evict() {
+	now := c.now()
+	oldest := ""
+	for k, v := range c.items {
+		if !now.Before(v.expires) {
+			delete(c.items, k)
+			continue
+		}
+		if oldest == "" || v.expires.Before(c.items[oldest].expires) {
+			oldest = k
+		}
+	}
+	if len(c.items) >= c.max && oldest != "" {
+		delete(c.items, oldest)
+	}
+}
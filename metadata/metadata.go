@@ -0,0 +1,8 @@
+// Package metadata berisi implementasi book.MetadataProvider untuk mengisi
+// judul, penulis dan tahun terbit dari ISBN.
+package metadata
+
+import "errors"
+
+// ErrNotFound dikembalikan bila provider tidak mengenal ISBN yang dicari.
+var ErrNotFound = errors.New("metadata not found")
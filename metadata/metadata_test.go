@@ -0,0 +1,132 @@
+package metadata
+
+import (
+	"api/features/book"
+	"api/mocks"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const laskarPelangi = `{"ISBN:9789793062792": {
+	"title": "Laskar Pelangi",
+	"authors": [{"name": "Andrea Hirata", "url": "https://openlibrary.org/authors/OL1A"}],
+	"publishers": [{"name": "Bentang Pustaka"}],
+	"publish_date": "September 2005",
+	"number_of_pages": 529
+}}`
+
+func TestOpenLibrary(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/books", r.URL.Path)
+		assert.Equal(t, "data", r.URL.Query().Get("jscmd"))
+		switch r.URL.Query().Get("bibkeys") {
+		case "ISBN:9789793062792":
+			w.Write([]byte(laskarPelangi))
+		case "ISBN:0000000000":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "ISBN:1111111111":
+			time.Sleep(200 * time.Millisecond)
+			w.Write([]byte(`{}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+	p := NewOpenLibrary(srv.URL+"/", 50*time.Millisecond)
+
+	t.Run("ditemukan", func(t *testing.T) {
+		res, err := p.Lookup(context.Background(), "9789793062792")
+		assert.Nil(t, err)
+		assert.Equal(t, book.Core{
+			ISBN:          "9789793062792",
+			Judul:         "Laskar Pelangi",
+			Penulis:       "Andrea Hirata",
+			TahunTerbit:   2005,
+			Penerbit:      "Bentang Pustaka",
+			JumlahHalaman: 529,
+		}, res)
+	})
+
+	t.Run("tidak ditemukan", func(t *testing.T) {
+		_, err := p.Lookup(context.Background(), "9780000000002")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		_, err := p.Lookup(context.Background(), "0000000000")
+		assert.ErrorContains(t, err, "status 500")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		start := time.Now()
+		_, err := p.Lookup(context.Background(), "1111111111")
+		assert.NotNil(t, err)
+		assert.NotErrorIs(t, err, ErrNotFound)
+		assert.Less(t, time.Since(start), 150*time.Millisecond)
+	})
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	found := book.Core{ISBN: "9789793062792", Judul: "Laskar Pelangi"}
+
+	t.Run("hasil disimpan sampai kedaluwarsa", func(t *testing.T) {
+		next := mocks.NewMetadataProvider(t)
+		next.On("Lookup", mock.Anything, "9789793062792").Return(found, nil).Twice()
+		c := NewCache(next, time.Minute, 10).(*cache)
+		now := time.Now()
+		c.now = func() time.Time { return now }
+
+		for i := 0; i < 3; i++ {
+			res, err := c.Lookup(ctx, "9789793062792")
+			assert.Nil(t, err)
+			assert.Equal(t, found, res)
+		}
+
+		now = now.Add(2 * time.Minute)
+		_, err := c.Lookup(ctx, "9789793062792")
+		assert.Nil(t, err)
+	})
+
+	t.Run("tidak ditemukan ikut disimpan", func(t *testing.T) {
+		next := mocks.NewMetadataProvider(t)
+		next.On("Lookup", mock.Anything, "9780000000002").Return(book.Core{}, ErrNotFound).Once()
+		c := NewCache(next, time.Minute, 10)
+
+		for i := 0; i < 2; i++ {
+			_, err := c.Lookup(ctx, "9780000000002")
+			assert.ErrorIs(t, err, ErrNotFound)
+		}
+	})
+
+	t.Run("error lain tidak disimpan", func(t *testing.T) {
+		next := mocks.NewMetadataProvider(t)
+		next.On("Lookup", mock.Anything, "9789793062792").Return(book.Core{}, errors.New("timeout")).Once()
+		next.On("Lookup", mock.Anything, "9789793062792").Return(found, nil).Once()
+		c := NewCache(next, time.Minute, 10)
+
+		_, err := c.Lookup(ctx, "9789793062792")
+		assert.NotNil(t, err)
+		res, err := c.Lookup(ctx, "9789793062792")
+		assert.Nil(t, err)
+		assert.Equal(t, found, res)
+	})
+
+	t.Run("ukuran dibatasi", func(t *testing.T) {
+		next := mocks.NewMetadataProvider(t)
+		next.On("Lookup", mock.Anything, mock.Anything).Return(found, nil)
+		c := NewCache(next, time.Minute, 2).(*cache)
+
+		for _, isbn := range []string{"1", "2", "3"} {
+			c.Lookup(ctx, isbn)
+		}
+		assert.Len(t, c.items, 2)
+	})
+}
@@ -0,0 +1,100 @@
+package metadata
+
+import (
+	"api/features/book"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxResponseSize = 1 << 20
+
+var yearPattern = regexp.MustCompile(`\b\d{4}\b`)
+
+type openLibrary struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewOpenLibrary membuat provider yang memakai Books API Open Library
+// (https://openlibrary.org/dev/docs/api/books). timeout membatasi lama satu
+// lookup agar request tambah buku tidak ikut tertahan.
+func NewOpenLibrary(baseURL string, timeout time.Duration) book.MetadataProvider {
+	return &openLibrary{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+type olName struct {
+	Name string `json:"name"`
+}
+
+type olBook struct {
+	Title         string   `json:"title"`
+	Subtitle      string   `json:"subtitle"`
+	Authors       []olName `json:"authors"`
+	Publishers    []olName `json:"publishers"`
+	PublishDate   string   `json:"publish_date"`
+	NumberOfPages int      `json:"number_of_pages"`
+}
+
+func (ol *openLibrary) Lookup(ctx context.Context, isbn string) (book.Core, error) {
+	key := "ISBN:" + isbn
+	query := url.Values{"bibkeys": {key}, "format": {"json"}, "jscmd": {"data"}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ol.baseURL+"/api/books?"+query.Encode(), nil)
+	if err != nil {
+		return book.Core{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := ol.client.Do(req)
+	if err != nil {
+		return book.Core{}, fmt.Errorf("metadata lookup: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return book.Core{}, fmt.Errorf("metadata lookup: status %d", resp.StatusCode)
+	}
+
+	res := map[string]olBook{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&res); err != nil {
+		return book.Core{}, fmt.Errorf("metadata lookup: %w", err)
+	}
+	data, ok := res[key]
+	if !ok {
+		return book.Core{}, ErrNotFound
+	}
+
+	out := book.Core{
+		ISBN:          isbn,
+		Judul:         data.Title,
+		JumlahHalaman: data.NumberOfPages,
+	}
+	if data.Subtitle != "" {
+		out.Judul += ": " + data.Subtitle
+	}
+	names := []string{}
+	for _, a := range data.Authors {
+		names = append(names, a.Name)
+	}
+	out.Penulis = strings.Join(names, ", ")
+	if len(data.Publishers) > 0 {
+		out.Penerbit = data.Publishers[0].Name
+	}
+	// publish_date berupa teks bebas seperti "1997" atau "July 22, 1997"
+	if y := yearPattern.FindString(data.PublishDate); y != "" {
+		out.TahunTerbit, _ = strconv.Atoi(y)
+	}
+
+	return out, nil
+}
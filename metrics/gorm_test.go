@@ -0,0 +1,53 @@
+package metrics_test
+
+import (
+	"api/dbtest"
+	"api/metrics"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MetricItem struct {
+	ID   uint
+	Nama string `gorm:"unique"`
+}
+
+// observations mengembalikan jumlah durasi yang tercatat untuk label
+// operation dan table.
+func observations(t *testing.T, operation, table string) uint64 {
+	m := &dto.Metric{}
+	require.NoError(t, metrics.DBQueryDuration.WithLabelValues(operation, table).(prometheus.Metric).Write(m))
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestGormPlugin(t *testing.T) {
+	db := dbtest.Open(t, MetricItem{})
+	require.NoError(t, db.Use(metrics.GormPlugin{}))
+
+	t.Run("durasi query dicatat per operasi dan tabel", func(t *testing.T) {
+		creates, queries := observations(t, "create", "metric_items"), observations(t, "query", "metric_items")
+
+		require.NoError(t, db.Create(&MetricItem{Nama: "satu"}).Error)
+		require.NoError(t, db.First(&MetricItem{}).Error)
+
+		assert.Equal(t, creates+1, observations(t, "create", "metric_items"))
+		assert.Equal(t, queries+1, observations(t, "query", "metric_items"))
+	})
+
+	t.Run("record not found bukan error", func(t *testing.T) {
+		before := testutil.ToFloat64(metrics.DBQueryErrors.WithLabelValues("query", "metric_items"))
+		assert.Error(t, db.First(&MetricItem{}, 99).Error)
+		assert.Equal(t, before, testutil.ToFloat64(metrics.DBQueryErrors.WithLabelValues("query", "metric_items")))
+	})
+
+	t.Run("query gagal dihitung", func(t *testing.T) {
+		before := testutil.ToFloat64(metrics.DBQueryErrors.WithLabelValues("create", "metric_items"))
+		assert.Error(t, db.Create(&MetricItem{Nama: "satu"}).Error)
+		assert.Equal(t, before+1, testutil.ToFloat64(metrics.DBQueryErrors.WithLabelValues("create", "metric_items")))
+	})
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "api"
+
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Jumlah request HTTP berdasarkan method, route dan status.",
+	}, []string{"method", "route", "status"})
+
+	HTTPDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latensi request HTTP berdasarkan method, route dan status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Lama eksekusi query GORM berdasarkan operasi dan tabel.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	DBQueryErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_query_errors_total",
+		Help:      "Jumlah query GORM yang gagal (selain record not found).",
+	}, []string{"operation", "table"})
+
+	UserRegistrations = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "user_registrations_total",
+		Help:      "Jumlah user yang berhasil mendaftar.",
+	})
+
+	UserLogins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "user_logins_total",
+		Help:      "Jumlah percobaan login berdasarkan hasilnya (succeeded/failed).",
+	}, []string{"result"})
+
+	BooksAdded = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "books_added_total",
+		Help:      "Jumlah buku yang berhasil ditambahkan.",
+	})
+
+	CacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Jumlah pembacaan cache berdasarkan nama cache dan hasilnya (hit/miss).",
+	}, []string{"name", "result"})
+
+	WebhookDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_deliveries_total",
+		Help:      "Jumlah percobaan pengiriman webhook berdasarkan event dan status delivery setelahnya (success/pending/dead).",
+	}, []string{"event", "status"})
+)
+
+const (
+	LoginSucceeded = "succeeded"
+	LoginFailed    = "failed"
+
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
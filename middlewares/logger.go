@@ -0,0 +1,74 @@
+package middlewares
+
+import (
+	"api/helper"
+	"api/logger"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// RequestID memberi setiap request sebuah ID (atau memakai header
+// X-Request-ID dari client) lalu meneruskannya lewat context bersama IP
+// client.
+func RequestID() echo.MiddlewareFunc {
+	return middleware.RequestIDWithConfig(middleware.RequestIDConfig{
+		RequestIDHandler: func(c echo.Context, id string) {
+			ctx := logger.WithIP(logger.WithRequestID(c.Request().Context(), id), c.RealIP())
+			c.SetRequest(c.Request().WithContext(ctx))
+		},
+	})
+}
+
+// JWTUser dipasang sebagai SuccessHandler middleware JWT agar ID user ikut
+// tersimpan di context dan tercatat pada log service maupun data.
+func JWTUser(c echo.Context) {
+	id := helper.ExtractToken(c.Get("user"))
+	if id <= 0 {
+		return
+	}
+	ctx := logger.WithUserID(c.Request().Context(), uint(id))
+	c.SetRequest(c.Request().WithContext(ctx))
+}
+
+// Logger mencatat setiap request sebagai satu baris log JSON.
+func Logger() echo.MiddlewareFunc {
+	return middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
+		LogLatency:      true,
+		LogMethod:       true,
+		LogURI:          true,
+		LogRoutePath:    true,
+		LogStatus:       true,
+		LogError:        true,
+		LogRemoteIP:     true,
+		LogUserAgent:    true,
+		LogResponseSize: true,
+		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
+			fields := logger.Fields{
+				"method":     v.Method,
+				"uri":        logger.RedactURI(v.URI),
+				"route":      v.RoutePath,
+				"status":     v.Status,
+				"latency_ms": float64(v.Latency.Microseconds()) / 1000,
+				"bytes_out":  v.ResponseSize,
+				"remote_ip":  v.RemoteIP,
+				"user_agent": v.UserAgent,
+			}
+			if v.Error != nil {
+				fields["error"] = v.Error
+			}
+
+			ctx := c.Request().Context()
+			switch {
+			case v.Status >= http.StatusInternalServerError:
+				logger.Error(ctx, "request", fields)
+			case v.Status >= http.StatusBadRequest:
+				logger.Warn(ctx, "request", fields)
+			default:
+				logger.Info(ctx, "request", fields)
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,45 @@
+package middlewares_test
+
+import (
+	"api/metrics"
+	"api/middlewares"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	e := echo.New()
+	e.Use(middlewares.Metrics())
+	e.GET("/books/:id", func(c echo.Context) error {
+		if c.Param("id") == "0" {
+			return echo.NewHTTPError(http.StatusNotFound, "buku tidak ditemukan")
+		}
+		return c.NoContent(http.StatusOK)
+	})
+
+	requests := func(route, status string) float64 {
+		return testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues(http.MethodGet, route, status))
+	}
+	serve := func(path string) {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	t.Run("route memakai pola yang terdaftar", func(t *testing.T) {
+		before := requests("/books/:id", "200")
+		serve("/books/1")
+		serve("/books/2")
+		assert.Equal(t, before+2, requests("/books/:id", "200"))
+		assert.Zero(t, requests("/books/1", "200"))
+	})
+
+	t.Run("status dari echo.HTTPError", func(t *testing.T) {
+		before := requests("/books/:id", "404")
+		serve("/books/0")
+		assert.Equal(t, before+1, requests("/books/:id", "404"))
+	})
+}
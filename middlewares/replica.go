@@ -0,0 +1,18 @@
+package middlewares
+
+import (
+	"api/replica"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ReadYourWrites menandai context request agar setelah ada penulisan,
+// bacaan berikutnya pada request yang sama diarahkan ke primary.
+func ReadYourWrites() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.SetRequest(c.Request().WithContext(replica.WithSession(c.Request().Context())))
+			return next(c)
+		}
+	}
+}
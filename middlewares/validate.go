@@ -0,0 +1,69 @@
+package middlewares
+
+import (
+	"api/openapi"
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxValidatedBody membatasi body yang dibaca ke memori untuk divalidasi.
+// Middleware ini berjalan sebelum JWT dan BodyLimit milik route, jadi tanpa
+// batas ini client tanpa login bisa membuat server menampung body sebesar
+// apa pun. Body JSON dan form di API ini jauh lebih kecil dari batas ini.
+const maxValidatedBody = 1 << 20
+
+// Validate menolak request yang tidak sesuai dokumen OpenAPI sebelum sampai
+// ke handler. Route yang tidak ada di dokumen dilewatkan begitu saja.
+func Validate(doc *openapi.Document) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			op := doc.Operation(req.Method, c.Path())
+			if op == nil {
+				return next(c)
+			}
+
+			var body []byte
+			if op.RequestBody != nil && req.Body != nil && openapi.HasReadableBody(openapi.ContentType(req)) {
+				b, err := io.ReadAll(http.MaxBytesReader(c.Response(), req.Body, maxValidatedBody))
+				if mbe := (*http.MaxBytesError)(nil); errors.As(err, &mbe) {
+					return c.JSON(http.StatusRequestEntityTooLarge, openapi.ValidationErrorResponse{
+						Message: "body terlalu besar",
+						Errors:  []openapi.Violation{{In: "body", Message: "maksimal 1MB"}},
+					})
+				}
+				if err != nil {
+					return c.JSON(http.StatusBadRequest, openapi.ValidationErrorResponse{
+						Message: "body tidak dapat dibaca",
+						Errors:  []openapi.Violation{{In: "body", Message: err.Error()}},
+					})
+				}
+				body = b
+				req.Body = io.NopCloser(bytes.NewReader(b))
+			}
+
+			params := map[string]string{}
+			for i, name := range c.ParamNames() {
+				params[name] = c.ParamValues()[i]
+			}
+
+			res := doc.Spec().ValidateRequest(op, req, params, body)
+			if res.Valid() {
+				return next(c)
+			}
+
+			code := http.StatusUnprocessableEntity
+			if res.Malformed {
+				code = http.StatusBadRequest
+			}
+			return c.JSON(code, openapi.ValidationErrorResponse{
+				Message: "request tidak sesuai spesifikasi",
+				Errors:  res.Violations,
+			})
+		}
+	}
+}
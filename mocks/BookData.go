@@ -3,6 +3,9 @@
 package mocks
 
 import (
+	context "context"
+	time "time"
+
 	book "api/features/book"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,20 +16,78 @@ type BookData struct {
 	mock.Mock
 }
 
-// Add provides a mock function with given fields: userID, newBook
-func (_m *BookData) Add(userID uint, newBook book.Core) (book.Core, error) {
-	ret := _m.Called(userID, newBook)
+// Add provides a mock function with given fields: ctx, userID, newBook
+func (_m *BookData) Add(ctx context.Context, userID uint, newBook book.Core) (book.Core, error) {
+	ret := _m.Called(ctx, userID, newBook)
 
 	var r0 book.Core
-	if rf, ok := ret.Get(0).(func(uint, book.Core) book.Core); ok {
-		r0 = rf(userID, newBook)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, book.Core) book.Core); ok {
+		r0 = rf(ctx, userID, newBook)
 	} else {
 		r0 = ret.Get(0).(book.Core)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, book.Core) error); ok {
-		r1 = rf(userID, newBook)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, book.Core) error); ok {
+		r1 = rf(ctx, userID, newBook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddBatch provides a mock function with given fields: ctx, userID, newBooks
+func (_m *BookData) AddBatch(ctx context.Context, userID uint, newBooks []book.Core) ([]book.Core, error) {
+	ret := _m.Called(ctx, userID, newBooks)
+
+	var r0 []book.Core
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []book.Core) []book.Core); ok {
+		r0 = rf(ctx, userID, newBooks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]book.Core)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, []book.Core) error); ok {
+		r1 = rf(ctx, userID, newBooks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelImports provides a mock function with given fields: ctx, userID
+func (_m *BookData) CancelImports(ctx context.Context, userID uint) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateImportJob provides a mock function with given fields: ctx, job
+func (_m *BookData) CreateImportJob(ctx context.Context, job book.ImportJob) (book.ImportJob, error) {
+	ret := _m.Called(ctx, job)
+
+	var r0 book.ImportJob
+	if rf, ok := ret.Get(0).(func(context.Context, book.ImportJob) book.ImportJob); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Get(0).(book.ImportJob)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, book.ImportJob) error); ok {
+		r1 = rf(ctx, job)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -34,13 +95,13 @@ func (_m *BookData) Add(userID uint, newBook book.Core) (book.Core, error) {
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: userID, bookID
-func (_m *BookData) Delete(userID uint, bookID uint) error {
-	ret := _m.Called(userID, bookID)
+// Delete provides a mock function with given fields: ctx, userID, bookID, version
+func (_m *BookData) Delete(ctx context.Context, userID uint, bookID uint, version uint) error {
+	ret := _m.Called(ctx, userID, bookID, version)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
-		r0 = rf(userID, bookID)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, uint) error); ok {
+		r0 = rf(ctx, userID, bookID, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -48,20 +109,145 @@ func (_m *BookData) Delete(userID uint, bookID uint) error {
 	return r0
 }
 
-// Update provides a mock function with given fields: userID, bookID, updatedData
-func (_m *BookData) Update(userID uint, bookID uint, updatedData book.Core) (book.Core, error) {
-	ret := _m.Called(userID, bookID, updatedData)
+// Detail provides a mock function with given fields: ctx, bookID
+func (_m *BookData) Detail(ctx context.Context, bookID uint) (book.Core, error) {
+	ret := _m.Called(ctx, bookID)
+
+	var r0 book.Core
+	if rf, ok := ret.Get(0).(func(context.Context, uint) book.Core); ok {
+		r0 = rf(ctx, bookID)
+	} else {
+		r0 = ret.Get(0).(book.Core)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExistingISBN provides a mock function with given fields: ctx, userID, isbns
+func (_m *BookData) ExistingISBN(ctx context.Context, userID uint, isbns []string) ([]string, error) {
+	ret := _m.Called(ctx, userID, isbns)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string) []string); ok {
+		r0 = rf(ctx, userID, isbns)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, []string) error); ok {
+		r1 = rf(ctx, userID, isbns)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// History provides a mock function with given fields: ctx, userID, bookID, page, limit
+func (_m *BookData) History(ctx context.Context, userID uint, bookID uint, page int, limit int) ([]book.Revision, int64, error) {
+	ret := _m.Called(ctx, userID, bookID, page, limit)
+
+	var r0 []book.Revision
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, int, int) []book.Revision); ok {
+		r0 = rf(ctx, userID, bookID, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]book.Revision)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, int, int) int64); ok {
+		r1 = rf(ctx, userID, bookID, page, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, uint, uint, int, int) error); ok {
+		r2 = rf(ctx, userID, bookID, page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ImportJob provides a mock function with given fields: ctx, userID, jobID
+func (_m *BookData) ImportJob(ctx context.Context, userID uint, jobID uint) (book.ImportJob, error) {
+	ret := _m.Called(ctx, userID, jobID)
+
+	var r0 book.ImportJob
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) book.ImportJob); ok {
+		r0 = rf(ctx, userID, jobID)
+	} else {
+		r0 = ret.Get(0).(book.ImportJob)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, filter
+func (_m *BookData) List(ctx context.Context, filter book.Filter) ([]book.Core, int64, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []book.Core
+	if rf, ok := ret.Get(0).(func(context.Context, book.Filter) []book.Core); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]book.Core)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, book.Filter) int64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, book.Filter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Lock provides a mock function with given fields: ctx, userID, bookID
+func (_m *BookData) Lock(ctx context.Context, userID uint, bookID uint) (book.Core, error) {
+	ret := _m.Called(ctx, userID, bookID)
 
 	var r0 book.Core
-	if rf, ok := ret.Get(0).(func(uint, uint, book.Core) book.Core); ok {
-		r0 = rf(userID, bookID, updatedData)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) book.Core); ok {
+		r0 = rf(ctx, userID, bookID)
 	} else {
 		r0 = ret.Get(0).(book.Core)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, uint, book.Core) error); ok {
-		r1 = rf(userID, bookID, updatedData)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, bookID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -69,6 +255,208 @@ func (_m *BookData) Update(userID uint, bookID uint, updatedData book.Core) (boo
 	return r0, r1
 }
 
+// Purge provides a mock function with given fields: ctx, userID, bookID
+func (_m *BookData) Purge(ctx context.Context, userID uint, bookID uint) (book.Core, error) {
+	ret := _m.Called(ctx, userID, bookID)
+
+	var r0 book.Core
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) book.Core); ok {
+		r0 = rf(ctx, userID, bookID)
+	} else {
+		r0 = ret.Get(0).(book.Core)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeTrash provides a mock function with given fields: ctx, before
+func (_m *BookData) PurgeTrash(ctx context.Context, before time.Time) ([]book.Core, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 []book.Core
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []book.Core); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]book.Core)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, userID, bookID
+func (_m *BookData) Restore(ctx context.Context, userID uint, bookID uint) (book.Core, error) {
+	ret := _m.Called(ctx, userID, bookID)
+
+	var r0 book.Core
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) book.Core); ok {
+		r0 = rf(ctx, userID, bookID)
+	} else {
+		r0 = ret.Get(0).(book.Core)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userID, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revision provides a mock function with given fields: ctx, userID, bookID, rev
+func (_m *BookData) Revision(ctx context.Context, userID uint, bookID uint, rev uint) (book.Revision, error) {
+	ret := _m.Called(ctx, userID, bookID, rev)
+
+	var r0 book.Revision
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, uint) book.Revision); ok {
+		r0 = rf(ctx, userID, bookID, rev)
+	} else {
+		r0 = ret.Get(0).(book.Revision)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, uint) error); ok {
+		r1 = rf(ctx, userID, bookID, rev)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Transfer provides a mock function with given fields: ctx, fromUserID, toUserID
+func (_m *BookData) Transfer(ctx context.Context, fromUserID uint, toUserID uint) ([]uint, error) {
+	ret := _m.Called(ctx, fromUserID, toUserID)
+
+	var r0 []uint
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []uint); ok {
+		r0 = rf(ctx, fromUserID, toUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, fromUserID, toUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Trash provides a mock function with given fields: ctx, userID, page, limit
+func (_m *BookData) Trash(ctx context.Context, userID uint, page int, limit int) ([]book.Core, int64, error) {
+	ret := _m.Called(ctx, userID, page, limit)
+
+	var r0 []book.Core
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int, int) []book.Core); ok {
+		r0 = rf(ctx, userID, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]book.Core)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, uint, int, int) int64); ok {
+		r1 = rf(ctx, userID, page, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, uint, int, int) error); ok {
+		r2 = rf(ctx, userID, page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Update provides a mock function with given fields: ctx, userID, bookID, updatedData, fields
+func (_m *BookData) Update(ctx context.Context, userID uint, bookID uint, updatedData book.Core, fields []string) (book.Core, error) {
+	ret := _m.Called(ctx, userID, bookID, updatedData, fields)
+
+	var r0 book.Core
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, book.Core, []string) book.Core); ok {
+		r0 = rf(ctx, userID, bookID, updatedData, fields)
+	} else {
+		r0 = ret.Get(0).(book.Core)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, book.Core, []string) error); ok {
+		r1 = rf(ctx, userID, bookID, updatedData, fields)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateCover provides a mock function with given fields: ctx, userID, bookID, coverKey, thumbnailKey
+func (_m *BookData) UpdateCover(ctx context.Context, userID uint, bookID uint, coverKey string, thumbnailKey string) (string, string, error) {
+	ret := _m.Called(ctx, userID, bookID, coverKey, thumbnailKey)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string, string) string); ok {
+		r0 = rf(ctx, userID, bookID, coverKey, thumbnailKey)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, string, string) string); ok {
+		r1 = rf(ctx, userID, bookID, coverKey, thumbnailKey)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, uint, uint, string, string) error); ok {
+		r2 = rf(ctx, userID, bookID, coverKey, thumbnailKey)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdateImportJob provides a mock function with given fields: ctx, job
+func (_m *BookData) UpdateImportJob(ctx context.Context, job book.ImportJob) error {
+	ret := _m.Called(ctx, job)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, book.ImportJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewBookData interface {
 	mock.TestingT
 	Cleanup(func())
@@ -3,6 +3,9 @@
 package mocks
 
 import (
+	context "context"
+	multipart "mime/multipart"
+
 	book "api/features/book"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,20 +16,20 @@ type BookService struct {
 	mock.Mock
 }
 
-// Add provides a mock function with given fields: token, newBook
-func (_m *BookService) Add(token interface{}, newBook book.Core) (book.Core, error) {
-	ret := _m.Called(token, newBook)
+// Add provides a mock function with given fields: ctx, token, newBook
+func (_m *BookService) Add(ctx context.Context, token interface{}, newBook book.Core) (book.Core, error) {
+	ret := _m.Called(ctx, token, newBook)
 
 	var r0 book.Core
-	if rf, ok := ret.Get(0).(func(interface{}, book.Core) book.Core); ok {
-		r0 = rf(token, newBook)
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, book.Core) book.Core); ok {
+		r0 = rf(ctx, token, newBook)
 	} else {
 		r0 = ret.Get(0).(book.Core)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(interface{}, book.Core) error); ok {
-		r1 = rf(token, newBook)
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, book.Core) error); ok {
+		r1 = rf(ctx, token, newBook)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -34,13 +37,13 @@ func (_m *BookService) Add(token interface{}, newBook book.Core) (book.Core, err
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: token, bookID
-func (_m *BookService) Delete(token interface{}, bookID uint) error {
-	ret := _m.Called(token, bookID)
+// Delete provides a mock function with given fields: ctx, token, bookID, version
+func (_m *BookService) Delete(ctx context.Context, token interface{}, bookID uint, version uint) error {
+	ret := _m.Called(ctx, token, bookID, version)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(interface{}, uint) error); ok {
-		r0 = rf(token, bookID)
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, uint, uint) error); ok {
+		r0 = rf(ctx, token, bookID, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -48,20 +51,92 @@ func (_m *BookService) Delete(token interface{}, bookID uint) error {
 	return r0
 }
 
-// Update provides a mock function with given fields: token, bookID, updatedData
-func (_m *BookService) Update(token interface{}, bookID uint, updatedData book.Core) (book.Core, error) {
-	ret := _m.Called(token, bookID, updatedData)
+// Detail provides a mock function with given fields: ctx, bookID
+func (_m *BookService) Detail(ctx context.Context, bookID uint) (book.Core, error) {
+	ret := _m.Called(ctx, bookID)
 
 	var r0 book.Core
-	if rf, ok := ret.Get(0).(func(interface{}, uint, book.Core) book.Core); ok {
-		r0 = rf(token, bookID, updatedData)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) book.Core); ok {
+		r0 = rf(ctx, bookID)
 	} else {
 		r0 = ret.Get(0).(book.Core)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(interface{}, uint, book.Core) error); ok {
-		r1 = rf(token, bookID, updatedData)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// History provides a mock function with given fields: ctx, token, bookID, page, limit
+func (_m *BookService) History(ctx context.Context, token interface{}, bookID uint, page int, limit int) ([]book.Revision, int64, error) {
+	ret := _m.Called(ctx, token, bookID, page, limit)
+
+	var r0 []book.Revision
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, uint, int, int) []book.Revision); ok {
+		r0 = rf(ctx, token, bookID, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]book.Revision)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, uint, int, int) int64); ok {
+		r1 = rf(ctx, token, bookID, page, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, interface{}, uint, int, int) error); ok {
+		r2 = rf(ctx, token, bookID, page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Import provides a mock function with given fields: ctx, token, rows
+func (_m *BookService) Import(ctx context.Context, token interface{}, rows []book.ImportRow) (book.ImportJob, error) {
+	ret := _m.Called(ctx, token, rows)
+
+	var r0 book.ImportJob
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, []book.ImportRow) book.ImportJob); ok {
+		r0 = rf(ctx, token, rows)
+	} else {
+		r0 = ret.Get(0).(book.ImportJob)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, []book.ImportRow) error); ok {
+		r1 = rf(ctx, token, rows)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportStatus provides a mock function with given fields: ctx, token, jobID
+func (_m *BookService) ImportStatus(ctx context.Context, token interface{}, jobID uint) (book.ImportJob, error) {
+	ret := _m.Called(ctx, token, jobID)
+
+	var r0 book.ImportJob
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, uint) book.ImportJob); ok {
+		r0 = rf(ctx, token, jobID)
+	} else {
+		r0 = ret.Get(0).(book.ImportJob)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, uint) error); ok {
+		r1 = rf(ctx, token, jobID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -69,6 +144,199 @@ func (_m *BookService) Update(token interface{}, bookID uint, updatedData book.C
 	return r0, r1
 }
 
+// List provides a mock function with given fields: ctx, filter
+func (_m *BookService) List(ctx context.Context, filter book.Filter) ([]book.Core, int64, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []book.Core
+	if rf, ok := ret.Get(0).(func(context.Context, book.Filter) []book.Core); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]book.Core)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, book.Filter) int64); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, book.Filter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Purge provides a mock function with given fields: ctx, token, bookID
+func (_m *BookService) Purge(ctx context.Context, token interface{}, bookID uint) error {
+	ret := _m.Called(ctx, token, bookID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, uint) error); ok {
+		r0 = rf(ctx, token, bookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PurgeTrash provides a mock function with given fields: ctx
+func (_m *BookService) PurgeTrash(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, token, bookID
+func (_m *BookService) Restore(ctx context.Context, token interface{}, bookID uint) (book.Core, error) {
+	ret := _m.Called(ctx, token, bookID)
+
+	var r0 book.Core
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, uint) book.Core); ok {
+		r0 = rf(ctx, token, bookID)
+	} else {
+		r0 = ret.Get(0).(book.Core)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, uint) error); ok {
+		r1 = rf(ctx, token, bookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revert provides a mock function with given fields: ctx, token, bookID, rev, version
+func (_m *BookService) Revert(ctx context.Context, token interface{}, bookID uint, rev uint, version uint) (book.Core, error) {
+	ret := _m.Called(ctx, token, bookID, rev, version)
+
+	var r0 book.Core
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, uint, uint, uint) book.Core); ok {
+		r0 = rf(ctx, token, bookID, rev, version)
+	} else {
+		r0 = ret.Get(0).(book.Core)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, uint, uint, uint) error); ok {
+		r1 = rf(ctx, token, bookID, rev, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Trash provides a mock function with given fields: ctx, token, page, limit
+func (_m *BookService) Trash(ctx context.Context, token interface{}, page int, limit int) ([]book.Core, int64, error) {
+	ret := _m.Called(ctx, token, page, limit)
+
+	var r0 []book.Core
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, int, int) []book.Core); ok {
+		r0 = rf(ctx, token, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]book.Core)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, int, int) int64); ok {
+		r1 = rf(ctx, token, page, limit)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, interface{}, int, int) error); ok {
+		r2 = rf(ctx, token, page, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Update provides a mock function with given fields: ctx, token, bookID, updatedData, fields
+func (_m *BookService) Update(ctx context.Context, token interface{}, bookID uint, updatedData book.Core, fields []string) (book.Core, error) {
+	ret := _m.Called(ctx, token, bookID, updatedData, fields)
+
+	var r0 book.Core
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, uint, book.Core, []string) book.Core); ok {
+		r0 = rf(ctx, token, bookID, updatedData, fields)
+	} else {
+		r0 = ret.Get(0).(book.Core)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, uint, book.Core, []string) error); ok {
+		r1 = rf(ctx, token, bookID, updatedData, fields)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadCover provides a mock function with given fields: ctx, token, bookID, file
+func (_m *BookService) UploadCover(ctx context.Context, token interface{}, bookID uint, file *multipart.FileHeader) (book.Core, error) {
+	ret := _m.Called(ctx, token, bookID, file)
+
+	var r0 book.Core
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, uint, *multipart.FileHeader) book.Core); ok {
+		r0 = rf(ctx, token, bookID, file)
+	} else {
+		r0 = ret.Get(0).(book.Core)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, uint, *multipart.FileHeader) error); ok {
+		r1 = rf(ctx, token, bookID, file)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WaitImports provides a mock function with given fields: ctx
+func (_m *BookService) WaitImports(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewBookService interface {
 	mock.TestingT
 	Cleanup(func())
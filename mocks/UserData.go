@@ -3,6 +3,9 @@
 package mocks
 
 import (
+	context "context"
+	time "time"
+
 	user "api/features/user"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,13 +16,34 @@ type UserData struct {
 	mock.Mock
 }
 
-// Deactive provides a mock function with given fields: id
-func (_m *UserData) Deactive(id uint) error {
-	ret := _m.Called(id)
+// Deactivated provides a mock function with given fields: ctx, email
+func (_m *UserData) Deactivated(ctx context.Context, email string) (user.Core, error) {
+	ret := _m.Called(ctx, email)
+
+	var r0 user.Core
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.Core); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(user.Core)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Deactive provides a mock function with given fields: ctx, id, version
+func (_m *UserData) Deactive(ctx context.Context, id uint, version uint) error {
+	ret := _m.Called(ctx, id, version)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, id, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -27,20 +51,43 @@ func (_m *UserData) Deactive(id uint) error {
 	return r0
 }
 
-// Login provides a mock function with given fields: email
-func (_m *UserData) Login(email string) (user.Core, error) {
-	ret := _m.Called(email)
+// Expired provides a mock function with given fields: ctx, before
+func (_m *UserData) Expired(ctx context.Context, before time.Time) ([]uint, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 []uint
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []uint); ok {
+		r0 = rf(ctx, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, email
+func (_m *UserData) Login(ctx context.Context, email string) (user.Core, error) {
+	ret := _m.Called(ctx, email)
 
 	var r0 user.Core
-	if rf, ok := ret.Get(0).(func(string) user.Core); ok {
-		r0 = rf(email)
+	if rf, ok := ret.Get(0).(func(context.Context, string) user.Core); ok {
+		r0 = rf(ctx, email)
 	} else {
 		r0 = ret.Get(0).(user.Core)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(email)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -48,20 +95,55 @@ func (_m *UserData) Login(email string) (user.Core, error) {
 	return r0, r1
 }
 
-// Profile provides a mock function with given fields: id
-func (_m *UserData) Profile(id uint) (user.Core, error) {
-	ret := _m.Called(id)
+// Profile provides a mock function with given fields: ctx, id
+func (_m *UserData) Profile(ctx context.Context, id uint) (user.Core, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 user.Core
+	if rf, ok := ret.Get(0).(func(context.Context, uint) user.Core); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(user.Core)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purge provides a mock function with given fields: ctx, id
+func (_m *UserData) Purge(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Register provides a mock function with given fields: ctx, newUser
+func (_m *UserData) Register(ctx context.Context, newUser user.Core) (user.Core, error) {
+	ret := _m.Called(ctx, newUser)
 
 	var r0 user.Core
-	if rf, ok := ret.Get(0).(func(uint) user.Core); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, user.Core) user.Core); ok {
+		r0 = rf(ctx, newUser)
 	} else {
 		r0 = ret.Get(0).(user.Core)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, user.Core) error); ok {
+		r1 = rf(ctx, newUser)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -69,20 +151,20 @@ func (_m *UserData) Profile(id uint) (user.Core, error) {
 	return r0, r1
 }
 
-// Register provides a mock function with given fields: newUser
-func (_m *UserData) Register(newUser user.Core) (user.Core, error) {
-	ret := _m.Called(newUser)
+// Restore provides a mock function with given fields: ctx, id
+func (_m *UserData) Restore(ctx context.Context, id uint) (user.Core, error) {
+	ret := _m.Called(ctx, id)
 
 	var r0 user.Core
-	if rf, ok := ret.Get(0).(func(user.Core) user.Core); ok {
-		r0 = rf(newUser)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) user.Core); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(user.Core)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(user.Core) error); ok {
-		r1 = rf(newUser)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -90,20 +172,20 @@ func (_m *UserData) Register(newUser user.Core) (user.Core, error) {
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: id, updateData
-func (_m *UserData) Update(id uint, updateData user.Core) (user.Core, error) {
-	ret := _m.Called(id, updateData)
+// Update provides a mock function with given fields: ctx, id, updateData, fields
+func (_m *UserData) Update(ctx context.Context, id uint, updateData user.Core, fields []string) (user.Core, error) {
+	ret := _m.Called(ctx, id, updateData, fields)
 
 	var r0 user.Core
-	if rf, ok := ret.Get(0).(func(uint, user.Core) user.Core); ok {
-		r0 = rf(id, updateData)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, user.Core, []string) user.Core); ok {
+		r0 = rf(ctx, id, updateData, fields)
 	} else {
 		r0 = ret.Get(0).(user.Core)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uint, user.Core) error); ok {
-		r1 = rf(id, updateData)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, user.Core, []string) error); ok {
+		r1 = rf(ctx, id, updateData, fields)
 	} else {
 		r1 = ret.Error(1)
 	}
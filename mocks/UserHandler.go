@@ -28,6 +28,22 @@ func (_m *UserHandler) Deactive() echo.HandlerFunc {
 	return r0
 }
 
+// Export provides a mock function with given fields:
+func (_m *UserHandler) Export() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // Login provides a mock function with given fields:
 func (_m *UserHandler) Login() echo.HandlerFunc {
 	ret := _m.Called()
@@ -76,6 +92,22 @@ func (_m *UserHandler) Register() echo.HandlerFunc {
 	return r0
 }
 
+// Restore provides a mock function with given fields:
+func (_m *UserHandler) Restore() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields:
 func (_m *UserHandler) Update() echo.HandlerFunc {
 	ret := _m.Called()
@@ -3,6 +3,9 @@
 package mocks
 
 import (
+	context "context"
+	io "io"
+
 	user "api/features/user"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,13 +16,13 @@ type UserService struct {
 	mock.Mock
 }
 
-// Deactive provides a mock function with given fields: token
-func (_m *UserService) Deactive(token interface{}) error {
-	ret := _m.Called(token)
+// Deactive provides a mock function with given fields: ctx, token, version, opt
+func (_m *UserService) Deactive(ctx context.Context, token interface{}, version uint, opt user.Deactivation) error {
+	ret := _m.Called(ctx, token, version, opt)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(interface{}) error); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, uint, user.Deactivation) error); ok {
+		r0 = rf(ctx, token, version, opt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -27,27 +30,50 @@ func (_m *UserService) Deactive(token interface{}) error {
 	return r0
 }
 
-// Login provides a mock function with given fields: email, password
-func (_m *UserService) Login(email string, password string) (string, user.Core, error) {
-	ret := _m.Called(email, password)
+// Export provides a mock function with given fields: ctx, token, format
+func (_m *UserService) Export(ctx context.Context, token interface{}, format string) (func(io.Writer) error, error) {
+	ret := _m.Called(ctx, token, format)
+
+	var r0 func(io.Writer) error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, string) func(io.Writer) error); ok {
+		r0 = rf(ctx, token, format)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func(io.Writer) error)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, string) error); ok {
+		r1 = rf(ctx, token, format)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, email, password
+func (_m *UserService) Login(ctx context.Context, email string, password string) (string, user.Core, error) {
+	ret := _m.Called(ctx, email, password)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(email, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, email, password)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 user.Core
-	if rf, ok := ret.Get(1).(func(string, string) user.Core); ok {
-		r1 = rf(email, password)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) user.Core); ok {
+		r1 = rf(ctx, email, password)
 	} else {
 		r1 = ret.Get(1).(user.Core)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(string, string) error); ok {
-		r2 = rf(email, password)
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, email, password)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -55,20 +81,41 @@ func (_m *UserService) Login(email string, password string) (string, user.Core,
 	return r0, r1, r2
 }
 
-// Profile provides a mock function with given fields: token
-func (_m *UserService) Profile(token interface{}) (user.Core, error) {
-	ret := _m.Called(token)
+// Profile provides a mock function with given fields: ctx, token
+func (_m *UserService) Profile(ctx context.Context, token interface{}) (user.Core, error) {
+	ret := _m.Called(ctx, token)
 
 	var r0 user.Core
-	if rf, ok := ret.Get(0).(func(interface{}) user.Core); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) user.Core); ok {
+		r0 = rf(ctx, token)
 	} else {
 		r0 = ret.Get(0).(user.Core)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(interface{}) error); ok {
-		r1 = rf(token)
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purge provides a mock function with given fields: ctx
+func (_m *UserService) Purge(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -76,20 +123,20 @@ func (_m *UserService) Profile(token interface{}) (user.Core, error) {
 	return r0, r1
 }
 
-// Register provides a mock function with given fields: newUser
-func (_m *UserService) Register(newUser user.Core) (user.Core, error) {
-	ret := _m.Called(newUser)
+// Register provides a mock function with given fields: ctx, newUser
+func (_m *UserService) Register(ctx context.Context, newUser user.Core) (user.Core, error) {
+	ret := _m.Called(ctx, newUser)
 
 	var r0 user.Core
-	if rf, ok := ret.Get(0).(func(user.Core) user.Core); ok {
-		r0 = rf(newUser)
+	if rf, ok := ret.Get(0).(func(context.Context, user.Core) user.Core); ok {
+		r0 = rf(ctx, newUser)
 	} else {
 		r0 = ret.Get(0).(user.Core)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(user.Core) error); ok {
-		r1 = rf(newUser)
+	if rf, ok := ret.Get(1).(func(context.Context, user.Core) error); ok {
+		r1 = rf(ctx, newUser)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -97,20 +144,48 @@ func (_m *UserService) Register(newUser user.Core) (user.Core, error) {
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: token, updateData
-func (_m *UserService) Update(token interface{}, updateData user.Core) (user.Core, error) {
-	ret := _m.Called(token, updateData)
+// Restore provides a mock function with given fields: ctx, email, password
+func (_m *UserService) Restore(ctx context.Context, email string, password string) (string, user.Core, error) {
+	ret := _m.Called(ctx, email, password)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, email, password)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 user.Core
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) user.Core); ok {
+		r1 = rf(ctx, email, password)
+	} else {
+		r1 = ret.Get(1).(user.Core)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, email, password)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Update provides a mock function with given fields: ctx, token, updateData, fields
+func (_m *UserService) Update(ctx context.Context, token interface{}, updateData user.Core, fields []string) (user.Core, error) {
+	ret := _m.Called(ctx, token, updateData, fields)
 
 	var r0 user.Core
-	if rf, ok := ret.Get(0).(func(interface{}, user.Core) user.Core); ok {
-		r0 = rf(token, updateData)
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, user.Core, []string) user.Core); ok {
+		r0 = rf(ctx, token, updateData, fields)
 	} else {
 		r0 = ret.Get(0).(user.Core)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(interface{}, user.Core) error); ok {
-		r1 = rf(token, updateData)
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, user.Core, []string) error); ok {
+		r1 = rf(ctx, token, updateData, fields)
 	} else {
 		r1 = ret.Error(1)
 	}
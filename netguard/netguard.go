@@ -0,0 +1,104 @@
+// Package netguard menolak koneksi keluar ke alamat internal seperti
+// loopback, jaringan privat dan link-local. Dipakai untuk request ke URL
+// yang ditentukan user (webhook) agar server tidak bisa dipakai memindai
+// layanan internal.
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+var ErrBlocked = errors.New("alamat tujuan tidak diizinkan")
+
+// blocked berisi rentang yang tidak tercakup method net.IP: "this network"
+// dan shared address space (CGNAT) yang juga dipakai metadata sebagian
+// penyedia cloud.
+var blocked = []*net.IPNet{
+	mustCIDR("0.0.0.0/8"),
+	mustCIDR("100.64.0.0/10"),
+}
+
+func mustCIDR(s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// Allowed menandakan ip adalah alamat publik yang boleh dihubungi.
+func Allowed(ip net.IP) bool {
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, n := range blocked {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// Control dipasang di net.Dialer.Control. Alamat diperiksa setelah DNS
+// di-resolve sehingga DNS rebinding dan redirect ke alamat internal ikut
+// tertolak.
+func Control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return ErrBlocked
+	}
+	if !Allowed(net.ParseIP(host)) {
+		return ErrBlocked
+	}
+	return nil
+}
+
+// Transport membuat http.Transport yang hanya bisa terhubung ke alamat
+// publik. Proxy dari environment tidak dipakai karena proxy bisa
+// meneruskan request ke alamat internal.
+func Transport(timeout time.Duration) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = nil
+	t.DialContext = (&net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Control:   Control,
+	}).DialContext
+	return t
+}
+
+// Resolver mencari alamat IP host, dipenuhi *net.Resolver.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// CheckHost memastikan semua alamat host boleh dihubungi. Host yang tidak
+// bisa di-resolve juga ditolak.
+func CheckHost(ctx context.Context, r Resolver, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if !Allowed(ip) {
+			return ErrBlocked
+		}
+		return nil
+	}
+
+	addrs, err := r.LookupIPAddr(ctx, host)
+	if err != nil {
+		return err
+	}
+	if len(addrs) == 0 {
+		return ErrBlocked
+	}
+	for _, a := range addrs {
+		if !Allowed(a.IP) {
+			return ErrBlocked
+		}
+	}
+	return nil
+}
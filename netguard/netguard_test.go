@@ -0,0 +1,62 @@
+package netguard_test
+
+import (
+	"api/netguard"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowed(t *testing.T) {
+	blocked := []string{"127.0.0.1", "::1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254",
+		"fe80::1", "fd00::1", "0.0.0.0", "::", "100.100.100.200", "::ffff:127.0.0.1", "224.0.0.1"}
+	for _, ip := range blocked {
+		assert.False(t, netguard.Allowed(net.ParseIP(ip)), ip)
+	}
+	for _, ip := range []string{"93.184.216.34", "2606:2800:220:1::"} {
+		assert.True(t, netguard.Allowed(net.ParseIP(ip)), ip)
+	}
+}
+
+type resolver map[string][]string
+
+func (r resolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	res := []net.IPAddr{}
+	for _, ip := range ips {
+		res = append(res, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return res, nil
+}
+
+func TestCheckHost(t *testing.T) {
+	r := resolver{
+		"example.com":  {"93.184.216.34"},
+		"internal.lan": {"93.184.216.34", "10.0.0.5"},
+	}
+	ctx := context.Background()
+
+	assert.NoError(t, netguard.CheckHost(ctx, r, "example.com"))
+	assert.NoError(t, netguard.CheckHost(ctx, r, "93.184.216.34"))
+	assert.ErrorIs(t, netguard.CheckHost(ctx, r, "internal.lan"), netguard.ErrBlocked)
+	assert.ErrorIs(t, netguard.CheckHost(ctx, r, "169.254.169.254"), netguard.ErrBlocked)
+	assert.Error(t, netguard.CheckHost(ctx, r, "tidak.ada"))
+}
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: netguard.Transport(time.Second)}
+	_, err := client.Get(srv.URL)
+	assert.ErrorIs(t, err, netguard.ErrBlocked)
+}
@@ -0,0 +1,43 @@
+package openapi
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Document menyimpan spec yang dibuat sekali dari route echo. Spec baru
+// dibuat saat pertama kali dibutuhkan, yaitu setelah semua route terdaftar.
+type Document struct {
+	e    *echo.Echo
+	once sync.Once
+	spec *Spec
+}
+
+func NewDocument(e *echo.Echo) *Document {
+	return &Document{e: e}
+}
+
+func (d *Document) Spec() *Spec {
+	d.once.Do(func() { d.spec = Generate(d.e.Routes()) })
+	return d.spec
+}
+
+// Operation mencari operasi untuk method dan path route echo (mis. /books/:id).
+func (d *Document) Operation(method, path string) *Operation {
+	item, ok := d.Spec().Paths[toOpenAPIPath(path)]
+	if !ok {
+		return nil
+	}
+	return (*item)[strings.ToLower(method)]
+}
+
+// Resolve mengikuti $ref ke components.schemas.
+func (s *Spec) Resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		schema = s.Components.Schemas[name]
+	}
+	return schema
+}
@@ -0,0 +1,321 @@
+package openapi
+
+import (
+	"api/helper"
+	"api/patch"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const bearerAuth = "bearerAuth"
+
+// internal berisi route yang sengaja tidak didokumentasikan.
+var internal = []string{"/metrics", "/openapi.json", "/docs", "/files"}
+
+func isInternal(path string) bool {
+	for _, p := range internal {
+		if path == p || strings.HasPrefix(path, p+"/") || strings.HasPrefix(path, p+"*") {
+			return true
+		}
+	}
+	return false
+}
+
+func key(method, path string) string {
+	return method + " " + path
+}
+
+// Diff membandingkan route yang terdaftar di echo dengan daftar Doc.
+// undocumented berisi route tanpa Doc, stale berisi Doc tanpa route.
+func Diff(routes []*echo.Route) (undocumented, stale []string) {
+	registered := map[string]bool{}
+	for _, r := range routes {
+		if isInternal(r.Path) {
+			continue
+		}
+		k := key(r.Method, r.Path)
+		registered[k] = true
+		if _, ok := docs[k]; !ok {
+			undocumented = append(undocumented, k)
+		}
+	}
+	for k := range docs {
+		if !registered[k] {
+			stale = append(stale, k)
+		}
+	}
+	sort.Strings(undocumented)
+	sort.Strings(stale)
+	return undocumented, stale
+}
+
+// Generate membuat dokumen OpenAPI dari route yang terdaftar di echo dan
+// struct request/response pada Doc masing-masing route.
+func Generate(routes []*echo.Route) *Spec {
+	spec := &Spec{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:       "Book Library API",
+			Description: "Dokumen ini dibuat otomatis dari route echo, jangan diubah manual.",
+			Version:     "1.0.0",
+		},
+		Paths: map[string]*PathItem{},
+		Components: Components{
+			SecuritySchemes: map[string]*SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	b := newSchemaBuilder()
+	errSchema := b.schemaOf(reflect.TypeOf(ErrorResponse{}))
+	vldSchema := b.schemaOf(reflect.TypeOf(ValidationErrorResponse{}))
+	tags := map[string]bool{}
+
+	for _, r := range routes {
+		doc, ok := docs[key(r.Method, r.Path)]
+		if !ok || isInternal(r.Path) {
+			continue
+		}
+
+		path := toOpenAPIPath(r.Path)
+		item, ok := spec.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			spec.Paths[path] = item
+		}
+		(*item)[strings.ToLower(r.Method)] = operation(b, r.Method, r.Path, doc, errSchema, vldSchema)
+		if doc.Tag != "" {
+			tags[doc.Tag] = true
+		}
+	}
+
+	for t := range tags {
+		spec.Tags = append(spec.Tags, Tag{Name: t})
+	}
+	sort.Slice(spec.Tags, func(i, j int) bool { return spec.Tags[i].Name < spec.Tags[j].Name })
+	spec.Components.Schemas = b.schemas
+
+	return spec
+}
+
+func operation(b *schemaBuilder, method, path string, doc Doc, errSchema, vldSchema *Schema) *Operation {
+	op := &Operation{
+		OperationID: doc.ID,
+		Summary:     doc.Summary,
+		Responses:   map[string]*Response{},
+	}
+	if doc.Tag != "" {
+		op.Tags = []string{doc.Tag}
+	}
+	if doc.Auth {
+		op.Security = []map[string][]string{{bearerAuth: {}}}
+	}
+
+	op.Parameters = pathParams(path, doc.Params)
+	for _, p := range doc.Params {
+		if p.In != "path" {
+			op.Parameters = append(op.Parameters, p)
+		}
+	}
+	if doc.Query != nil {
+		op.Parameters = append(op.Parameters, queryParams(b, reflect.TypeOf(doc.Query))...)
+	}
+	if doc.ETag && (method != http.MethodPost || doc.IfMatch) {
+		op.Parameters = append(op.Parameters, etagParam(method))
+	}
+
+	if doc.Body != nil {
+		t := reflect.TypeOf(doc.Body)
+		schema := b.schemaOf(t)
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]*MediaType{}}
+		switch {
+		case hasFileField(t):
+			op.RequestBody.Content[echo.MIMEMultipartForm] = &MediaType{Schema: schema}
+		case hasFormTag(t):
+			op.RequestBody.Content[echo.MIMEApplicationJSON] = &MediaType{Schema: schema}
+			op.RequestBody.Content[echo.MIMEApplicationForm] = &MediaType{Schema: schema}
+		default:
+			op.RequestBody.Content[echo.MIMEApplicationJSON] = &MediaType{Schema: schema}
+		}
+		if doc.Patch {
+			op.RequestBody.Content[patch.MIMEMergePatch] = &MediaType{Schema: schema}
+			op.RequestBody.Content[patch.MIMEJSONPatch] = &MediaType{Schema: b.schemaOf(reflect.TypeOf([]PatchOperation{}))}
+		}
+	}
+
+	success := successSchema(b, doc)
+	for _, code := range append([]int{doc.Status}, doc.AltStatus...) {
+		op.Responses[strconv.Itoa(code)] = &Response{
+			Description: http.StatusText(code),
+			Content:     map[string]*MediaType{echo.MIMEApplicationJSON: {Schema: success}},
+		}
+		for _, ctype := range doc.Produces {
+			op.Responses[strconv.Itoa(code)].Content[ctype] = &MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+		}
+	}
+	if doc.Auth {
+		op.Responses[strconv.Itoa(http.StatusUnauthorized)] = errorResponse(http.StatusUnauthorized, errSchema)
+	}
+	for _, code := range doc.Errors {
+		op.Responses[strconv.Itoa(code)] = errorResponse(code, errSchema)
+	}
+	if doc.ETag {
+		etagResponses(op, method, errSchema)
+	}
+	if len(op.Parameters) > 0 || op.RequestBody != nil {
+		op.Responses[strconv.Itoa(http.StatusBadRequest)] = errorResponse(http.StatusBadRequest, vldSchema)
+	}
+	if op.RequestBody != nil {
+		op.Responses[strconv.Itoa(http.StatusUnprocessableEntity)] = errorResponse(http.StatusUnprocessableEntity, vldSchema)
+	}
+
+	return op
+}
+
+func successSchema(b *schemaBuilder, doc Doc) *Schema {
+	if doc.Raw != nil {
+		return b.schemaOf(reflect.TypeOf(doc.Raw))
+	}
+	if doc.Data == nil {
+		return &Schema{Type: "string"}
+	}
+
+	s := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"message": {Type: "string"},
+			"data":    b.schemaOf(reflect.TypeOf(doc.Data)),
+		},
+		Required: []string{"data"},
+	}
+	if doc.Token {
+		s.Properties["token"] = &Schema{Type: "string"}
+		s.Required = append(s.Required, "token")
+	}
+	if doc.Paginated {
+		s.Properties["pagination"] = b.schemaOf(reflect.TypeOf(helper.Pagination{}))
+		s.Required = append(s.Required, "pagination")
+	}
+	return s
+}
+
+// queryParams membuat query parameter dari field struct yang bertag query.
+func queryParams(b *schemaBuilder, t reflect.Type) []Parameter {
+	var params []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("query"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		s := b.schemaOf(f.Type)
+		p := Parameter{Name: name, In: "query", Schema: s}
+		p.Required = applyValidate(s, f.Tag.Get("validate"))
+		params = append(params, p)
+	}
+	return params
+}
+
+func errorResponse(code int, errSchema *Schema) *Response {
+	return &Response{
+		Description: http.StatusText(code),
+		Content:     map[string]*MediaType{echo.MIMEApplicationJSON: {Schema: errSchema}},
+	}
+}
+
+// etagParam membuat header If-None-Match untuk GET dan If-Match untuk
+// method lain.
+func etagParam(method string) Parameter {
+	if method == http.MethodGet {
+		return Parameter{
+			Name: "If-None-Match", In: "header", Schema: &Schema{Type: "string"},
+			Description: "ETag yang sudah dimiliki client, response 304 bila data belum berubah",
+		}
+	}
+	return Parameter{
+		Name: "If-Match", In: "header", Schema: &Schema{Type: "string"},
+		Description: "ETag hasil baca terakhir, response 412 bila data sudah diubah",
+	}
+}
+
+// etagResponses menambahkan header ETag ke response sukses (kecuali DELETE)
+// beserta response 304 untuk GET atau 412 untuk PATCH/DELETE.
+func etagResponses(op *Operation, method string, errSchema *Schema) {
+	for code, res := range op.Responses {
+		if code[0] == '2' && method != http.MethodDelete {
+			res.Headers = map[string]*Header{"ETag": {Description: "Versi data", Schema: &Schema{Type: "string"}}}
+		}
+	}
+	switch method {
+	case http.MethodPost:
+		return
+	case http.MethodGet:
+		op.Responses[strconv.Itoa(http.StatusNotModified)] = &Response{Description: http.StatusText(http.StatusNotModified)}
+		return
+	}
+	op.Responses[strconv.Itoa(http.StatusPreconditionFailed)] = errorResponse(http.StatusPreconditionFailed, errSchema)
+}
+
+// pathParams membuat parameter untuk setiap segmen ":nama" pada path echo.
+// Secara default path parameter adalah integer ID positif.
+func pathParams(path string, override []Parameter) []Parameter {
+	var params []Parameter
+	for _, seg := range strings.Split(path, "/") {
+		if !strings.HasPrefix(seg, ":") {
+			continue
+		}
+		name := seg[1:]
+		p := Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "integer", Minimum: floatPtr(1)}}
+		for _, o := range override {
+			if o.In == "path" && o.Name == name {
+				p = o
+				p.Required = true
+			}
+		}
+		params = append(params, p)
+	}
+	return params
+}
+
+func toOpenAPIPath(path string) string {
+	segs := strings.Split(path, "/")
+	for i, seg := range segs {
+		if strings.HasPrefix(seg, ":") {
+			segs[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segs, "/")
+}
+
+func hasFormTag(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("form"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFileField menandakan request berupa upload file (multipart/form-data).
+func hasFileField(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i).Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft == fileType {
+			return true
+		}
+	}
+	return false
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
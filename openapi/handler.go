@@ -0,0 +1,37 @@
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+const initializerJS = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: "/openapi.json",
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+// Register menambahkan /openapi.json dan Swagger UI di /docs.
+func Register(e *echo.Echo, doc *Document) {
+	e.GET("/openapi.json", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, doc.Spec())
+	})
+
+	e.GET("/docs", func(c echo.Context) error {
+		return c.Redirect(http.StatusMovedPermanently, "/docs/index.html")
+	})
+	e.GET("/docs/index.html", func(c echo.Context) error {
+		return c.HTMLBlob(http.StatusOK, swaggerFiles.FileIndexHTML)
+	})
+	e.GET("/docs/swagger-initializer.js", func(c echo.Context) error {
+		return c.Blob(http.StatusOK, "application/javascript", []byte(initializerJS))
+	})
+	e.GET("/docs/*", echo.WrapHandler(http.StripPrefix("/docs", http.FileServer(swaggerFiles.HTTP))))
+}
@@ -0,0 +1,66 @@
+package openapi_test
+
+import (
+	audhl "api/features/audit/handler"
+	ahl "api/features/author/handler"
+	bhl "api/features/book/handler"
+	rhl "api/features/review/handler"
+	shl "api/features/shelf/handler"
+	uhl "api/features/user/handler"
+	whhl "api/features/webhook/handler"
+	"api/health"
+	"api/openapi"
+	"api/routes"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "tulis ulang openapi.yaml dari route yang terdaftar")
+
+const specFile = "../openapi.yaml"
+
+func newRouter() *echo.Echo {
+	e := echo.New()
+	routes.Register(e, routes.Handlers{
+		JWT:     func(next echo.HandlerFunc) echo.HandlerFunc { return next },
+		User:    uhl.New(nil),
+		Book:    bhl.New(nil),
+		Author:  ahl.New(nil),
+		Review:  rhl.New(nil),
+		Shelf:   shl.New(nil),
+		Audit:   audhl.New(nil),
+		Webhook: whhl.New(nil),
+		Health:  health.New(),
+	})
+	openapi.Register(e, openapi.NewDocument(e))
+	return e
+}
+
+func TestRoutesDocumented(t *testing.T) {
+	e := newRouter()
+
+	undocumented, stale := openapi.Diff(e.Routes())
+	assert.Empty(t, undocumented, "route belum punya Doc di openapi/operations.go")
+	assert.Empty(t, stale, "Doc tanpa route yang terdaftar")
+}
+
+func TestSpecFileUpToDate(t *testing.T) {
+	e := newRouter()
+
+	spec := openapi.Generate(e.Routes())
+	out, err := spec.YAML()
+	assert.Nil(t, err)
+
+	if *update {
+		assert.Nil(t, os.WriteFile(specFile, out, 0644))
+		return
+	}
+
+	current, err := os.ReadFile(specFile)
+	assert.Nil(t, err)
+	assert.Equal(t, string(out), string(current), "openapi.yaml tidak sesuai route, jalankan: go test ./openapi -update")
+}
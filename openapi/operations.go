@@ -0,0 +1,334 @@
+package openapi
+
+import (
+	audhl "api/features/audit/handler"
+	ahl "api/features/author/handler"
+	bhl "api/features/book/handler"
+	rhl "api/features/review/handler"
+	shl "api/features/shelf/handler"
+	uhl "api/features/user/handler"
+	whhl "api/features/webhook/handler"
+	"net/http"
+)
+
+// ErrorResponse adalah bentuk umum response gagal dari helper PrintErrorResponse.
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// PatchOperation adalah satu operasi JSON Patch (RFC 6902).
+type PatchOperation struct {
+	Op    string      `json:"op" validate:"required,oneof=add remove replace move copy test"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// ValidationErrorResponse dikembalikan middleware validasi saat request tidak
+// sesuai spec: 400 bila request tidak bisa dibaca, 422 bila isi body salah.
+type ValidationErrorResponse struct {
+	Message string      `json:"message"`
+	Errors  []Violation `json:"errors"`
+}
+
+// Doc mendeskripsikan satu route. Route yang terdaftar di echo tetapi tidak
+// punya Doc (atau sebaliknya) akan membuat test openapi gagal.
+type Doc struct {
+	ID      string
+	Summary string
+	Tag     string
+	// Auth menandakan route memakai middleware JWT.
+	Auth bool
+	// Params berisi query parameter, serta path parameter yang tipenya
+	// bukan integer ID.
+	Params []Parameter
+	// Query adalah struct request dengan tag query, setiap field menjadi
+	// query parameter opsional.
+	Query interface{}
+	// Body adalah struct request dari package handler.
+	Body interface{}
+	// Status adalah kode response sukses.
+	Status int
+	// AltStatus adalah kode response sukses lain dengan isi yang sama,
+	// misalnya 202 untuk proses yang dilanjutkan di background.
+	AltStatus []int
+	// Data adalah isi field "data" pada response sukses. Bila nil, response
+	// sukses berupa teks biasa.
+	Data interface{}
+	// Token menandakan response sukses juga membawa field "token".
+	Token bool
+	// Paginated menandakan Data berupa daftar dan response sukses membawa
+	// field "pagination".
+	Paginated bool
+	// Raw dipakai untuk response yang tidak dibungkus field "data".
+	Raw interface{}
+	// Produces berisi content type lain dari response sukses yang berupa
+	// file, selain application/json.
+	Produces []string
+	// Errors adalah kode response gagal yang mungkin dikembalikan.
+	Errors []int
+	// Patch menandakan Body juga diterima sebagai merge patch dan JSON Patch.
+	Patch bool
+	// ETag menandakan response sukses membawa header ETag. Route GET
+	// menerima If-None-Match (304), PATCH/DELETE menerima If-Match (412).
+	ETag bool
+	// IfMatch menandakan route POST yang mengubah data yang sudah ada juga
+	// menerima If-Match, kode 412 tetap perlu disebut di Errors.
+	IfMatch bool
+}
+
+var docs = map[string]Doc{
+	"GET /healthz": {
+		ID: "liveness", Summary: "Liveness probe", Tag: "system",
+		Status: http.StatusOK, Raw: map[string]interface{}{},
+	},
+	"GET /readyz": {
+		ID: "readiness", Summary: "Readiness probe, memeriksa database dan migrasi", Tag: "system",
+		Status: http.StatusOK, Raw: map[string]interface{}{}, Errors: []int{http.StatusServiceUnavailable},
+	},
+	"GET /version": {
+		ID: "version", Summary: "Informasi build", Tag: "system",
+		Status: http.StatusOK, Raw: map[string]interface{}{},
+	},
+
+	"POST /register": {
+		ID: "register", Summary: "Mendaftarkan user baru", Tag: "users",
+		Body: uhl.RegisterRequest{}, Status: http.StatusCreated, Data: uhl.UserReponse{},
+		Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"POST /login": {
+		ID: "login", Summary: "Login dan mendapatkan token JWT", Tag: "users",
+		Body: uhl.LoginRequest{}, Status: http.StatusOK, Data: uhl.UserReponse{}, Token: true,
+		Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"GET /users": {
+		ID: "profile", Summary: "Melihat profil user yang sedang login", Tag: "users", Auth: true,
+		Status: http.StatusOK, Data: uhl.UserReponse{}, ETag: true,
+		Errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"PATCH /users": {
+		ID: "updateProfile", Summary: "Mengubah sebagian profil user yang sedang login dengan merge patch atau JSON Patch", Tag: "users", Auth: true,
+		Body: uhl.UpdateRequest{}, Status: http.StatusOK, Data: uhl.UserReponse{}, Patch: true, ETag: true,
+		Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"GET /users/export": {
+		ID: "exportUser", Summary: "Mengunduh profil dan seluruh buku user dalam format json, csv atau zip", Tag: "users", Auth: true,
+		Query: uhl.ExportRequest{}, Status: http.StatusOK, Raw: uhl.ExportResponse{}, Produces: []string{"text/csv", "application/zip"},
+		Errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"DELETE /users": {
+		ID: "deactivate", Summary: "Menonaktifkan akun user yang sedang login, buku disembunyikan atau dipindahkan ke user lain", Tag: "users", Auth: true,
+		Query: uhl.DeactiveRequest{}, Status: http.StatusAccepted, ETag: true,
+		Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"POST /users/restore": {
+		ID: "restoreUser", Summary: "Memulihkan akun nonaktif selama masa pemulihan lalu login", Tag: "users",
+		Body: uhl.LoginRequest{}, Status: http.StatusOK, Data: uhl.UserReponse{}, Token: true,
+		Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+	},
+
+	"POST /books": {
+		ID: "addBook", Summary: "Menambahkan buku milik user, cukup dengan ISBN bila metadata ditemukan", Tag: "books", Auth: true,
+		Body: bhl.AddBookRequest{}, Status: http.StatusCreated, Data: bhl.BookResponse{}, ETag: true,
+		Errors: []int{http.StatusBadRequest, http.StatusInternalServerError},
+	},
+	"GET /books": {
+		ID: "listBooks", Summary: "Mencari buku berdasarkan metadata", Tag: "books",
+		Query: bhl.ListBookRequest{}, Status: http.StatusOK, Data: []bhl.BookResponse{}, Paginated: true,
+		Errors: []int{http.StatusInternalServerError},
+	},
+	"GET /books/:id": {
+		ID: "getBook", Summary: "Melihat detail buku", Tag: "books",
+		Status: http.StatusOK, Data: bhl.BookResponse{}, ETag: true,
+		Errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"POST /books/import": {
+		ID: "importBooks", Summary: "Import banyak buku dari CSV atau JSON Lines, lebih dari 100 baris diproses di background", Tag: "books", Auth: true,
+		Body: bhl.ImportBookRequest{}, Status: http.StatusOK, AltStatus: []int{http.StatusAccepted}, Data: bhl.ImportJobResponse{},
+		Errors: []int{http.StatusRequestEntityTooLarge, http.StatusInternalServerError},
+	},
+	"GET /books/import/:id": {
+		ID: "importStatus", Summary: "Melihat status dan laporan import buku", Tag: "books", Auth: true,
+		Status: http.StatusOK, Data: bhl.ImportJobResponse{},
+		Errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"PATCH /books/:id": {
+		ID: "updateBook", Summary: "Mengubah sebagian buku milik user dengan merge patch atau JSON Patch", Tag: "books", Auth: true,
+		Body: bhl.UpdateBookRequest{}, Status: http.StatusCreated, Data: bhl.BookResponse{}, Patch: true, ETag: true,
+		Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"DELETE /books/:id": {
+		ID: "deleteBook", Summary: "Memindahkan buku milik user ke sampah", Tag: "books", Auth: true,
+		Status: http.StatusAccepted, ETag: true,
+		Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"GET /books/trash": {
+		ID: "listTrash", Summary: "Melihat buku di sampah beserta waktu penghapusan permanennya", Tag: "books", Auth: true,
+		Query: bhl.TrashRequest{}, Status: http.StatusOK, Data: []bhl.TrashResponse{}, Paginated: true,
+		Errors: []int{http.StatusInternalServerError},
+	},
+	"POST /books/:id/restore": {
+		ID: "restoreBook", Summary: "Memulihkan buku dari sampah", Tag: "books", Auth: true,
+		Status: http.StatusOK, Data: bhl.BookResponse{}, ETag: true,
+		Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"DELETE /books/trash/:id": {
+		ID: "purgeBook", Summary: "Menghapus permanen buku di sampah beserta cover-nya", Tag: "books", Auth: true,
+		Status: http.StatusAccepted,
+		Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"GET /books/:id/history": {
+		ID: "bookHistory", Summary: "Melihat riwayat perubahan metadata buku milik user", Tag: "books", Auth: true,
+		Query: bhl.HistoryRequest{}, Status: http.StatusOK, Data: []bhl.RevisionResponse{}, Paginated: true,
+		Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"POST /books/:id/revert/:rev": {
+		ID: "revertBook", Summary: "Mengembalikan metadata buku ke isi revisi lama", Tag: "books", Auth: true,
+		Status: http.StatusOK, Data: bhl.BookResponse{}, ETag: true, IfMatch: true,
+		Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusPreconditionFailed, http.StatusInternalServerError},
+	},
+	"POST /books/:id/cover": {
+		ID: "uploadBookCover", Summary: "Mengunggah gambar cover buku (jpeg, png, gif, maks 2MB)", Tag: "books", Auth: true,
+		Body: bhl.UploadCoverRequest{}, Status: http.StatusOK, Data: bhl.BookResponse{}, ETag: true,
+		Errors: []int{http.StatusForbidden, http.StatusNotFound, http.StatusRequestEntityTooLarge, http.StatusInternalServerError},
+	},
+
+	"GET /books/:id/reviews": {
+		ID: "listReviews", Summary: "Melihat ulasan buku, terbaru di atas", Tag: "reviews",
+		Query: rhl.ListReviewRequest{}, Status: http.StatusOK, Data: []rhl.ReviewResponse{}, Paginated: true,
+		Errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"POST /books/:id/reviews": {
+		ID: "addReview", Summary: "Memberi rating dan ulasan buku, satu ulasan per user", Tag: "reviews", Auth: true,
+		Body: rhl.AddReviewRequest{}, Status: http.StatusCreated, Data: rhl.ReviewResponse{},
+		Errors: []int{http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+	},
+	"PATCH /reviews/:id": {
+		ID: "updateReview", Summary: "Mengubah ulasan milik user", Tag: "reviews", Auth: true,
+		Body: rhl.UpdateReviewRequest{}, Status: http.StatusOK, Data: rhl.ReviewResponse{},
+		Errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"DELETE /reviews/:id": {
+		ID: "deleteReview", Summary: "Menghapus ulasan milik user", Tag: "reviews", Auth: true,
+		Status: http.StatusAccepted,
+		Errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+
+	"PUT /books/:id/reading": {
+		ID: "setReading", Summary: "Menyimpan status baca (want_to_read, reading, finished) dan progres halaman", Tag: "reading", Auth: true,
+		Body: shl.SetReadingRequest{}, Status: http.StatusOK, Data: shl.ReadingResponse{},
+		Errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"DELETE /books/:id/reading": {
+		ID: "deleteReading", Summary: "Menghapus status baca buku", Tag: "reading", Auth: true,
+		Status: http.StatusAccepted,
+		Errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"GET /reading": {
+		ID: "listReading", Summary: "Daftar baca user, bisa difilter per status", Tag: "reading", Auth: true,
+		Query: shl.ListReadingRequest{}, Status: http.StatusOK, Data: []shl.ReadingResponse{}, Paginated: true,
+		Errors: []int{http.StatusInternalServerError},
+	},
+	"GET /reading/stats": {
+		ID: "readingStats", Summary: "Statistik baca: buku selesai per tahun dan halaman dibaca", Tag: "reading", Auth: true,
+		Status: http.StatusOK, Data: shl.ReadingStatsResponse{},
+		Errors: []int{http.StatusInternalServerError},
+	},
+	"GET /shelves": {
+		ID: "listShelves", Summary: "Daftar rak buatan user", Tag: "reading", Auth: true,
+		Status: http.StatusOK, Data: []shl.ShelfResponse{},
+		Errors: []int{http.StatusInternalServerError},
+	},
+	"POST /shelves": {
+		ID: "addShelf", Summary: "Membuat rak baru", Tag: "reading", Auth: true,
+		Body: shl.ShelfRequest{}, Status: http.StatusCreated, Data: shl.ShelfResponse{},
+		Errors: []int{http.StatusConflict, http.StatusInternalServerError},
+	},
+	"PATCH /shelves/:id": {
+		ID: "updateShelf", Summary: "Mengganti nama rak", Tag: "reading", Auth: true,
+		Body: shl.ShelfRequest{}, Status: http.StatusOK, Data: shl.ShelfResponse{},
+		Errors: []int{http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+	},
+	"DELETE /shelves/:id": {
+		ID: "deleteShelf", Summary: "Menghapus rak, buku di dalamnya tidak ikut terhapus", Tag: "reading", Auth: true,
+		Status: http.StatusAccepted,
+		Errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"GET /shelves/:id/books": {
+		ID: "listShelfBooks", Summary: "Daftar buku di rak", Tag: "reading", Auth: true,
+		Query: shl.ListShelfBookRequest{}, Status: http.StatusOK, Data: []shl.ShelfBookResponse{}, Paginated: true,
+		Errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"PUT /shelves/:id/books/:book_id": {
+		ID: "addShelfBook", Summary: "Memasukkan buku ke rak", Tag: "reading", Auth: true,
+		Status: http.StatusOK,
+		Errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"DELETE /shelves/:id/books/:book_id": {
+		ID: "removeShelfBook", Summary: "Mengeluarkan buku dari rak", Tag: "reading", Auth: true,
+		Status: http.StatusAccepted,
+		Errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+
+	"GET /authors": {
+		ID: "listAuthors", Summary: "Mencari penulis berdasarkan nama", Tag: "authors",
+		Query: ahl.ListAuthorRequest{}, Status: http.StatusOK, Data: []ahl.AuthorResponse{}, Paginated: true,
+		Errors: []int{http.StatusInternalServerError},
+	},
+	"GET /authors/:id": {
+		ID: "getAuthor", Summary: "Halaman penulis beserta daftar bukunya", Tag: "authors",
+		Status: http.StatusOK, Data: ahl.AuthorResponse{},
+		Errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"POST /authors": {
+		ID: "addAuthor", Summary: "Menambahkan penulis", Tag: "authors", Auth: true,
+		Body: ahl.AddAuthorRequest{}, Status: http.StatusCreated, Data: ahl.AuthorResponse{},
+		Errors: []int{http.StatusConflict, http.StatusInternalServerError},
+	},
+	"PATCH /authors/:id": {
+		ID: "updateAuthor", Summary: "Mengubah penulis, nama penulis pada buku ikut diperbarui (khusus admin)", Tag: "authors", Auth: true,
+		Body: ahl.UpdateAuthorRequest{}, Status: http.StatusOK, Data: ahl.AuthorResponse{},
+		Errors: []int{http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+	},
+	"DELETE /authors/:id": {
+		ID: "deleteAuthor", Summary: "Menghapus penulis yang tidak memiliki buku (khusus admin)", Tag: "authors", Auth: true,
+		Status: http.StatusAccepted,
+		Errors: []int{http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+	},
+	"POST /authors/:id/merge": {
+		ID: "mergeAuthor", Summary: "Menggabungkan penulis duplikat ke penulis ini (khusus admin)", Tag: "authors", Auth: true,
+		Body: ahl.MergeAuthorRequest{}, Status: http.StatusOK, Data: ahl.AuthorResponse{},
+		Errors: []int{http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"GET /audit": {
+		ID: "listAudit", Summary: "Melihat audit log perubahan user dan buku (khusus admin)", Tag: "audit", Auth: true,
+		Query: audhl.ListAuditRequest{}, Status: http.StatusOK, Data: []audhl.AuditResponse{}, Paginated: true,
+		Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+	},
+
+	"POST /webhooks": {
+		ID: "addWebhook", Summary: "Mendaftarkan URL webhook untuk event buku dan akun, secret untuk verifikasi signature hanya ditampilkan sekali", Tag: "webhooks", Auth: true,
+		Body: whhl.WebhookRequest{}, Status: http.StatusCreated, Data: whhl.WebhookResponse{},
+		Errors: []int{http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError},
+	},
+	"GET /webhooks": {
+		ID: "listWebhooks", Summary: "Daftar webhook user", Tag: "webhooks", Auth: true,
+		Status: http.StatusOK, Data: []whhl.WebhookResponse{},
+		Errors: []int{http.StatusInternalServerError},
+	},
+	"DELETE /webhooks/:id": {
+		ID: "deleteWebhook", Summary: "Menghapus webhook beserta log delivery-nya", Tag: "webhooks", Auth: true,
+		Status: http.StatusAccepted,
+		Errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"GET /webhooks/:id/deliveries": {
+		ID: "listWebhookDeliveries", Summary: "Log delivery webhook, delivery yang gagal dicoba ulang dengan backoff sampai berstatus dead", Tag: "webhooks", Auth: true,
+		Query: whhl.ListDeliveryRequest{}, Status: http.StatusOK, Data: []whhl.DeliveryResponse{}, Paginated: true,
+		Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+	},
+	"POST /webhooks/:id/test": {
+		ID: "testWebhook", Summary: "Mengirim event ping ke webhook sekali dan mengembalikan hasilnya", Tag: "webhooks", Auth: true,
+		Status: http.StatusOK, Data: whhl.DeliveryResponse{},
+		Errors: []int{http.StatusNotFound, http.StatusInternalServerError},
+	},
+}
@@ -0,0 +1,333 @@
+package openapi
+
+import (
+	"api/patch"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Violation adalah satu pelanggaran terhadap spec pada sebuah request.
+type Violation struct {
+	In      string `json:"in"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// Result menampung hasil validasi. Malformed bernilai true bila request
+// tidak bisa dibaca sama sekali (param salah tipe, body bukan JSON, content
+// type tidak didukung) sehingga dijawab 400, selain itu 422.
+type Result struct {
+	Violations []Violation
+	Malformed  bool
+}
+
+func (r *Result) add(malformed bool, in, field, msg string) {
+	r.Violations = append(r.Violations, Violation{In: in, Field: field, Message: msg})
+	if malformed {
+		r.Malformed = true
+	}
+}
+
+func (r *Result) Valid() bool {
+	return len(r.Violations) == 0
+}
+
+// ValidateRequest memeriksa path param, query param, content type dan body
+// terhadap operasi di spec. body adalah isi request yang sudah dibaca.
+func (s *Spec) ValidateRequest(op *Operation, req *http.Request, pathParams map[string]string, body []byte) Result {
+	res := Result{}
+
+	for _, p := range op.Parameters {
+		switch p.In {
+		case "path":
+			val := pathParams[p.Name]
+			if msg := checkParam(s.Resolve(p.Schema), val); msg != "" {
+				res.add(true, "path", p.Name, msg)
+			}
+		case "query":
+			val, ok := req.URL.Query()[p.Name]
+			if !ok || len(val) == 0 {
+				if p.Required {
+					res.add(true, "query", p.Name, "wajib diisi")
+				}
+				continue
+			}
+			if msg := checkParam(s.Resolve(p.Schema), val[0]); msg != "" {
+				res.add(true, "query", p.Name, msg)
+			}
+		}
+	}
+
+	if op.RequestBody == nil {
+		return res
+	}
+	if req.Header.Get("Content-Type") == "" && len(bytes.TrimSpace(body)) == 0 {
+		if op.RequestBody.Required {
+			res.add(true, "body", "", "body wajib diisi")
+		}
+		return res
+	}
+
+	ctype := ContentType(req)
+	media, ok := op.RequestBody.Content[ctype]
+	if !ok {
+		var allowed []string
+		for k := range op.RequestBody.Content {
+			allowed = append(allowed, k)
+		}
+		sort.Strings(allowed)
+		res.add(true, "header", "Content-Type", "harus salah satu dari "+strings.Join(allowed, ", "))
+		return res
+	}
+	if !HasReadableBody(ctype) {
+		return res
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.RequestBody.Required {
+			res.add(true, "body", "", "body wajib diisi")
+		}
+		return res
+	}
+
+	schema := s.Resolve(media.Schema)
+	switch {
+	case isJSON(ctype):
+		var doc interface{}
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		if err := dec.Decode(&doc); err != nil {
+			res.add(true, "body", "", "JSON tidak valid: "+err.Error())
+			return res
+		}
+		s.validateValue(&res, schema, doc, "")
+	case ctype == echo.MIMEApplicationForm:
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			res.add(true, "body", "", "form tidak valid: "+err.Error())
+			return res
+		}
+		s.validateForm(&res, schema, form)
+	}
+
+	return res
+}
+
+// ContentType mengembalikan content type request tanpa parameter (charset dll).
+func ContentType(req *http.Request) string {
+	ctype, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+	return ctype
+}
+
+// HasReadableBody menandakan body dengan content type ini divalidasi isinya.
+// Body lain seperti multipart hanya diperiksa content type-nya.
+func HasReadableBody(ctype string) bool {
+	return ctype == "" || isJSON(ctype) || ctype == echo.MIMEApplicationForm
+}
+
+func isJSON(ctype string) bool {
+	return ctype == echo.MIMEApplicationJSON || ctype == patch.MIMEMergePatch || ctype == patch.MIMEJSONPatch
+}
+
+func checkParam(schema *Schema, val string) string {
+	if schema == nil {
+		return ""
+	}
+	switch schema.Type {
+	case "integer":
+		n, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return "harus berupa bilangan bulat"
+		}
+		return checkNumber(schema, float64(n))
+	case "number":
+		n, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return "harus berupa angka"
+		}
+		return checkNumber(schema, n)
+	case "boolean":
+		if _, err := strconv.ParseBool(val); err != nil {
+			return "harus berupa boolean"
+		}
+	case "string":
+		return checkString(schema, val)
+	}
+	return ""
+}
+
+func checkNumber(schema *Schema, n float64) string {
+	if schema.Minimum != nil && n < *schema.Minimum {
+		return fmt.Sprintf("minimal %v", *schema.Minimum)
+	}
+	if schema.Maximum != nil && n > *schema.Maximum {
+		return fmt.Sprintf("maksimal %v", *schema.Maximum)
+	}
+	return ""
+}
+
+func checkString(schema *Schema, val string) string {
+	l := utf8.RuneCountInString(val)
+	if schema.MinLength != nil && l < *schema.MinLength {
+		return fmt.Sprintf("minimal %d karakter", *schema.MinLength)
+	}
+	if schema.MaxLength != nil && l > *schema.MaxLength {
+		return fmt.Sprintf("maksimal %d karakter", *schema.MaxLength)
+	}
+	if len(schema.Enum) > 0 {
+		found := false
+		for _, e := range schema.Enum {
+			if e == val {
+				found = true
+			}
+		}
+		if !found {
+			return "harus salah satu dari " + strings.Join(schema.Enum, ", ")
+		}
+	}
+	if schema.Format == "email" && val != "" {
+		if _, err := mail.ParseAddress(val); err != nil {
+			return "format email tidak valid"
+		}
+	}
+	return ""
+}
+
+func (s *Spec) validateValue(res *Result, schema *Schema, val interface{}, field string) {
+	schema = s.Resolve(schema)
+	if schema == nil || (schema.Type == "" && len(schema.Properties) == 0) {
+		return
+	}
+	if val == nil {
+		if !schema.Nullable {
+			res.add(false, "body", field, "tidak boleh null")
+		}
+		return
+	}
+
+	switch schema.Type {
+	case "object":
+		obj, ok := val.(map[string]interface{})
+		if !ok {
+			res.add(false, "body", field, "harus berupa object")
+			return
+		}
+		for _, name := range schema.Required {
+			if v, ok := obj[name]; !ok || isEmpty(v) {
+				res.add(false, "body", join(field, name), "wajib diisi")
+			}
+		}
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if prop, ok := schema.Properties[name]; ok {
+				s.validateValue(res, prop, obj[name], join(field, name))
+			} else if schema.AdditionalProperties != nil {
+				s.validateValue(res, schema.AdditionalProperties, obj[name], join(field, name))
+			}
+		}
+	case "array":
+		arr, ok := val.([]interface{})
+		if !ok {
+			res.add(false, "body", field, "harus berupa array")
+			return
+		}
+		for i, item := range arr {
+			s.validateValue(res, schema.Items, item, fmt.Sprintf("%s[%d]", field, i))
+		}
+	case "string":
+		str, ok := val.(string)
+		if !ok {
+			res.add(false, "body", field, "harus berupa string")
+			return
+		}
+		if msg := checkString(schema, str); msg != "" {
+			res.add(false, "body", field, msg)
+		}
+	case "integer", "number":
+		num, ok := val.(json.Number)
+		if !ok {
+			res.add(false, "body", field, "harus berupa angka")
+			return
+		}
+		if schema.Type == "integer" {
+			if _, err := num.Int64(); err != nil {
+				res.add(false, "body", field, "harus berupa bilangan bulat")
+				return
+			}
+		}
+		f, _ := num.Float64()
+		if msg := checkNumber(schema, f); msg != "" {
+			res.add(false, "body", field, msg)
+		}
+	case "boolean":
+		if _, ok := val.(bool); !ok {
+			res.add(false, "body", field, "harus berupa boolean")
+		}
+	}
+}
+
+func (s *Spec) validateForm(res *Result, schema *Schema, form url.Values) {
+	if schema == nil {
+		return
+	}
+	for _, name := range schema.Required {
+		if form.Get(name) == "" {
+			res.add(false, "body", name, "wajib diisi")
+		}
+	}
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop := schema.Properties[name]
+		val, ok := form[name]
+		if !ok || len(val) == 0 || val[0] == "" {
+			continue
+		}
+		if msg := checkParam(s.Resolve(prop), val[0]); msg != "" {
+			res.add(false, "body", name, msg)
+		}
+	}
+}
+
+// isEmpty meniru aturan validate:"required", nilai kosong dianggap tidak diisi.
+func isEmpty(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == ""
+	case json.Number:
+		f, err := t.Float64()
+		return err == nil && f == 0
+	case bool:
+		return !t
+	}
+	return false
+}
+
+func join(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
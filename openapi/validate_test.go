@@ -0,0 +1,136 @@
+package openapi_test
+
+import (
+	audhl "api/features/audit/handler"
+	ahl "api/features/author/handler"
+	bhl "api/features/book/handler"
+	rhl "api/features/review/handler"
+	shl "api/features/shelf/handler"
+	"api/features/user"
+	uhl "api/features/user/handler"
+	whhl "api/features/webhook/handler"
+	"api/health"
+	"api/middlewares"
+	"api/mocks"
+	"api/openapi"
+	"api/patch"
+	"api/routes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newValidatedRouter(userSrv user.UserService) *echo.Echo {
+	e := echo.New()
+	doc := openapi.NewDocument(e)
+	e.Use(middlewares.Validate(doc))
+	routes.Register(e, routes.Handlers{
+		JWT:     func(next echo.HandlerFunc) echo.HandlerFunc { return next },
+		User:    uhl.New(userSrv),
+		Book:    bhl.New(nil),
+		Author:  ahl.New(nil),
+		Review:  rhl.New(nil),
+		Shelf:   shl.New(nil),
+		Audit:   audhl.New(nil),
+		Webhook: whhl.New(nil),
+		Health:  health.New(),
+	})
+	openapi.Register(e, doc)
+	return e
+}
+
+func doRequest(e *echo.Echo, method, path, ctype, body string) (int, openapi.ValidationErrorResponse) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if ctype != "" {
+		req.Header.Set(echo.HeaderContentType, ctype)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	res := openapi.ValidationErrorResponse{}
+	json.Unmarshal(rec.Body.Bytes(), &res)
+	return rec.Code, res
+}
+
+func TestValidate(t *testing.T) {
+	srv := mocks.NewUserService(t)
+	e := newValidatedRouter(srv)
+
+	t.Run("id bukan angka", func(t *testing.T) {
+		code, res := doRequest(e, http.MethodPatch, "/books/abc", echo.MIMEApplicationJSON, `{"judul":"Naruto"}`)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, []openapi.Violation{{In: "path", Field: "id", Message: "harus berupa bilangan bulat"}}, res.Errors)
+	})
+
+	t.Run("id kurang dari 1", func(t *testing.T) {
+		code, res := doRequest(e, http.MethodDelete, "/books/0", "", "")
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "id", res.Errors[0].Field)
+	})
+
+	t.Run("body bukan json", func(t *testing.T) {
+		code, res := doRequest(e, http.MethodPost, "/books", echo.MIMEApplicationJSON, `{"judul":`)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "body", res.Errors[0].In)
+	})
+
+	t.Run("body terlalu besar ditolak sebelum dibaca seluruhnya", func(t *testing.T) {
+		code, res := doRequest(e, http.MethodPost, "/books", echo.MIMEApplicationJSON, `{"judul":"`+strings.Repeat("a", 2<<20)+`"}`)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+		assert.Equal(t, "body", res.Errors[0].In)
+	})
+
+	t.Run("content type tidak didukung", func(t *testing.T) {
+		code, res := doRequest(e, http.MethodPost, "/books", echo.MIMETextPlain, `judul`)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "Content-Type", res.Errors[0].Field)
+	})
+
+	t.Run("field wajib dan tipe salah", func(t *testing.T) {
+		code, res := doRequest(e, http.MethodPost, "/authors", echo.MIMEApplicationJSON, `{"bio":5}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.ElementsMatch(t, []openapi.Violation{
+			{In: "body", Field: "nama", Message: "wajib diisi"},
+			{In: "body", Field: "bio", Message: "harus berupa string"},
+		}, res.Errors)
+	})
+
+	t.Run("form dengan email tidak valid", func(t *testing.T) {
+		code, res := doRequest(e, http.MethodPost, "/register", echo.MIMEApplicationForm, "nama=alif&email=alif&password=be1422")
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Equal(t, []openapi.Violation{{In: "body", Field: "email", Message: "format email tidak valid"}}, res.Errors)
+	})
+
+	t.Run("status baca di luar enum", func(t *testing.T) {
+		code, res := doRequest(e, http.MethodPut, "/books/1/reading", echo.MIMEApplicationJSON, `{"status":"dropped"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Equal(t, []openapi.Violation{{In: "body", Field: "status", Message: "harus salah satu dari want_to_read, reading, finished"}}, res.Errors)
+	})
+
+	t.Run("operasi json patch tidak dikenal", func(t *testing.T) {
+		code, res := doRequest(e, http.MethodPatch, "/books/1", patch.MIMEJSONPatch, `[{"op":"rename","path":"/judul"}]`)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Equal(t, []openapi.Violation{{In: "body", Field: "[0].op", Message: "harus salah satu dari add, remove, replace, move, copy, test"}}, res.Errors)
+	})
+
+	t.Run("merge patch boleh null tetapi tipe tetap diperiksa", func(t *testing.T) {
+		code, res := doRequest(e, http.MethodPatch, "/books/1", patch.MIMEMergePatch, `{"penerbit":null,"tahun_terbit":"1997"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, code)
+		assert.Equal(t, []openapi.Violation{{In: "body", Field: "tahun_terbit", Message: "harus berupa angka"}}, res.Errors)
+	})
+
+	t.Run("request valid diteruskan ke handler", func(t *testing.T) {
+		resData := user.Core{ID: 1, Nama: "alif", Email: "alif@be14.com"}
+		srv.On("Register", mock.Anything, mock.Anything).Return(resData, nil).Once()
+
+		code, _ := doRequest(e, http.MethodPost, "/register", echo.MIMEApplicationJSON, `{"nama":"alif","email":"alif@be14.com","password":"be1422"}`)
+		assert.Equal(t, http.StatusCreated, code)
+		srv.AssertExpectations(t)
+	})
+}
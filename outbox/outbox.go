@@ -0,0 +1,35 @@
+// Package outbox menyimpan event perubahan data di tabel outbox. Event
+// ditulis di transaksi yang sama dengan perubahannya sehingga hanya event
+// dari perubahan yang berhasil di-commit yang akan diteruskan.
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Message adalah satu event yang menunggu diteruskan. UserID adalah pemilik
+// data yang berubah, dipakai untuk mencari subscription yang berhak
+// menerima event.
+type Message struct {
+	ID        uint   `gorm:"primaryKey"`
+	Event     string `gorm:"size:50"`
+	UserID    uint   `gorm:"index"`
+	Payload   string `gorm:"type:text"`
+	CreatedAt time.Time
+}
+
+func (Message) TableName() string {
+	return "outbox"
+}
+
+// Add menulis event ke outbox memakai tx, payload disimpan sebagai JSON.
+func Add(tx *gorm.DB, event string, userID uint, payload interface{}) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&Message{Event: event, UserID: userID, Payload: string(b)}).Error
+}
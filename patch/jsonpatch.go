@@ -0,0 +1,266 @@
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type operation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// JSONPatch menerapkan daftar operasi add, remove, replace, move, copy dan
+// test secara berurutan. Bila satu operasi gagal, doc tidak berubah sama
+// sekali.
+func JSONPatch(doc, ops []byte) ([]byte, error) {
+	root, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	list := []operation{}
+	if err := json.Unmarshal(ops, &list); err != nil {
+		return nil, fmt.Errorf("format patch tidak sesuai, harus berupa array operasi: %w", err)
+	}
+
+	for i, op := range list {
+		root, err = apply(root, op)
+		if err != nil {
+			if err == ErrTestFailed {
+				return nil, fmt.Errorf("%w (operasi ke-%d)", err, i)
+			}
+			return nil, fmt.Errorf("format patch tidak sesuai, operasi ke-%d: %w", i, err)
+		}
+	}
+	return json.Marshal(root)
+}
+
+func apply(root interface{}, op operation) (interface{}, error) {
+	if op.Path == nil {
+		return nil, fmt.Errorf("path wajib diisi")
+	}
+	path, err := pointer(*op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("value wajib diisi untuk %s", op.Op)
+		}
+		val, err := decode(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		switch op.Op {
+		case "add":
+			return add(root, path, val)
+		case "replace":
+			return replace(root, path, val)
+		}
+		cur, err := get(root, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(cur, val) {
+			return nil, ErrTestFailed
+		}
+		return root, nil
+	case "remove":
+		return remove(root, path)
+	case "move", "copy":
+		if op.From == nil {
+			return nil, fmt.Errorf("from wajib diisi untuk %s", op.Op)
+		}
+		from, err := pointer(*op.From)
+		if err != nil {
+			return nil, err
+		}
+		val, err := get(root, from)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "copy" {
+			raw, _ := json.Marshal(val)
+			val, _ = decode(raw)
+			return add(root, path, val)
+		}
+		if isPrefix(from, path) && len(from) < len(path) {
+			return nil, fmt.Errorf("tidak bisa memindahkan %s ke dalam dirinya sendiri", *op.From)
+		}
+		if root, err = remove(root, from); err != nil {
+			return nil, err
+		}
+		return add(root, path, val)
+	}
+	return nil, fmt.Errorf("op %q tidak dikenal", op.Op)
+}
+
+// pointer memecah JSON Pointer (RFC 6901) menjadi token.
+func pointer(p string) ([]string, error) {
+	if p == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(p, "/") {
+		return nil, fmt.Errorf("path %q harus diawali /", p)
+	}
+	tokens := strings.Split(p[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+	return tokens, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func get(node interface{}, path []string) (interface{}, error) {
+	for _, t := range path {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			v, ok := n[t]
+			if !ok {
+				return nil, fmt.Errorf("path /%s tidak ditemukan", strings.Join(path, "/"))
+			}
+			node = v
+		case []interface{}:
+			i, err := index(t, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, fmt.Errorf("path /%s tidak ditemukan", strings.Join(path, "/"))
+		}
+	}
+	return node, nil
+}
+
+// index membaca indeks array yang harus berada di antara 0 dan max.
+func index(t string, max int) (int, error) {
+	i, err := strconv.Atoi(t)
+	if err != nil || i < 0 || i > max || (len(t) > 1 && t[0] == '0') {
+		return 0, fmt.Errorf("indeks array %q tidak valid", t)
+	}
+	return i, nil
+}
+
+// update menelusuri path sampai container terakhir lalu menggantinya dengan
+// hasil fn, sehingga slice yang berubah panjang ikut tersimpan ke induknya.
+func update(node interface{}, path []string, fn func(container interface{}, key string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return fn(node, path[0])
+	}
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("path /%s tidak ditemukan", path[0])
+		}
+		c, err := update(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[path[0]] = c
+		return n, nil
+	case []interface{}:
+		i, err := index(path[0], len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+		c, err := update(n[i], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = c
+		return n, nil
+	}
+	return nil, fmt.Errorf("path /%s tidak ditemukan", path[0])
+}
+
+func add(root interface{}, path []string, val interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return val, nil
+	}
+	return update(root, path, func(c interface{}, key string) (interface{}, error) {
+		switch n := c.(type) {
+		case map[string]interface{}:
+			n[key] = val
+			return n, nil
+		case []interface{}:
+			if key == "-" {
+				return append(n, val), nil
+			}
+			i, err := index(key, len(n))
+			if err != nil {
+				return nil, err
+			}
+			n = append(n, nil)
+			copy(n[i+1:], n[i:])
+			n[i] = val
+			return n, nil
+		}
+		return nil, fmt.Errorf("tidak bisa menambah %q pada nilai yang bukan object atau array", key)
+	})
+}
+
+func remove(root interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("dokumen tidak bisa dihapus")
+	}
+	return update(root, path, func(c interface{}, key string) (interface{}, error) {
+		switch n := c.(type) {
+		case map[string]interface{}:
+			if _, ok := n[key]; !ok {
+				return nil, fmt.Errorf("field %q tidak ditemukan", key)
+			}
+			delete(n, key)
+			return n, nil
+		case []interface{}:
+			i, err := index(key, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			return append(n[:i], n[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("field %q tidak ditemukan", key)
+	})
+}
+
+func replace(root interface{}, path []string, val interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return val, nil
+	}
+	return update(root, path, func(c interface{}, key string) (interface{}, error) {
+		switch n := c.(type) {
+		case map[string]interface{}:
+			if _, ok := n[key]; !ok {
+				return nil, fmt.Errorf("field %q tidak ditemukan", key)
+			}
+			n[key] = val
+			return n, nil
+		case []interface{}:
+			i, err := index(key, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			n[i] = val
+			return n, nil
+		}
+		return nil, fmt.Errorf("field %q tidak ditemukan", key)
+	})
+}
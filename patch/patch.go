@@ -0,0 +1,155 @@
+// Package patch menerapkan JSON Merge Patch (RFC 7396) dan JSON Patch
+// (RFC 6902) pada dokumen JSON sebuah resource.
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	MIMEMergePatch = "application/merge-patch+json"
+	MIMEJSONPatch  = "application/json-patch+json"
+)
+
+// ErrTestFailed dikembalikan bila operasi test pada JSON Patch tidak cocok.
+var ErrTestFailed = errors.New("conflict: operasi test pada patch tidak terpenuhi")
+
+// Apply menerapkan body pada doc sesuai content type. application/json
+// diperlakukan sama dengan merge patch.
+func Apply(ctype string, doc, body []byte) ([]byte, error) {
+	switch ctype {
+	case MIMEJSONPatch:
+		return JSONPatch(doc, body)
+	case MIMEMergePatch, "application/json", "":
+		return MergePatch(doc, body)
+	}
+	return nil, fmt.Errorf("format patch tidak sesuai, content type %q tidak didukung", ctype)
+}
+
+// MergePatch menerapkan merge patch: field yang tidak ada tidak berubah,
+// null menghapus field dan object digabung secara rekursif.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decode(patch)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(merge(target, p))
+}
+
+func merge(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = merge(t[k], v)
+	}
+	return t
+}
+
+// Changed mengembalikan nama field teratas yang nilainya berbeda antara
+// before dan after, termasuk field yang ditambah atau dihapus.
+func Changed(before, after []byte) ([]string, error) {
+	b, err := decodeObject(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := decodeObject(after)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []string{}
+	for k, v := range a {
+		if old, ok := b[k]; !ok || !equal(old, v) {
+			res = append(res, k)
+		}
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			res = append(res, k)
+		}
+	}
+	sort.Strings(res)
+	return res, nil
+}
+
+func decode(raw []byte) (interface{}, error) {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("format patch tidak sesuai, JSON tidak valid: %w", err)
+	}
+	return v, nil
+}
+
+func decodeObject(raw []byte) (map[string]interface{}, error) {
+	v, err := decode(raw)
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("format patch tidak sesuai, hasil patch harus berupa object")
+	}
+	return obj, nil
+}
+
+// equal membandingkan dua nilai JSON, angka dibandingkan nilainya sehingga
+// 1 dan 1.0 dianggap sama.
+func equal(a, b interface{}) bool {
+	switch x := a.(type) {
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		fx, errx := x.Float64()
+		fy, erry := y.Float64()
+		if errx != nil || erry != nil {
+			return x == y
+		}
+		return fx == fy
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, v := range x {
+			w, ok := y[k]
+			if !ok || !equal(v, w) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
@@ -0,0 +1,79 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const doc = `{"judul":"One Piece","tahun_terbit":1997,"alamat":"Jakarta","genre":["manga","aksi"]}`
+
+func TestMergePatch(t *testing.T) {
+	t.Run("null menghapus dan field lain tetap", func(t *testing.T) {
+		res, err := MergePatch([]byte(doc), []byte(`{"alamat":null,"tahun_terbit":0}`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"judul":"One Piece","tahun_terbit":0,"genre":["manga","aksi"]}`, string(res))
+	})
+
+	t.Run("array diganti utuh", func(t *testing.T) {
+		res, err := MergePatch([]byte(doc), []byte(`{"genre":["drama"]}`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"judul":"One Piece","tahun_terbit":1997,"alamat":"Jakarta","genre":["drama"]}`, string(res))
+	})
+
+	t.Run("JSON tidak valid", func(t *testing.T) {
+		_, err := MergePatch([]byte(doc), []byte(`{"judul":`))
+		assert.ErrorContains(t, err, "format")
+	})
+}
+
+func TestJSONPatch(t *testing.T) {
+	t.Run("operasi berurutan", func(t *testing.T) {
+		res, err := JSONPatch([]byte(doc), []byte(`[
+			{"op":"test","path":"/judul","value":"One Piece"},
+			{"op":"replace","path":"/judul","value":"Naruto"},
+			{"op":"add","path":"/genre/-","value":"shonen"},
+			{"op":"remove","path":"/genre/0"},
+			{"op":"copy","from":"/judul","path":"/alamat"},
+			{"op":"move","from":"/tahun_terbit","path":"/tahun"}
+		]`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"judul":"Naruto","tahun":1997,"alamat":"Naruto","genre":["aksi","shonen"]}`, string(res))
+	})
+
+	t.Run("test gagal", func(t *testing.T) {
+		_, err := JSONPatch([]byte(doc), []byte(`[{"op":"test","path":"/tahun_terbit","value":1998}]`))
+		assert.ErrorIs(t, err, ErrTestFailed)
+		assert.ErrorContains(t, err, "conflict")
+	})
+
+	t.Run("angka dibandingkan nilainya", func(t *testing.T) {
+		_, err := JSONPatch([]byte(doc), []byte(`[{"op":"test","path":"/tahun_terbit","value":1997.0}]`))
+		assert.NoError(t, err)
+	})
+
+	t.Run("path tidak ada", func(t *testing.T) {
+		for _, ops := range []string{
+			`[{"op":"replace","path":"/isbn","value":"x"}]`,
+			`[{"op":"remove","path":"/genre/5"}]`,
+			`[{"op":"add","path":"/genre/01","value":"x"}]`,
+			`[{"op":"move","from":"/genre","path":"/genre/0"}]`,
+			`[{"op":"add","path":"judul","value":"x"}]`,
+			`[{"op":"add","path":"/judul"}]`,
+			`[{"op":"rename","path":"/judul"}]`,
+		} {
+			_, err := JSONPatch([]byte(doc), []byte(ops))
+			assert.ErrorContains(t, err, "format", ops)
+		}
+	})
+}
+
+func TestChanged(t *testing.T) {
+	res, err := Changed([]byte(doc), []byte(`{"judul":"One Piece","tahun_terbit":1997.0,"genre":["aksi"],"isbn":"x"}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alamat", "genre", "isbn"}, res)
+
+	_, err = Changed([]byte(doc), []byte(`[]`))
+	assert.ErrorContains(t, err, "object")
+}
@@ -0,0 +1,111 @@
+// Package replica mengarahkan query baca ke database replica dan perubahan
+// ke primary. Query di dalam transaksi, query dengan row lock dan query
+// setelah request menulis tetap memakai primary.
+package replica
+
+import (
+	"context"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+type sessionKey struct{}
+
+type primaryKey struct{}
+
+// session menandai request yang sudah menulis ke primary.
+type session struct {
+	wrote int32
+}
+
+// WithSession menyiapkan ctx untuk satu request. Setelah request menulis,
+// bacaan berikutnya di request yang sama diarahkan ke primary agar
+// perubahannya langsung terbaca walau replica tertinggal.
+func WithSession(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sessionKey{}, &session{})
+}
+
+// Primary memaksa semua query dengan ctx memakai primary, misalnya untuk
+// data yang akan disimpan di cache.
+func Primary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func usePrimary(ctx context.Context) bool {
+	if ctx.Value(primaryKey{}) != nil {
+		return true
+	}
+	s, ok := ctx.Value(sessionKey{}).(*session)
+	return ok && atomic.LoadInt32(&s.wrote) == 1
+}
+
+// Plugin memilih replica secara bergantian untuk setiap query baca.
+type Plugin struct {
+	replicas []gorm.ConnPool
+	next     uint32
+}
+
+func New(replicas ...gorm.ConnPool) *Plugin {
+	return &Plugin{
+		replicas: replicas,
+	}
+}
+
+func (*Plugin) Name() string {
+	return "replica"
+}
+
+func (p *Plugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Query().Before("gorm:query").Register("replica:route_query", p.route); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register("replica:route_row", p.route); err != nil {
+		return err
+	}
+
+	// Exec (raw) selalu dianggap menulis
+	hooks := []struct {
+		operation string
+		after     func(string, func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().After("gorm:create").Register},
+		{"update", cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().After("gorm:delete").Register},
+		{"raw", cb.Raw().After("gorm:raw").Register},
+	}
+	for _, h := range hooks {
+		if err := h.after("replica:wrote_"+h.operation, wrote); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Plugin) route(db *gorm.DB) {
+	if len(p.replicas) == 0 || db.Statement == nil {
+		return
+	}
+	if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); ok {
+		return
+	}
+	if _, ok := db.Statement.Clauses["FOR"]; ok {
+		return
+	}
+	if ctx := db.Statement.Context; ctx != nil && usePrimary(ctx) {
+		return
+	}
+
+	i := atomic.AddUint32(&p.next, 1)
+	db.Statement.ConnPool = p.replicas[int(i)%len(p.replicas)]
+}
+
+func wrote(db *gorm.DB) {
+	if db.Error != nil || db.Statement == nil || db.Statement.Context == nil {
+		return
+	}
+	if s, ok := db.Statement.Context.Value(sessionKey{}).(*session); ok {
+		atomic.StoreInt32(&s.wrote, 1)
+	}
+}
@@ -0,0 +1,77 @@
+package replica_test
+
+import (
+	"api/dbtest"
+	"api/replica"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Item struct {
+	ID   uint
+	Nama string
+}
+
+// open membuat primary dan replica terpisah yang isinya sengaja berbeda
+// agar terlihat query dijalankan di mana.
+func open(t *testing.T) *gorm.DB {
+	if os.Getenv("TESTDSN") != "" {
+		t.Skip("butuh dua database terpisah")
+	}
+	primary := dbtest.Open(t, Item{})
+	rep := dbtest.Open(t, Item{})
+	require.NoError(t, primary.Create(&Item{ID: 1, Nama: "primary"}).Error)
+	require.NoError(t, rep.Create(&Item{ID: 1, Nama: "replica"}).Error)
+
+	pool, err := rep.DB()
+	require.NoError(t, err)
+	require.NoError(t, primary.Use(replica.New(pool)))
+	return primary
+}
+
+func nama(t *testing.T, db *gorm.DB) string {
+	row := Item{}
+	require.NoError(t, db.First(&row, 1).Error)
+	return row.Nama
+}
+
+func TestRoute(t *testing.T) {
+	db := open(t)
+	ctx := context.Background()
+
+	t.Run("baca dari replica", func(t *testing.T) {
+		assert.Equal(t, "replica", nama(t, db.WithContext(ctx)))
+
+		var n string
+		require.NoError(t, db.WithContext(ctx).Model(&Item{}).Select("nama").Where("id = 1").Row().Scan(&n))
+		assert.Equal(t, "replica", n)
+	})
+
+	t.Run("dipaksa ke primary", func(t *testing.T) {
+		assert.Equal(t, "primary", nama(t, db.WithContext(replica.Primary(ctx))))
+	})
+
+	t.Run("transaksi dan row lock di primary", func(t *testing.T) {
+		err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			assert.Equal(t, "primary", nama(t, tx))
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "primary", nama(t, db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"})))
+	})
+
+	t.Run("baca setelah menulis di request yang sama", func(t *testing.T) {
+		reqCtx := replica.WithSession(ctx)
+		assert.Equal(t, "replica", nama(t, db.WithContext(reqCtx)))
+
+		require.NoError(t, db.WithContext(reqCtx).Create(&Item{ID: 2, Nama: "baru"}).Error)
+		assert.Equal(t, "primary", nama(t, db.WithContext(reqCtx)))
+		assert.Equal(t, "replica", nama(t, db.WithContext(replica.WithSession(ctx))), "request lain tetap ke replica")
+	})
+}
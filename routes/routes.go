@@ -0,0 +1,104 @@
+package routes
+
+import (
+	"api/features/audit"
+	"api/features/author"
+	"api/features/book"
+	"api/features/review"
+	"api/features/shelf"
+	"api/features/user"
+	"api/features/webhook"
+	"api/health"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Handlers berisi semua handler yang didaftarkan ke router.
+type Handlers struct {
+	JWT     echo.MiddlewareFunc
+	User    user.UserHandler
+	Book    book.BookHandler
+	Author  author.AuthorHandler
+	Review  review.ReviewHandler
+	Shelf   shelf.ShelfHandler
+	Audit   audit.AuditHandler
+	Webhook webhook.WebhookHandler
+	Health  *health.Handler
+	// Files adalah direktori blob lokal yang disajikan di /files, kosong
+	// bila blob disimpan di luar (S3).
+	Files string
+}
+
+func Register(e *echo.Echo, h Handlers) {
+	e.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
+	e.GET("/healthz", h.Health.Liveness())
+	e.GET("/readyz", h.Health.Readiness())
+	e.GET("/version", h.Health.Version())
+
+	// users
+	e.POST("/register", h.User.Register())
+	e.POST("/login", h.User.Login())
+	e.GET("/users", h.User.Profile(), h.JWT)
+	e.PATCH("/users", h.User.Update(), h.JWT)
+	e.DELETE("/users", h.User.Deactive(), h.JWT)
+	e.POST("/users/restore", h.User.Restore())
+	e.GET("/users/export", h.User.Export(), h.JWT)
+
+	// books
+	e.POST("/books", h.Book.Add(), h.JWT)
+	e.GET("/books", h.Book.List())
+	e.GET("/books/:id", h.Book.Detail())
+	e.POST("/books/import", h.Book.Import(), h.JWT, middleware.BodyLimit("10M"))
+	e.GET("/books/import/:id", h.Book.ImportStatus(), h.JWT)
+	e.PATCH("/books/:id", h.Book.Update(), h.JWT)
+	e.DELETE("/books/:id", h.Book.Delete(), h.JWT)
+	e.POST("/books/:id/cover", h.Book.UploadCover(), h.JWT, middleware.BodyLimit("3M"))
+	e.GET("/books/trash", h.Book.Trash(), h.JWT)
+	e.POST("/books/:id/restore", h.Book.Restore(), h.JWT)
+	e.DELETE("/books/trash/:id", h.Book.Purge(), h.JWT)
+	e.GET("/books/:id/history", h.Book.History(), h.JWT)
+	e.POST("/books/:id/revert/:rev", h.Book.Revert(), h.JWT)
+
+	// reviews
+	e.GET("/books/:id/reviews", h.Review.List())
+	e.POST("/books/:id/reviews", h.Review.Add(), h.JWT)
+	e.PATCH("/reviews/:id", h.Review.Update(), h.JWT)
+	e.DELETE("/reviews/:id", h.Review.Delete(), h.JWT)
+
+	// reading status dan rak
+	e.PUT("/books/:id/reading", h.Shelf.SetReading(), h.JWT)
+	e.DELETE("/books/:id/reading", h.Shelf.DeleteReading(), h.JWT)
+	e.GET("/reading", h.Shelf.ListReading(), h.JWT)
+	e.GET("/reading/stats", h.Shelf.Stats(), h.JWT)
+	e.GET("/shelves", h.Shelf.ListShelf(), h.JWT)
+	e.POST("/shelves", h.Shelf.AddShelf(), h.JWT)
+	e.PATCH("/shelves/:id", h.Shelf.UpdateShelf(), h.JWT)
+	e.DELETE("/shelves/:id", h.Shelf.DeleteShelf(), h.JWT)
+	e.GET("/shelves/:id/books", h.Shelf.ShelfBooks(), h.JWT)
+	e.PUT("/shelves/:id/books/:book_id", h.Shelf.AddToShelf(), h.JWT)
+	e.DELETE("/shelves/:id/books/:book_id", h.Shelf.RemoveFromShelf(), h.JWT)
+
+	// authors
+	e.GET("/authors", h.Author.List())
+	e.GET("/authors/:id", h.Author.Detail())
+	e.POST("/authors", h.Author.Add(), h.JWT)
+	e.PATCH("/authors/:id", h.Author.Update(), h.JWT)
+	e.DELETE("/authors/:id", h.Author.Delete(), h.JWT)
+	e.POST("/authors/:id/merge", h.Author.Merge(), h.JWT)
+
+	// audit log, hanya admin
+	e.GET("/audit", h.Audit.List(), h.JWT)
+
+	// webhooks
+	e.POST("/webhooks", h.Webhook.Add(), h.JWT)
+	e.GET("/webhooks", h.Webhook.List(), h.JWT)
+	e.DELETE("/webhooks/:id", h.Webhook.Delete(), h.JWT)
+	e.GET("/webhooks/:id/deliveries", h.Webhook.Deliveries(), h.JWT)
+	e.POST("/webhooks/:id/test", h.Webhook.Test(), h.JWT)
+
+	if h.Files != "" {
+		e.Static("/files", h.Files)
+	}
+}
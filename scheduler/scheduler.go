@@ -0,0 +1,34 @@
+// Package scheduler menjalankan pekerjaan berkala di background.
+package scheduler
+
+import (
+	"api/logger"
+	"api/tracing"
+	"context"
+	"time"
+)
+
+// Every menjalankan fn saat dipanggil lalu setiap interval sampai ctx
+// selesai. Error dari fn hanya dicatat agar jadwal berikutnya tetap jalan.
+func Every(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		run(ctx, name, fn)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func run(ctx context.Context, name string, fn func(ctx context.Context) error) {
+	ctx, span := tracing.Start(ctx, "scheduler."+name)
+	defer span.End()
+
+	if err := fn(ctx); err != nil {
+		logger.Error(ctx, "scheduled job error", logger.Fields{"job": name, "error": err})
+	}
+}
@@ -0,0 +1,34 @@
+package scheduler_test
+
+import (
+	"api/scheduler"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var runs int32
+	done := make(chan struct{})
+	go func() {
+		scheduler.Every(ctx, "test", 10*time.Millisecond, func(ctx context.Context) error {
+			// error tidak menghentikan jadwal berikutnya
+			atomic.AddInt32(&runs, 1)
+			return errors.New("gagal")
+		})
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&runs) >= 3 }, time.Second, 5*time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Every tidak berhenti setelah ctx selesai")
+	}
+}
@@ -0,0 +1,114 @@
+// Package uow menyediakan unit of work: beberapa perubahan lintas
+// repository dijalankan dalam satu transaksi database.
+package uow
+
+import (
+	"api/features/book"
+	"api/features/user"
+	"api/tracing"
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+type ctxKey struct{}
+
+type hooksKey struct{}
+
+// hooks menampung fungsi AfterCommit milik satu tingkat unit of work.
+type hooks struct {
+	mu  sync.Mutex
+	fns []func(ctx context.Context)
+}
+
+func (h *hooks) add(fns ...func(ctx context.Context)) {
+	h.mu.Lock()
+	h.fns = append(h.fns, fns...)
+	h.mu.Unlock()
+}
+
+// Repos berisi repository yang terikat pada satu transaksi.
+type Repos struct {
+	Users user.UserData
+	Books book.BookData
+}
+
+// UnitOfWork menjalankan fn di dalam satu transaksi. Transaksi di-commit bila
+// fn mengembalikan nil dan di-rollback bila fn gagal atau panic. Bila ctx
+// sudah membawa transaksi, fn dijalankan di savepoint sehingga kegagalannya
+// hanya membatalkan perubahan fn sendiri. Fungsi yang didaftarkan lewat
+// AfterCommit dijalankan setelah transaksi terluar di-commit.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context, repos Repos) error) error
+}
+
+type gormUoW struct {
+	db    *gorm.DB
+	repos func(db *gorm.DB) Repos
+}
+
+// New membuat UnitOfWork di atas db. repos membuat repository dari koneksi
+// transaksi, biasanya memanggil constructor New milik package data.
+func New(db *gorm.DB, repos func(db *gorm.DB) Repos) UnitOfWork {
+	return &gormUoW{
+		db:    db,
+		repos: repos,
+	}
+}
+
+func (u *gormUoW) Do(ctx context.Context, fn func(ctx context.Context, repos Repos) error) error {
+	ctx, span := tracing.Start(ctx, "UnitOfWork.Do")
+	defer span.End()
+
+	parent, nested := ctx.Value(hooksKey{}).(*hooks)
+	own := &hooks{}
+	err := DB(ctx, u.db).Transaction(func(tx *gorm.DB) error {
+		txCtx := context.WithValue(context.WithValue(ctx, ctxKey{}, tx), hooksKey{}, own)
+		return fn(txCtx, u.repos(tx))
+	})
+	if err != nil {
+		return err
+	}
+
+	// savepoint yang berhasil belum tentu ikut di-commit, hook-nya
+	// diserahkan ke unit of work di atasnya
+	if nested {
+		parent.add(own.fns...)
+		return nil
+	}
+	for _, f := range own.fns {
+		f(ctx)
+	}
+	return nil
+}
+
+// AfterCommit menjalankan fn setelah unit of work terluar pada ctx
+// di-commit. fn dibuang bila transaksi di-rollback, dan langsung dijalankan
+// bila ctx tidak berada di dalam unit of work. Dipakai untuk efek di luar
+// database, misalnya menghapus cache, agar tidak terjadi sebelum perubahan
+// terlihat oleh koneksi lain.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if h, ok := ctx.Value(hooksKey{}).(*hooks); ok {
+		h.add(fn)
+		return
+	}
+	fn(ctx)
+}
+
+// InTx menandakan ctx berada di dalam unit of work sehingga data yang
+// dibaca lewat DB mungkin belum di-commit.
+func InTx(ctx context.Context) bool {
+	_, ok := ctx.Value(ctxKey{}).(*gorm.DB)
+	return ok
+}
+
+// DB mengembalikan transaksi yang dibawa ctx, atau db bila ctx tidak berada
+// di dalam unit of work. Repository memakai DB sebagai pengganti
+// db.WithContext(ctx) agar query ikut transaksi yang sedang berjalan.
+func DB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(ctxKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
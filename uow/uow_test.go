@@ -0,0 +1,166 @@
+package uow_test
+
+import (
+	"api/dbtest"
+	author "api/features/author/data"
+	"api/features/book"
+	bd "api/features/book/data"
+	"api/features/user"
+	ud "api/features/user/data"
+	"api/outbox"
+	"api/uow"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newUoW(t *testing.T) (uow.UnitOfWork, user.UserData, book.BookData) {
+	db := dbtest.Open(t, ud.User{}, bd.Genre{}, bd.Books{}, author.Author{}, bd.BookAuthor{}, bd.BookRevision{}, outbox.Message{})
+	repos := func(db *gorm.DB) uow.Repos {
+		return uow.Repos{Users: ud.New(db), Books: bd.New(db)}
+	}
+	return uow.New(db, repos), ud.New(db), bd.New(db)
+}
+
+var errBatal = errors.New("batal")
+
+func TestDo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("commit semua perubahan", func(t *testing.T) {
+		u, users, books := newUoW(t)
+		err := u.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+			res, err := r.Users.Register(ctx, user.Core{Nama: "alif", Email: "alif@be14.com"})
+			if err != nil {
+				return err
+			}
+			_, err = r.Books.Add(ctx, res.ID, book.Core{Judul: "One Piece", TahunTerbit: 1997, Penulis: "Eiichiro Oda"})
+			return err
+		})
+		require.NoError(t, err)
+
+		_, err = users.Profile(ctx, 1)
+		assert.NoError(t, err)
+		_, err = books.Detail(ctx, 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback bila fn gagal", func(t *testing.T) {
+		u, users, _ := newUoW(t)
+		err := u.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+			if _, err := r.Users.Register(ctx, user.Core{Nama: "alif", Email: "alif@be14.com"}); err != nil {
+				return err
+			}
+			return errBatal
+		})
+		assert.ErrorIs(t, err, errBatal)
+
+		_, err = users.Profile(ctx, 1)
+		assert.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("repository biasa ikut transaksi lewat ctx", func(t *testing.T) {
+		u, users, _ := newUoW(t)
+		err := u.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+			if _, err := users.Register(ctx, user.Core{Nama: "alif", Email: "alif@be14.com"}); err != nil {
+				return err
+			}
+			// perubahan terlihat dari repository transaksi sebelum commit
+			if _, err := r.Users.Profile(ctx, 1); err != nil {
+				return err
+			}
+			return errBatal
+		})
+		assert.ErrorIs(t, err, errBatal)
+
+		_, err = users.Profile(ctx, 1)
+		assert.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("unit of work bersarang memakai savepoint", func(t *testing.T) {
+		u, users, _ := newUoW(t)
+		err := u.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+			if _, err := r.Users.Register(ctx, user.Core{Nama: "alif", Email: "alif@be14.com"}); err != nil {
+				return err
+			}
+			err := u.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+				if _, err := r.Users.Register(ctx, user.Core{Nama: "budi", Email: "budi@be14.com"}); err != nil {
+					return err
+				}
+				return errBatal
+			})
+			assert.ErrorIs(t, err, errBatal)
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, err = users.Profile(ctx, 1)
+		assert.NoError(t, err)
+		_, err = users.Profile(ctx, 2)
+		assert.ErrorContains(t, err, "not found")
+	})
+}
+
+func TestAfterCommit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("dijalankan setelah commit", func(t *testing.T) {
+		u, users, _ := newUoW(t)
+		committed := false
+		err := u.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+			if _, err := r.Users.Register(ctx, user.Core{Nama: "alif", Email: "alif@be14.com"}); err != nil {
+				return err
+			}
+			uow.AfterCommit(ctx, func(ctx context.Context) {
+				// perubahan sudah terlihat dari luar transaksi
+				_, err := users.Profile(ctx, 1)
+				committed = err == nil
+			})
+			assert.False(t, committed)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.True(t, committed)
+	})
+
+	t.Run("dibuang bila rollback", func(t *testing.T) {
+		u, _, _ := newUoW(t)
+		called := false
+		err := u.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+			uow.AfterCommit(ctx, func(ctx context.Context) { called = true })
+			return errBatal
+		})
+		assert.ErrorIs(t, err, errBatal)
+		assert.False(t, called)
+	})
+
+	t.Run("savepoint menunggu transaksi terluar", func(t *testing.T) {
+		u, _, _ := newUoW(t)
+		called := []string{}
+		err := u.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+			_ = u.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+				uow.AfterCommit(ctx, func(ctx context.Context) { called = append(called, "batal") })
+				return errBatal
+			})
+			err := u.Do(ctx, func(ctx context.Context, r uow.Repos) error {
+				uow.AfterCommit(ctx, func(ctx context.Context) { called = append(called, "berhasil") })
+				return nil
+			})
+			assert.Empty(t, called)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"berhasil"}, called)
+	})
+
+	t.Run("di luar unit of work langsung dijalankan", func(t *testing.T) {
+		called := false
+		uow.AfterCommit(ctx, func(ctx context.Context) { called = true })
+		assert.True(t, called)
+		assert.False(t, uow.InTx(ctx))
+	})
+}